@@ -7,14 +7,23 @@ const (
 	LabelCacheEnabled     = "cache.kyverno.io/enabled"
 	LabelCertManagedBy    = "cert.kyverno.io/managed-by"
 	LabelCleanupTtl       = "cleanup.kyverno.io/ttl"
+	LabelPolicyBundle     = "policybundle.kyverno.io/name"
 	LabelWebhookManagedBy = "webhook.kyverno.io/managed-by"
 	// Well known annotations
 	AnnotationAutogenControllers       = "pod-policies.kyverno.io/autogen-controllers"
 	AnnotationImageVerify              = "kyverno.io/verify-images"
 	AnnotationPolicyCategory           = "policies.kyverno.io/category"
+	AnnotationPolicyDescription        = "policies.kyverno.io/description"
+	AnnotationPolicyMinVersion         = "policies.kyverno.io/minversion"
 	AnnotationPolicyScored             = "policies.kyverno.io/scored"
 	AnnotationPolicySeverity           = "policies.kyverno.io/severity"
+	AnnotationPolicySubject            = "policies.kyverno.io/subject"
+	AnnotationPolicyTitle              = "policies.kyverno.io/title"
+	AnnotationKubernetesVersion        = "kyverno.io/kubernetes-version"
+	AnnotationKyvernoVersion           = "kyverno.io/kyverno-version"
 	AnnotationCleanupPropagationPolicy = "cleanup.kyverno.io/propagation-policy"
+	AnnotationPolicyBundleDigest       = "policybundle.kyverno.io/digest"
+	AnnotationExplain                  = "kyverno.io/explain"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
@@ -3,11 +3,13 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	kjson "github.com/kyverno/kyverno-json/pkg/apis/policy/v1alpha1"
 	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/pss/utils"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -401,6 +403,11 @@ type Mutation struct {
 	// ForEach applies mutation rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 	// +optional
 	ForEachMutation []ForEachMutation `json:"foreach,omitempty"`
+
+	// CEL allows mutation using the common expression language.
+	// See https://kubernetes.io/docs/reference/using-api/cel/ for more details.
+	// +optional
+	CEL *MutationCEL `json:"cel,omitempty"`
 }
 
 func (m *Mutation) GetPatchStrategicMerge() apiextensions.JSON {
@@ -513,6 +520,12 @@ type Validation struct {
 	// +optional
 	Deny *Deny `json:"deny,omitempty"`
 
+	// Collection loads all the objects of the given kinds in the `collection` variable,
+	// allowing the rule to check the resource for consistency against other resources.
+	// Collection rules are only evaluated in background scans.
+	// +optional
+	Collection *Collection `json:"collection,omitempty"`
+
 	// PodSecurity applies exemptions for Kubernetes Pod Security admission
 	// by specifying exclusions for Pod Security Standards controls.
 	// +optional
@@ -522,6 +535,11 @@ type Validation struct {
 	// +optional
 	CEL *CEL `json:"cel,omitempty"`
 
+	// JSONSchema validates the resource, or a sub-document selected with a JMESPath expression, against a JSON Schema.
+	// JSON Schema draft-04, draft-06 and draft-07 are supported.
+	// +optional
+	JSONSchema *JSONSchema `json:"jsonSchema,omitempty"`
+
 	// Assert defines a kyverno-json assertion tree.
 	// +optional
 	Assert AssertionTree `json:"assert"`
@@ -615,6 +633,84 @@ type CEL struct {
 	Variables []admissionregistrationv1beta1.Variable `json:"variables,omitempty"`
 }
 
+// PatchType specifies the type of patch produced by a CEL mutation.
+// +kubebuilder:validation:Enum=ApplyConfiguration;JSONPatch
+type PatchType string
+
+const (
+	// PatchTypeApplyConfiguration merges a partial object produced by the expression into the resource with server side apply semantics.
+	PatchTypeApplyConfiguration PatchType = "ApplyConfiguration"
+	// PatchTypeJSONPatch applies RFC 6902 JSON Patch operations produced by the expression to the resource.
+	PatchTypeJSONPatch PatchType = "JSONPatch"
+)
+
+// MutationCEL defines mutations using CEL expressions.
+type MutationCEL struct {
+	// Mutations is a list of CEL mutations applied in order, each mutation sees the resource
+	// as modified by the previous ones.
+	Mutations []CELMutation `json:"mutations,omitempty"`
+
+	// Variables contain definitions of variables that can be used in composition of other expressions.
+	// Each variable is defined as a named CEL expression.
+	// The variables defined here will be available under `variables` in other expressions of the policy.
+	// +optional
+	Variables []admissionregistrationv1beta1.Variable `json:"variables,omitempty"`
+}
+
+// CELMutation is a single CEL mutation producing either an apply configuration or JSON patch operations.
+type CELMutation struct {
+	// PatchType indicates the patch strategy used.
+	// Allowed values are "ApplyConfiguration" and "JSONPatch".
+	PatchType PatchType `json:"patchType"`
+
+	// ApplyConfiguration defines the desired configuration values of an object.
+	// Required when patchType is ApplyConfiguration.
+	// +optional
+	ApplyConfiguration *ApplyConfiguration `json:"applyConfiguration,omitempty"`
+
+	// JSONPatch defines a JSON patch operation to perform a mutation to the object.
+	// Required when patchType is JSONPatch.
+	// +optional
+	JSONPatch *JSONPatch `json:"jsonPatch,omitempty"`
+}
+
+// ApplyConfiguration defines the desired configuration values of an object.
+// Unlike MutatingAdmissionPolicy, the expression returns an untyped map, the typed `Object{...}`
+// form is not supported. The result is merged with server side apply semantics: lists are merged
+// according to their list type, setting atomic lists, maps or structs is rejected and null values are ignored.
+// Custom resources are merged structurally and their lists are atomic.
+// Kyverno anchors are not interpreted.
+type ApplyConfiguration struct {
+	// Expression is a CEL expression that evaluates to a partial object, for example
+	// `{"metadata": {"labels": {"env": "prod"}}}`. The result is merged into the resource
+	// with server side apply semantics.
+	Expression string `json:"expression,omitempty"`
+}
+
+// JSONPatch defines JSON patch operations produced by a CEL expression.
+// Unlike MutatingAdmissionPolicy, operations are untyped maps, the typed `JSONPatch{...}` form is not supported.
+type JSONPatch struct {
+	// Expression is a CEL expression that evaluates to a list of JSON patch operations, for example
+	// `[{"op": "add", "path": "/metadata/labels/env", "value": "prod"}]`.
+	// See https://tools.ietf.org/html/rfc6902.
+	Expression string `json:"expression,omitempty"`
+}
+
+// GetExpression returns the expression of the mutation according to its patch type.
+func (m CELMutation) GetExpression() string {
+	switch m.PatchType {
+	case PatchTypeApplyConfiguration:
+		if m.ApplyConfiguration != nil {
+			return m.ApplyConfiguration.Expression
+		}
+	case PatchTypeJSONPatch:
+		if m.JSONPatch != nil {
+			return m.JSONPatch.Expression
+		}
+	}
+	return ""
+}
+
 func (c *CEL) HasParam() bool {
 	return c.ParamKind != nil && c.ParamRef != nil
 }
@@ -700,6 +796,31 @@ func (d *Deny) SetAnyAllConditions(in any) {
 	d.RawAnyAllConditions = new
 }
 
+// Collection specifies the objects a cross-resource validation rule is evaluated against.
+type Collection struct {
+	// Kinds is a list of kinds of the objects loaded in the collection.
+	// Kinds are specified the same way as in match and exclude blocks,
+	// wildcards and subresources are not allowed.
+	Kinds []string `json:"kinds"`
+}
+
+// Validate implements programmatic validation
+func (c *Collection) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(c.Kinds) == 0 {
+		errs = append(errs, field.Required(path.Child("kinds"), "at least one kind is required"))
+	}
+	for i, kind := range c.Kinds {
+		if kind == "" {
+			errs = append(errs, field.Required(path.Child("kinds").Index(i), "kind must not be empty"))
+		} else if strings.Contains(kind, "*") {
+			errs = append(errs, field.Invalid(path.Child("kinds").Index(i), kind, "wildcards are not allowed in collection kinds"))
+		} else if _, _, _, subresource := kubeutils.ParseKindSelector(kind); subresource != "" {
+			errs = append(errs, field.Invalid(path.Child("kinds").Index(i), kind, "subresources are not allowed in collection kinds"))
+		}
+	}
+	return errs
+}
+
 // ForEachValidation applies validate rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 type ForEachValidation struct {
 	// List specifies a JMESPath expression that results in one or more elements
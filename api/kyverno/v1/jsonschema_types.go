@@ -0,0 +1,114 @@
+package v1
+
+import (
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// JSONSchema validates a document against a JSON Schema.
+// The schema is either declared inline or referenced from a ConfigMap or an OCI artifact.
+// Schemas are validated with JSON Schema draft-04, draft-06 or draft-07, later drafts (2019-09, 2020-12) are not supported.
+type JSONSchema struct {
+	// Selector is a JMESPath expression evaluated against the rule context that selects the document to validate.
+	// String results (an annotation or a ConfigMap data key for example) are parsed as JSON or YAML.
+	// The rule is skipped when the selector returns no document.
+	// Defaults to `request.object`.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// Schema is an inline JSON Schema.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Schema *apiextv1.JSON `json:"schema,omitempty"`
+
+	// ConfigMap references a ConfigMap key holding the JSON Schema.
+	// +optional
+	ConfigMap *JSONSchemaConfigMapReference `json:"configMap,omitempty"`
+
+	// OCI references an OCI artifact holding the JSON Schema in a layer
+	// with the `application/schema+json` media type.
+	// +optional
+	OCI *JSONSchemaOCIReference `json:"oci,omitempty"`
+}
+
+// JSONSchemaConfigMapReference refers to a ConfigMap key holding a JSON Schema
+type JSONSchemaConfigMapReference struct {
+	// Name is the ConfigMap name.
+	Name string `json:"name"`
+
+	// Namespace is the ConfigMap namespace.
+	// For namespaced policies it defaults to the policy namespace and can't reference another namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Key is the ConfigMap data key holding the schema.
+	Key string `json:"key"`
+}
+
+// JSONSchemaOCIReference refers to an OCI artifact holding a JSON Schema
+type JSONSchemaOCIReference struct {
+	// Reference is the OCI artifact reference.
+	// Tags are resolved to a digest at most every 5 minutes, pin the reference by digest to avoid the resolution.
+	// Example: ghcr.io/acme/schemas/app-config:v1
+	Reference string `json:"reference"`
+
+	// ImageRegistryCredentials provides credentials that will be used for authentication with registry
+	// +kubebuilder:validation:Optional
+	ImageRegistryCredentials *ImageRegistryCredentials `json:"imageRegistryCredentials,omitempty"`
+}
+
+// GetSelector returns the selector of the document to validate, defaulting to the admitted resource.
+func (s *JSONSchema) GetSelector() string {
+	if s.Selector == "" {
+		return "request.object"
+	}
+	return s.Selector
+}
+
+// Binding returns the ConfigMap reference with defaults applied for a policy in the given namespace.
+func (c JSONSchemaConfigMapReference) Binding(policyNamespace string) JSONSchemaConfigMapReference {
+	if policyNamespace != "" && c.Namespace == "" {
+		c.Namespace = policyNamespace
+	}
+	return c
+}
+
+// Validate implements programmatic validation
+func (s *JSONSchema) Validate(path *field.Path, policyNamespace string) (errs field.ErrorList) {
+	count := 0
+	if s.Schema != nil {
+		count++
+	}
+	if s.ConfigMap != nil {
+		count++
+		errs = append(errs, s.ConfigMap.Validate(path.Child("configMap"), policyNamespace)...)
+	}
+	if s.OCI != nil {
+		count++
+		if s.OCI.Reference == "" {
+			errs = append(errs, field.Required(path.Child("oci").Child("reference"), "a reference is required"))
+		}
+	}
+	if count != 1 {
+		errs = append(errs, field.Invalid(path, s, "exactly one of schema, configMap or oci must be specified"))
+	}
+	return errs
+}
+
+// Validate implements programmatic validation
+func (c *JSONSchemaConfigMapReference) Validate(path *field.Path, policyNamespace string) (errs field.ErrorList) {
+	if c.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "a name is required"))
+	}
+	if c.Key == "" {
+		errs = append(errs, field.Required(path.Child("key"), "a key is required"))
+	}
+	if policyNamespace == "" && c.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("namespace"), "a namespace is required for cluster policies"))
+	}
+	if policyNamespace != "" && c.Namespace != "" && c.Namespace != policyNamespace {
+		errs = append(errs, field.Forbidden(path.Child("namespace"), "a namespaced policy can only reference schemas in its own namespace"))
+	}
+	return errs
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_Validate_PolicyParams(t *testing.T) {
+	path := field.NewPath("dummy")
+
+	subject := PolicyParams{Kind: "ConfigMap", Name: "params"}
+	assert.Equal(t, len(subject.Validate(path, "")), 0)
+	assert.Equal(t, len(subject.Validate(path, "team")), 0)
+
+	subject = PolicyParams{APIVersion: "a/b/c"}
+	errs := subject.Validate(path, "")
+	assert.Equal(t, len(errs), 3)
+	assert.Equal(t, errs[0].Field, "dummy.apiVersion")
+	assert.Equal(t, errs[1].Field, "dummy.kind")
+	assert.Equal(t, errs[2].Field, "dummy.name")
+
+	subject = PolicyParams{Kind: "ConfigMap", Name: "params", Namespace: "other"}
+	assert.Equal(t, len(subject.Validate(path, "")), 0)
+	errs = subject.Validate(path, "team")
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.namespace")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+}
+
+func Test_PolicyParams_Binding(t *testing.T) {
+	subject := PolicyParams{Kind: "ConfigMap", Name: "params"}
+	assert.DeepEqual(t, subject.Binding(""), PolicyParams{APIVersion: "v1", Kind: "ConfigMap", Name: "params"})
+	assert.DeepEqual(t, subject.Binding("team"), PolicyParams{APIVersion: "v1", Kind: "ConfigMap", Name: "params", Namespace: "team"})
+
+	subject = PolicyParams{APIVersion: "example.io/v1", Kind: "TeamParams", Name: "params", Namespace: "shared"}
+	assert.DeepEqual(t, subject.Binding(""), subject)
+}
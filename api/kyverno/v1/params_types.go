@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PolicyParams references the object holding the parameters of a policy.
+// The referenced object is made available to all rules of the policy in the `params` variable.
+// Kyverno watches the kind of the referenced object in all namespaces, custom resources require
+// get, list and watch permissions to be granted to the admission, background and reports controllers.
+type PolicyParams struct {
+	// APIVersion of the parameters object, defaults to `v1`.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the parameters object, it can be a ConfigMap or any custom resource.
+	Kind string `json:"kind"`
+
+	// Name of the parameters object.
+	Name string `json:"name"`
+
+	// Namespace of the parameters object, leave empty for cluster scoped objects.
+	// For namespaced policies it defaults to the policy namespace and can't reference another namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Binding returns the parameters reference with defaults applied for a policy in the given namespace.
+func (p PolicyParams) Binding(policyNamespace string) PolicyParams {
+	if p.APIVersion == "" {
+		p.APIVersion = "v1"
+	}
+	if policyNamespace != "" && p.Namespace == "" {
+		p.Namespace = policyNamespace
+	}
+	return p
+}
+
+// Validate implements programmatic validation
+func (p *PolicyParams) Validate(path *field.Path, policyNamespace string) (errs field.ErrorList) {
+	if p.APIVersion != "" {
+		if _, err := schema.ParseGroupVersion(p.APIVersion); err != nil {
+			errs = append(errs, field.Invalid(path.Child("apiVersion"), p.APIVersion, err.Error()))
+		}
+	}
+	if p.Kind == "" {
+		errs = append(errs, field.Required(path.Child("kind"), "a kind is required"))
+	}
+	if p.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "a name is required"))
+	}
+	if policyNamespace != "" && p.Namespace != "" && p.Namespace != policyNamespace {
+		errs = append(errs, field.Forbidden(path.Child("namespace"), "a namespaced policy can only reference parameters in its own namespace"))
+	}
+	return errs
+}
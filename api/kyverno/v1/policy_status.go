@@ -31,6 +31,9 @@ type PolicyStatus struct {
 	// ValidatingAdmissionPolicy contains status information
 	// +optional
 	ValidatingAdmissionPolicy ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy"`
+	// Params is the parameters object bound to the policy
+	// +optional
+	Params *PolicyParams `json:"params,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
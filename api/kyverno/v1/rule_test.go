@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -1137,3 +1138,57 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
 	}
 }
+
+func Test_ValidateCollection(t *testing.T) {
+	path := field.NewPath("dummy")
+
+	subject := Rule{Validation: &Validation{Collection: &Collection{Kinds: []string{"NetworkPolicy", "networking.k8s.io/v1/Ingress"}}}}
+	assert.Equal(t, len(subject.ValidateCollection(path)), 0)
+
+	subject = Rule{Validation: &Validation{Collection: &Collection{}}}
+	errs := subject.ValidateCollection(path)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.validate.collection.kinds")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeRequired)
+
+	subject = Rule{Validation: &Validation{Collection: &Collection{Kinds: []string{"", "*", "Pod/status"}}}}
+	errs = subject.ValidateCollection(path)
+	assert.Equal(t, len(errs), 3)
+	assert.Equal(t, errs[0].Field, "dummy.validate.collection.kinds[0]")
+	assert.Equal(t, errs[1].Field, "dummy.validate.collection.kinds[1]")
+	assert.Equal(t, errs[2].Field, "dummy.validate.collection.kinds[2]")
+}
+
+func Test_ValidateJSONSchema(t *testing.T) {
+	path := field.NewPath("dummy")
+
+	subject := Rule{Validation: &Validation{JSONSchema: &JSONSchema{Schema: &apiextv1.JSON{Raw: []byte(`{"type": "object"}`)}}}}
+	assert.Equal(t, len(subject.ValidateJSONSchema(path, "")), 0)
+
+	subject = Rule{Validation: &Validation{JSONSchema: &JSONSchema{ConfigMap: &JSONSchemaConfigMapReference{Name: "schemas", Key: "app.json"}}}}
+	assert.Equal(t, len(subject.ValidateJSONSchema(path, "team-a")), 0)
+	errs := subject.ValidateJSONSchema(path, "")
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.validate.jsonSchema.configMap.namespace")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeRequired)
+
+	subject = Rule{Validation: &Validation{JSONSchema: &JSONSchema{ConfigMap: &JSONSchemaConfigMapReference{Name: "schemas", Namespace: "team-b", Key: "app.json"}}}}
+	errs = subject.ValidateJSONSchema(path, "team-a")
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.validate.jsonSchema.configMap.namespace")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+
+	subject = Rule{Validation: &Validation{JSONSchema: &JSONSchema{}}}
+	errs = subject.ValidateJSONSchema(path, "")
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.validate.jsonSchema")
+
+	subject = Rule{Validation: &Validation{JSONSchema: &JSONSchema{
+		Schema: &apiextv1.JSON{Raw: []byte(`{"type": "object"}`)},
+		OCI:    &JSONSchemaOCIReference{},
+	}}}
+	errs = subject.ValidateJSONSchema(path, "")
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.validate.jsonSchema.oci.reference")
+	assert.Equal(t, errs[1].Field, "dummy.validate.jsonSchema")
+}
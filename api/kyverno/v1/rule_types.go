@@ -124,6 +124,11 @@ func (r *Rule) HasMutateStandard() bool {
 	return r.HasMutate()
 }
 
+// HasMutateCEL checks for mutate.cel rule
+func (r *Rule) HasMutateCEL() bool {
+	return r.Mutation != nil && r.Mutation.CEL != nil && !datautils.DeepEqual(*r.Mutation.CEL, MutationCEL{})
+}
+
 // HasMutateExisting checks if the mutate rule applies to existing resources
 func (r *Rule) HasMutateExisting() bool {
 	return r.Mutation != nil && r.Mutation.Targets != nil
@@ -182,6 +187,16 @@ func (r *Rule) HasValidateAssert() bool {
 	return r.Validation != nil && !datautils.DeepEqual(r.Validation.Assert, AssertionTree{})
 }
 
+// HasValidateCollection checks for validate.collection rule
+func (r *Rule) HasValidateCollection() bool {
+	return r.Validation != nil && r.Validation.Collection != nil
+}
+
+// HasValidateJSONSchema checks for validate.jsonSchema rule
+func (r *Rule) HasValidateJSONSchema() bool {
+	return r.Validation != nil && r.Validation.JSONSchema != nil
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return r.Validation != nil && !datautils.DeepEqual(*r.Validation, Validation{})
@@ -450,6 +465,22 @@ func (r *Rule) ValidatePSaControlNames(path *field.Path) (errs field.ErrorList)
 	return errs
 }
 
+// ValidateCollection checks the collection of a validate.collection rule
+func (r *Rule) ValidateCollection(path *field.Path) (errs field.ErrorList) {
+	if !r.HasValidateCollection() {
+		return nil
+	}
+	return r.Validation.Collection.Validate(path.Child("validate").Child("collection"))
+}
+
+// ValidateJSONSchema checks the schema source of a validate.jsonSchema rule
+func (r *Rule) ValidateJSONSchema(path *field.Path, policyNamespace string) (errs field.ErrorList) {
+	if !r.HasValidateJSONSchema() {
+		return nil
+	}
+	return r.Validation.JSONSchema.Validate(path.Child("validate").Child("jsonSchema"), policyNamespace)
+}
+
 func (r *Rule) ValidateGenerate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	if !r.HasGenerate() {
 		return nil
@@ -467,5 +498,7 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.ValidateMutationRuleTargetNamespace(path, namespaced, policyNamespace)...)
 	errs = append(errs, r.ValidatePSaControlNames(path)...)
 	errs = append(errs, r.ValidateGenerate(path, namespaced, policyNamespace, clusterResources)...)
+	errs = append(errs, r.ValidateCollection(path)...)
+	errs = append(errs, r.ValidateJSONSchema(path, policyNamespace)...)
 	return errs
 }
@@ -89,6 +89,22 @@ func (in *AnyAllConditions) DeepCopy() *AnyAllConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyConfiguration) DeepCopyInto(out *ApplyConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyConfiguration.
+func (in *ApplyConfiguration) DeepCopy() *ApplyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Attestation) DeepCopyInto(out *Attestation) {
 	*out = *in
@@ -256,6 +272,32 @@ func (in *CEL) DeepCopy() *CEL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELMutation) DeepCopyInto(out *CELMutation) {
+	*out = *in
+	if in.ApplyConfiguration != nil {
+		in, out := &in.ApplyConfiguration, &out.ApplyConfiguration
+		*out = new(ApplyConfiguration)
+		**out = **in
+	}
+	if in.JSONPatch != nil {
+		in, out := &in.JSONPatch, &out.JSONPatch
+		*out = new(JSONPatch)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELMutation.
+func (in *CELMutation) DeepCopy() *CELMutation {
+	if in == nil {
+		return nil
+	}
+	out := new(CELMutation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CTLog) DeepCopyInto(out *CTLog) {
 	*out = *in
@@ -401,6 +443,27 @@ func (in *ClusterPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Collection) DeepCopyInto(out *Collection) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Collection.
+func (in *Collection) DeepCopy() *Collection {
+	if in == nil {
+		return nil
+	}
+	out := new(Collection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -929,6 +992,90 @@ func (in *ImageVerification) DeepCopy() *ImageVerification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPatch) DeepCopyInto(out *JSONPatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatch.
+func (in *JSONPatch) DeepCopy() *JSONPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchema) DeepCopyInto(out *JSONSchema) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(JSONSchemaConfigMapReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(JSONSchemaOCIReference)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchema.
+func (in *JSONSchema) DeepCopy() *JSONSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaConfigMapReference) DeepCopyInto(out *JSONSchemaConfigMapReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaConfigMapReference.
+func (in *JSONSchemaConfigMapReference) DeepCopy() *JSONSchemaConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONSchemaConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaOCIReference) DeepCopyInto(out *JSONSchemaOCIReference) {
+	*out = *in
+	if in.ImageRegistryCredentials != nil {
+		in, out := &in.ImageRegistryCredentials, &out.ImageRegistryCredentials
+		*out = new(ImageRegistryCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaOCIReference.
+func (in *JSONSchemaOCIReference) DeepCopy() *JSONSchemaOCIReference {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONSchemaOCIReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeylessAttestor) DeepCopyInto(out *KeylessAttestor) {
 	*out = *in
@@ -1052,6 +1199,11 @@ func (in *Mutation) DeepCopyInto(out *Mutation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = new(MutationCEL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1065,6 +1217,34 @@ func (in *Mutation) DeepCopy() *Mutation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationCEL) DeepCopyInto(out *MutationCEL) {
+	*out = *in
+	if in.Mutations != nil {
+		in, out := &in.Mutations, &out.Mutations
+		*out = make([]CELMutation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]v1beta1.Variable, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationCEL.
+func (in *MutationCEL) DeepCopy() *MutationCEL {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationCEL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectFieldBinding) DeepCopyInto(out *ObjectFieldBinding) {
 	*out = *in
@@ -1201,6 +1381,22 @@ func (in *PolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyParams) DeepCopyInto(out *PolicyParams) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyParams.
+func (in *PolicyParams) DeepCopy() *PolicyParams {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	*out = *in
@@ -1219,6 +1415,11 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
 	out.ValidatingAdmissionPolicy = in.ValidatingAdmissionPolicy
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = new(PolicyParams)
+		**out = **in
+	}
 	return
 }
 
@@ -1580,6 +1781,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(WebhookConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = new(PolicyParams)
+		**out = **in
+	}
 	return
 }
 
@@ -1789,6 +1995,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(Deny)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Collection != nil {
+		in, out := &in.Collection, &out.Collection
+		*out = new(Collection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodSecurity != nil {
 		in, out := &in.PodSecurity, &out.PodSecurity
 		*out = new(PodSecurity)
@@ -1799,6 +2010,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(CEL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JSONSchema != nil {
+		in, out := &in.JSONSchema, &out.JSONSchema
+		*out = new(JSONSchema)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Assert.DeepCopyInto(&out.Assert)
 	return
 }
@@ -0,0 +1,66 @@
+package v2alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PolicyBundleConditionReady means that the policy bundle is synchronized
+	PolicyBundleConditionReady = "Ready"
+)
+
+const (
+	// PolicyBundleReasonSucceeded is the reason set when the policy bundle is synchronized
+	PolicyBundleReasonSucceeded = "Succeeded"
+	// PolicyBundleReasonFailed is the reason set when the policy bundle failed to synchronize
+	PolicyBundleReasonFailed = "Failed"
+)
+
+type PolicyBundleStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Digest is the digest of the last synchronized image
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Indicates the time when the policy bundle was last synchronized successfully
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+	// Resources are the resources synchronized from the bundle
+	// +optional
+	Resources []PolicyBundleResource `json:"resources,omitempty"`
+}
+
+// PolicyBundleResource identifies a resource synchronized from a policy bundle
+type PolicyBundleResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (status *PolicyBundleStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyBundleConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyBundleReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyBundleReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func (status *PolicyBundleStatus) UpdateSyncTime() {
+	status.LastSyncTime = metav1.Now()
+}
+
+// IsReady indicates if the policy bundle is synchronized
+func (status *PolicyBundleStatus) IsReady() bool {
+	condition := meta.FindStatusCondition(status.Conditions, PolicyBundleConditionReady)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v2alpha1
+
+import (
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=pbundle,categories=kyverno,scope="Cluster"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="IMAGE",type=string,JSONPath=".spec.image"
+// +kubebuilder:printcolumn:name="DIGEST",type=string,JSONPath=".status.digest",priority=1
+// +kubebuilder:printcolumn:name="LAST SYNC",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicyBundle declares an OCI policy bundle to be synchronized in the cluster.
+// Policy bundles are created with `kyverno oci push`.
+type PolicyBundle struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares policy bundle behaviors.
+	Spec PolicyBundleSpec `json:"spec"`
+
+	// Status contains policy bundle runtime data.
+	// +optional
+	Status PolicyBundleStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (b *PolicyBundle) Validate() (errs field.ErrorList) {
+	errs = append(errs, b.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// PolicyBundleSpec stores policy bundle spec
+type PolicyBundleSpec struct {
+	// Image is the reference of the OCI policy bundle, either by tag or by digest.
+	Image string `json:"image"`
+
+	// RefreshInterval defines how often the image is polled for changes.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:default="10m"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// Verification declares how the bundle signature is verified.
+	// Unsigned bundles and bundles not signed by the expected key or identity are not synchronized.
+	Verification PolicyBundleVerification `json:"verification"`
+
+	// Prune deletes the resources previously synchronized from the bundle when they are removed from it.
+	// +kubebuilder:default=true
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+}
+
+// GetRefreshInterval returns the polling interval of the bundle image
+func (s *PolicyBundleSpec) GetRefreshInterval() time.Duration {
+	if s.RefreshInterval == nil || s.RefreshInterval.Duration <= 0 {
+		return 10 * time.Minute
+	}
+	return s.RefreshInterval.Duration
+}
+
+// IsPruneEnabled returns true if removed resources must be deleted
+func (s *PolicyBundleSpec) IsPruneEnabled() bool {
+	return s.Prune == nil || *s.Prune
+}
+
+// Validate implements programmatic validation
+func (s *PolicyBundleSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), "An image is required"))
+	} else if _, err := name.ParseReference(s.Image); err != nil {
+		errs = append(errs, field.Invalid(path.Child("image"), s.Image, err.Error()))
+	}
+	errs = append(errs, s.Verification.Validate(path.Child("verification"))...)
+	return errs
+}
+
+// PolicyBundleVerification declares the expected signer of a policy bundle
+// +kubebuilder:oneOf:={required:{keys}}
+// +kubebuilder:oneOf:={required:{keyless}}
+type PolicyBundleVerification struct {
+	// Keys specifies one or more public keys.
+	// +kubebuilder:validation:Optional
+	Keys *kyvernov1.StaticKeyAttestor `json:"keys,omitempty"`
+
+	// Keyless is a set of attribute used to verify a Sigstore keyless signature.
+	// +kubebuilder:validation:Optional
+	Keyless *kyvernov1.KeylessAttestor `json:"keyless,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (v *PolicyBundleVerification) Validate(path *field.Path) (errs field.ErrorList) {
+	if (v.Keys == nil) == (v.Keyless == nil) {
+		errs = append(errs, field.Invalid(path, v, "Either keys or keyless is required"))
+		return errs
+	}
+	if v.Keys != nil {
+		if v.Keys.PublicKeys == "" && v.Keys.KMS == "" && v.Keys.Secret == nil {
+			errs = append(errs, field.Invalid(path.Child("keys"), v.Keys, "Either publicKeys, kms or secret is required"))
+		}
+	}
+	if v.Keyless != nil {
+		if v.Keyless.Subject == "" && v.Keyless.SubjectRegExp == "" {
+			errs = append(errs, field.Required(path.Child("keyless", "subject"), "Either subject or subjectRegExp is required"))
+		}
+		if v.Keyless.Issuer == "" && v.Keyless.IssuerRegExp == "" {
+			errs = append(errs, field.Required(path.Child("keyless", "issuer"), "Either issuer or issuerRegExp is required"))
+		}
+	}
+	return errs
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyBundleList is a list of Policy Bundles
+type PolicyBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicyBundle `json:"items"`
+}
@@ -0,0 +1,119 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestPolicyBundleSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    PolicyBundleSpec
+		wantErr bool
+	}{
+		{
+			name: "valid keys",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/policies:v1",
+				Verification: PolicyBundleVerification{
+					Keys: &kyvernov1.StaticKeyAttestor{
+						PublicKeys: "-----BEGIN PUBLIC KEY-----",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid keyless",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/policies@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				Verification: PolicyBundleVerification{
+					Keyless: &kyvernov1.KeylessAttestor{
+						Subject: "https://github.com/kyverno/policies/.github/workflows/release.yaml@refs/heads/main",
+						Issuer:  "https://token.actions.githubusercontent.com",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing image",
+			spec: PolicyBundleSpec{
+				Verification: PolicyBundleVerification{
+					Keys: &kyvernov1.StaticKeyAttestor{
+						KMS: "awskms:///alias/policies",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid image",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/Policies:v1",
+				Verification: PolicyBundleVerification{
+					Keys: &kyvernov1.StaticKeyAttestor{
+						KMS: "awskms:///alias/policies",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing verification",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/policies:v1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "both keys and keyless",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/policies:v1",
+				Verification: PolicyBundleVerification{
+					Keys: &kyvernov1.StaticKeyAttestor{
+						KMS: "awskms:///alias/policies",
+					},
+					Keyless: &kyvernov1.KeylessAttestor{
+						Subject: "https://github.com/kyverno/policies/.github/workflows/release.yaml@refs/heads/main",
+						Issuer:  "https://token.actions.githubusercontent.com",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty keys",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/policies:v1",
+				Verification: PolicyBundleVerification{
+					Keys: &kyvernov1.StaticKeyAttestor{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "keyless without issuer",
+			spec: PolicyBundleSpec{
+				Image: "ghcr.io/kyverno/policies:v1",
+				Verification: PolicyBundleVerification{
+					Keyless: &kyvernov1.KeylessAttestor{
+						Subject: "https://github.com/kyverno/policies/.github/workflows/release.yaml@refs/heads/main",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.Validate(field.NewPath("spec"))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("PolicyBundleSpec.Validate() error = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
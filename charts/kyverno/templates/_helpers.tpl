@@ -52,6 +52,9 @@
 {{- with .dumpPatches -}}
   {{- $flags = append $flags (print "--dumpPatches=" .enabled) -}}
 {{- end -}}
+{{- with .explainAdmission -}}
+  {{- $flags = append $flags (print "--explainAdmission=" .enabled) -}}
+{{- end -}}
 {{- with .globalContext -}}
   {{- $flags = append $flags (print "--maxAPICallResponseLength=" (int .maxApiCallResponseLength)) -}}
 {{- end -}}
@@ -64,6 +67,9 @@
     {{- $flags = append $flags (print "--omitEvents=" (join "," .)) -}}
   {{- end -}}
 {{- end -}}
+{{- with .policyBundles -}}
+  {{- $flags = append $flags (print "--enablePolicyBundles=" .enabled) -}}
+{{- end -}}
 {{- with .policyExceptions -}}
   {{- $flags = append $flags (print "--enablePolicyException=" .enabled) -}}
   {{- with .namespace -}}
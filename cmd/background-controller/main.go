@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/collection"
 	"github.com/kyverno/kyverno/pkg/config"
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
@@ -188,12 +189,21 @@ func main() {
 			kyvernoInformer.Kyverno().V1().Policies(),
 			&wg,
 		)
+		// params store
+		paramsStore := collection.NewStore(
+			signalCtx,
+			setup.Logger.WithName("params-store"),
+			setup.KyvernoDynamicClient.GetDynamicInterface(),
+			setup.KyvernoDynamicClient.Discovery(),
+		)
 		engine := internal.NewEngine(
 			signalCtx,
 			setup.Logger,
 			setup.Configuration,
 			setup.MetricsConfiguration,
 			setup.Jp,
+			setup.CELCache,
+			setup.SchemaCache,
 			setup.KyvernoDynamicClient,
 			setup.RegistryClient,
 			setup.ImageVerifyCacheClient,
@@ -203,6 +213,8 @@ func main() {
 			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
 			polexCache,
 			gcstore,
+			nil,
+			paramsStore,
 		)
 		ephrs, err := breaker.StartBackgroundReportsCounter(signalCtx, setup.MetadataClient)
 		if err != nil {
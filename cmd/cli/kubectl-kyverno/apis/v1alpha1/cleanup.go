@@ -0,0 +1,36 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CleanupSpec declares cleanup policies and TTL labels evaluation in a test
+type CleanupSpec struct {
+	// Policies are the cleanup policies to be used in the test
+	Policies []string `json:"policies,omitempty"`
+
+	// Time is the simulated current time used to evaluate cleanup policies and TTL labels.
+	// Defaults to the current time.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Results are the cleanup results to be checked in the test
+	Results []CleanupResult `json:"results,omitempty"`
+}
+
+// CleanupResult declares a cleanup test result
+type CleanupResult struct {
+	// Policy mentions the name of the cleanup policy, in the form `<namespace>/<name>` for namespaced policies.
+	// Leave it empty to check resources deleted because of the `cleanup.kyverno.io/ttl` label.
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// Kind mentions the kind of the resources.
+	Kind string `json:"kind"`
+
+	// Resources are the names of the resources, in the form `<namespace>/<name>` for namespaced resources.
+	Resources []string `json:"resources"`
+
+	// Deleted tells if the resources are expected to be deleted.
+	Deleted bool `json:"deleted"`
+}
@@ -27,6 +27,9 @@ type Test struct {
 	// Target Resources are for policies that have mutate existing
 	TargetResources []string `json:"targetResources,omitempty"`
 
+	// HelmValues are values files used to render the Helm charts referenced in resources and target resources
+	HelmValues []string `json:"helmValues,omitempty"`
+
 	// Variables is the values to be used in the test
 	Variables string `json:"variables,omitempty"`
 
@@ -44,6 +47,13 @@ type Test struct {
 
 	// Policy Exceptions are the policy exceptions to be used in the test
 	PolicyExceptions []string `json:"exceptions,omitempty"`
+
+	// ImageLayouts are OCI image layout directories serving images, signatures and attestations
+	// in place of remote registries
+	ImageLayouts []string `json:"imageLayouts,omitempty"`
+
+	// Cleanup declares cleanup policies and TTL labels to be evaluated against the test resources
+	Cleanup *CleanupSpec `json:"cleanup,omitempty"`
 }
 
 type CheckResult struct {
@@ -0,0 +1,276 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Runner runs a test case and returns the status of its results, keyed by policy.
+type Runner func(test.TestCase) (map[string]Status, error)
+
+// Build loads the policies and the tests found under root and builds the catalog.
+// Test status is computed only if a runner is provided.
+func Build(root string, fileName string, run Runner) (*Catalog, error) {
+	policies, err := loadPolicies(root)
+	if err != nil {
+		return nil, err
+	}
+	catalog := newCatalog(policies)
+	tests, err := test.LoadTests(root, fileName)
+	if err != nil {
+		return nil, err
+	}
+	for _, testCase := range tests {
+		if testCase.Err != nil {
+			return nil, fmt.Errorf("failed to load test %s (%w)", testCase.Path, testCase.Err)
+		}
+		if err := catalog.addExamples(testCase); err != nil {
+			return nil, err
+		}
+		if run != nil {
+			catalog.addStatus(testCase, run)
+		}
+	}
+	return &catalog, nil
+}
+
+func loadPolicies(root string) ([]*Policy, error) {
+	var policies []*Policy
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// skip hidden files and dirs, as the policy loader does
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		content, err := os.ReadFile(path) // #nosec G304
+		if err != nil {
+			return err
+		}
+		if !containsPolicies(content) {
+			return nil
+		}
+		results, err := policy.Load(nil, "", path)
+		if err != nil {
+			return fmt.Errorf("failed to load policies from %s (%w)", path, err)
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		for _, p := range results.Policies {
+			policies = append(policies, newPolicy(abs, filepath.ToSlash(rel), p))
+		}
+		return nil
+	})
+	return policies, err
+}
+
+// containsPolicies returns true if one of the documents is a kyverno Policy or ClusterPolicy,
+// resources and other documents living next to policies are ignored.
+func containsPolicies(content []byte) bool {
+	documents, err := extyaml.SplitDocuments(content)
+	if err != nil {
+		return false
+	}
+	for _, document := range documents {
+		var meta metav1.TypeMeta
+		if err := yaml.Unmarshal(document, &meta); err != nil {
+			continue
+		}
+		if !strings.HasPrefix(meta.APIVersion, kyvernov1.GroupName+"/") {
+			continue
+		}
+		if meta.Kind == "Policy" || meta.Kind == "ClusterPolicy" {
+			return true
+		}
+	}
+	return false
+}
+
+// find looks up the policy referenced by a test result, namespaced policies
+// can be referenced with or without their namespace. When the same policy is
+// declared in multiple files, the one loaded by the test is preferred.
+func (c *Catalog) find(testCase test.TestCase, reference string) *Policy {
+	var candidates []*Policy
+	for _, policy := range c.Policies() {
+		if policy.Key == reference || (policy.Namespace != "" && policy.Name == reference) {
+			candidates = append(candidates, policy)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	for _, path := range testCase.Test.Policies {
+		path, err := filepath.Abs(filepath.Join(testCase.Dir(), path))
+		if err != nil {
+			continue
+		}
+		for _, candidate := range candidates {
+			if candidate.file == path || strings.HasPrefix(candidate.file, path+string(filepath.Separator)) {
+				return candidate
+			}
+		}
+	}
+	return candidates[0]
+}
+
+func (c *Catalog) addExamples(testCase test.TestCase) error {
+	var manifests []*unstructured.Unstructured
+	for _, path := range testCase.Test.Resources {
+		if source.IsHttp(path) {
+			continue
+		}
+		path = filepath.Join(testCase.Dir(), path)
+		// kustomizations and charts are rendered by the test command only
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(path) // #nosec G304
+		if err != nil {
+			return fmt.Errorf("failed to read resources of test %s (%w)", testCase.Path, err)
+		}
+		resources, err := resource.GetUnstructuredResources(content)
+		if err != nil {
+			return fmt.Errorf("failed to load resources of test %s (%w)", testCase.Path, err)
+		}
+		manifests = append(manifests, resources...)
+	}
+	for _, result := range testCase.Test.Results {
+		policy := c.find(testCase, result.Policy)
+		if policy == nil {
+			continue
+		}
+		policy.Tested = true
+		status := result.Result
+		if status == "" {
+			status = result.Status
+		}
+		if status != policyreportv1alpha2.StatusPass && status != policyreportv1alpha2.StatusFail {
+			continue
+		}
+		references := result.Resources
+		if result.Resource != "" {
+			references = append(references, result.Resource)
+		}
+		for _, reference := range references {
+			manifest := findManifest(manifests, result.Kind, reference)
+			if manifest == nil {
+				continue
+			}
+			policy.addExample(Example{
+				Result:   string(status),
+				Rule:     result.Rule,
+				Resource: describe(manifest),
+				Manifest: marshal(manifest),
+			})
+		}
+	}
+	return nil
+}
+
+func (p *Policy) addExample(example Example) {
+	for _, existing := range p.Examples {
+		if existing.Result == example.Result && existing.Rule == example.Rule && existing.Resource == example.Resource {
+			return
+		}
+	}
+	p.Examples = append(p.Examples, example)
+}
+
+func (c *Catalog) addStatus(testCase test.TestCase, run Runner) {
+	statuses, err := run(testCase)
+	for _, result := range testCase.Test.Results {
+		policy := c.find(testCase, result.Policy)
+		if policy == nil {
+			continue
+		}
+		if err != nil {
+			policy.Status.Error = err.Error()
+			continue
+		}
+		status, ok := statuses[result.Policy]
+		if !ok {
+			continue
+		}
+		policy.Status.Pass += status.Pass
+		policy.Status.Fail += status.Fail
+		policy.Status.Skip += status.Skip
+		// statuses are aggregated per policy reference, count them once
+		delete(statuses, result.Policy)
+	}
+}
+
+func findManifest(manifests []*unstructured.Unstructured, kind string, reference any) *unstructured.Unstructured {
+	var namespace, name string
+	switch reference := reference.(type) {
+	case string:
+		if parts := strings.Split(reference, "/"); len(parts) == 2 {
+			namespace, name = parts[0], parts[1]
+		} else {
+			name = reference
+		}
+	case map[string]any:
+		namespace, _ = reference["namespace"].(string)
+		name, _ = reference["name"].(string)
+	case v1alpha1.TestResourceSpec:
+		namespace, name = reference.Namespace, reference.Name
+	}
+	for _, manifest := range manifests {
+		if kind != "" && manifest.GetKind() != kind {
+			continue
+		}
+		if namespace != "" && manifest.GetNamespace() != namespace {
+			continue
+		}
+		if manifest.GetName() == name {
+			return manifest
+		}
+	}
+	return nil
+}
+
+func describe(manifest *unstructured.Unstructured) string {
+	if manifest.GetNamespace() == "" {
+		return manifest.GetKind() + "/" + manifest.GetName()
+	}
+	return manifest.GetKind() + "/" + manifest.GetNamespace() + "/" + manifest.GetName()
+}
+
+func marshal(manifest *unstructured.Unstructured) string {
+	data, err := yaml.Marshal(manifest.Object)
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(data))
+}
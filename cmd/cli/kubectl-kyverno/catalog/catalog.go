@@ -0,0 +1,250 @@
+package catalog
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy/annotations"
+	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/pod-security-admission/api"
+)
+
+const uncategorized = "Other"
+
+type Catalog struct {
+	Categories []Category
+}
+
+type Category struct {
+	Name     string
+	Policies []*Policy
+}
+
+type Policy struct {
+	Key               string
+	Page              string
+	Name              string
+	Namespace         string
+	Kind              string
+	Path              string
+	Title             string
+	Category          string
+	Severity          string
+	Subject           string
+	Description       string
+	MinVersion        string
+	KyvernoVersion    string
+	KubernetesVersion string
+	Background        bool
+	Rules             []Rule
+	Examples          []Example
+	Tested            bool
+	Status            Status
+	// file is the absolute path of the file declaring the policy
+	file string
+}
+
+type Rule struct {
+	Name          string
+	Type          string
+	Kinds         []string
+	FailureAction string
+	PodSecurity   *PodSecurity
+}
+
+type PodSecurity struct {
+	Level      string
+	Version    string
+	Controls   []string
+	Exclusions []string
+}
+
+type Example struct {
+	Result   string
+	Rule     string
+	Resource string
+	Manifest string
+}
+
+type Status struct {
+	Pass  int
+	Fail  int
+	Skip  int
+	Error string
+}
+
+func (s Status) Total() int {
+	return s.Pass + s.Fail + s.Skip
+}
+
+func (s Status) Passed() bool {
+	return s.Error == "" && s.Fail == 0
+}
+
+// Policies returns the catalog policies across all categories.
+func (c Catalog) Policies() []*Policy {
+	var policies []*Policy
+	for _, category := range c.Categories {
+		policies = append(policies, category.Policies...)
+	}
+	return policies
+}
+
+func newCatalog(policies []*Policy) Catalog {
+	categories := map[string][]*Policy{}
+	pages := map[string]int{}
+	for _, policy := range policies {
+		// the same policy can be declared in multiple files, give each one its own page
+		page := strings.ReplaceAll(policy.Key, "/", "-")
+		pages[page]++
+		policy.Page = page
+		if pages[page] > 1 {
+			policy.Page = fmt.Sprintf("%s-%d", page, pages[page])
+		}
+		name := policy.Category
+		if name == "" {
+			name = uncategorized
+		}
+		categories[name] = append(categories[name], policy)
+	}
+	var catalog Catalog
+	for name, policies := range categories {
+		slices.SortFunc(policies, func(a, b *Policy) int {
+			return cmp.Or(cmp.Compare(a.Title, b.Title), cmp.Compare(a.Key, b.Key))
+		})
+		catalog.Categories = append(catalog.Categories, Category{Name: name, Policies: policies})
+	}
+	slices.SortFunc(catalog.Categories, func(a, b Category) int {
+		// keep uncategorized policies last
+		if a.Name == uncategorized {
+			return 1
+		}
+		if b.Name == uncategorized {
+			return -1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return catalog
+}
+
+func newPolicy(file, path string, policy kyvernov1.PolicyInterface) *Policy {
+	metadata := policy.GetAnnotations()
+	spec := policy.GetSpec()
+	out := &Policy{
+		Key:               key(policy.GetNamespace(), policy.GetName()),
+		Name:              policy.GetName(),
+		Namespace:         policy.GetNamespace(),
+		Kind:              policy.GetKind(),
+		Path:              path,
+		file:              file,
+		Title:             annotations.Title(metadata),
+		Category:          annotations.Category(metadata),
+		Severity:          string(annotations.Severity(metadata)),
+		Subject:           annotations.Subject(metadata),
+		Description:       annotations.Description(metadata),
+		MinVersion:        annotations.MinVersion(metadata),
+		KyvernoVersion:    annotations.KyvernoVersion(metadata),
+		KubernetesVersion: annotations.KubernetesVersion(metadata),
+		Background:        spec.BackgroundProcessingEnabled(),
+	}
+	if out.Title == "" {
+		out.Title = policy.GetName()
+	}
+	for _, rule := range spec.Rules {
+		out.Rules = append(out.Rules, newRule(spec, rule))
+	}
+	return out
+}
+
+func newRule(spec *kyvernov1.Spec, rule kyvernov1.Rule) Rule {
+	out := Rule{
+		Name:  rule.Name,
+		Kinds: kinds(rule),
+	}
+	switch {
+	case rule.HasValidate():
+		out.Type = "validate"
+		out.FailureAction = string(failureAction(spec, rule.Validation.FailureAction))
+		if rule.HasValidatePodSecurity() {
+			out.PodSecurity = podSecurity(rule.Validation.PodSecurity)
+		}
+	case rule.HasVerifyImages():
+		out.Type = "verifyImages"
+		actions := sets.New[string]()
+		for _, iv := range rule.VerifyImages {
+			actions.Insert(string(failureAction(spec, iv.FailureAction)))
+		}
+		out.FailureAction = joinSorted(actions)
+	case rule.HasMutate():
+		out.Type = "mutate"
+	case rule.HasGenerate():
+		out.Type = "generate"
+	}
+	return out
+}
+
+func failureAction(spec *kyvernov1.Spec, action *kyvernov1.ValidationFailureAction) kyvernov1.ValidationFailureAction {
+	if action != nil && action.IsValid() {
+		if action.Enforce() {
+			return kyvernov1.Enforce
+		}
+		return kyvernov1.Audit
+	}
+	if spec.ValidationFailureAction.Enforce() {
+		return kyvernov1.Enforce
+	}
+	return kyvernov1.Audit
+}
+
+func kinds(rule kyvernov1.Rule) []string {
+	kinds := sets.New(rule.MatchResources.Kinds...)
+	for _, filter := range rule.MatchResources.Any {
+		kinds.Insert(filter.Kinds...)
+	}
+	for _, filter := range rule.MatchResources.All {
+		kinds.Insert(filter.Kinds...)
+	}
+	return sets.List(kinds)
+}
+
+func podSecurity(pss *kyvernov1.PodSecurity) *PodSecurity {
+	out := &PodSecurity{
+		Level:   string(pss.Level),
+		Version: pss.Version,
+	}
+	if out.Version == "" {
+		out.Version = "latest"
+	}
+	switch pss.Level {
+	case api.LevelBaseline:
+		out.Controls = pssutils.PSS_baseline_control_names
+	case api.LevelRestricted:
+		out.Controls = append(slices.Clone(pssutils.PSS_baseline_control_names), pssutils.PSS_restricted_control_names...)
+	}
+	for _, exclude := range pss.Exclude {
+		exclusion := exclude.ControlName
+		if exclude.RestrictedField != "" {
+			exclusion += " (" + exclude.RestrictedField + ")"
+		}
+		if len(exclude.Images) > 0 {
+			exclusion += " for images " + joinSorted(sets.New(exclude.Images...))
+		}
+		out.Exclusions = append(out.Exclusions, exclusion)
+	}
+	return out
+}
+
+func key(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func joinSorted(values sets.Set[string]) string {
+	return strings.Join(sets.List(values), ", ")
+}
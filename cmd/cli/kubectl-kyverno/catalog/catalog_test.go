@@ -0,0 +1,242 @@
+package catalog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-latest-tag
+  annotations:
+    policies.kyverno.io/title: Disallow Latest Tag
+    policies.kyverno.io/category: Best Practices
+    policies.kyverno.io/severity: medium
+    policies.kyverno.io/subject: Pod
+    policies.kyverno.io/minversion: 1.6.0
+    policies.kyverno.io/description: >-
+      The ':latest' tag is mutable.
+spec:
+  validationFailureAction: Enforce
+  background: true
+  rules:
+  - name: validate-image-tag
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      failureAction: Audit
+      message: Using a mutable image tag e.g. 'latest' is not allowed.
+      pattern:
+        spec:
+          containers:
+          - image: "!*:latest"
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: baseline
+  namespace: team
+spec:
+  background: false
+  validationFailureAction: Enforce
+  rules:
+  - name: baseline
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      podSecurity:
+        level: baseline
+        version: v1.29
+        exclude:
+        - controlName: Host Ports
+          images:
+          - nginx
+`
+
+const resources = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: good
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.2
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: bad
+spec:
+  containers:
+  - name: nginx
+    image: nginx:latest
+`
+
+const kyvernoTest = `
+apiVersion: cli.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+policies:
+- ../policies.yaml
+resources:
+- resources.yaml
+results:
+- policy: disallow-latest-tag
+  rule: validate-image-tag
+  kind: Pod
+  resources:
+  - good
+  result: pass
+- policy: disallow-latest-tag
+  rule: validate-image-tag
+  kind: Pod
+  resources:
+  - bad
+  result: fail
+- policy: baseline
+  rule: baseline
+  kind: Pod
+  resources:
+  - good
+  result: skip
+`
+
+func setup(t *testing.T) string {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policies.yaml"), []byte(policies), 0o600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "tests"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tests", "resources.yaml"), []byte(resources), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tests", "kyverno-test.yaml"), []byte(kyvernoTest), 0o600))
+	return dir
+}
+
+func TestBuild(t *testing.T) {
+	dir := setup(t)
+	runner := func(test.TestCase) (map[string]Status, error) {
+		return map[string]Status{
+			"disallow-latest-tag": {Pass: 2},
+			"baseline":            {Skip: 1},
+		}, nil
+	}
+	catalog, err := Build(dir, "kyverno-test.yaml", runner)
+	assert.NoError(t, err)
+	assert.Len(t, catalog.Categories, 2)
+	assert.Equal(t, "Best Practices", catalog.Categories[0].Name)
+	assert.Equal(t, uncategorized, catalog.Categories[1].Name)
+
+	latest := catalog.Categories[0].Policies[0]
+	assert.Equal(t, "disallow-latest-tag", latest.Key)
+	assert.Equal(t, "disallow-latest-tag", latest.Page)
+	assert.Equal(t, "Disallow Latest Tag", latest.Title)
+	assert.Equal(t, "medium", latest.Severity)
+	assert.Equal(t, "Pod", latest.Subject)
+	assert.Equal(t, "1.6.0", latest.MinVersion)
+	assert.Equal(t, "The ':latest' tag is mutable.", latest.Description)
+	assert.Equal(t, "policies.yaml", latest.Path)
+	assert.True(t, latest.Background)
+	assert.Equal(t, []Rule{{
+		Name:          "validate-image-tag",
+		Type:          "validate",
+		Kinds:         []string{"Pod"},
+		FailureAction: "Audit",
+	}}, latest.Rules)
+	assert.Len(t, latest.Examples, 2)
+	assert.Equal(t, "pass", latest.Examples[0].Result)
+	assert.Equal(t, "Pod/default/good", latest.Examples[0].Resource)
+	assert.Contains(t, latest.Examples[0].Manifest, "image: nginx:1.2")
+	assert.Equal(t, "fail", latest.Examples[1].Result)
+	assert.Equal(t, "Pod/default/bad", latest.Examples[1].Resource)
+	assert.True(t, latest.Tested)
+	assert.Equal(t, Status{Pass: 2}, latest.Status)
+
+	baseline := catalog.Categories[1].Policies[0]
+	assert.Equal(t, "team/baseline", baseline.Key)
+	assert.Equal(t, "team-baseline", baseline.Page)
+	assert.Equal(t, "baseline", baseline.Title)
+	assert.False(t, baseline.Background)
+	assert.Equal(t, "Enforce", baseline.Rules[0].FailureAction)
+	assert.NotNil(t, baseline.Rules[0].PodSecurity)
+	assert.Equal(t, "baseline", baseline.Rules[0].PodSecurity.Level)
+	assert.Equal(t, "v1.29", baseline.Rules[0].PodSecurity.Version)
+	assert.Contains(t, baseline.Rules[0].PodSecurity.Controls, "Host Ports")
+	assert.Equal(t, []string{"Host Ports for images nginx"}, baseline.Rules[0].PodSecurity.Exclusions)
+	assert.Empty(t, baseline.Examples)
+	assert.True(t, baseline.Tested)
+	assert.Equal(t, Status{Skip: 1}, baseline.Status)
+}
+
+func TestBuildWithRunnerError(t *testing.T) {
+	dir := setup(t)
+	runner := func(test.TestCase) (map[string]Status, error) {
+		return nil, errors.New("boom")
+	}
+	catalog, err := Build(dir, "kyverno-test.yaml", runner)
+	assert.NoError(t, err)
+	for _, policy := range catalog.Policies() {
+		assert.Equal(t, "boom", policy.Status.Error)
+		assert.Equal(t, "error: boom", status(policy))
+		assert.Equal(t, "fail", statusClass(policy))
+	}
+}
+
+func TestBuildWithoutRunner(t *testing.T) {
+	dir := setup(t)
+	catalog, err := Build(dir, "kyverno-test.yaml", nil)
+	assert.NoError(t, err)
+	for _, policy := range catalog.Policies() {
+		assert.Equal(t, "not run", status(policy))
+		assert.Equal(t, "", statusClass(policy))
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := setup(t)
+	runner := func(test.TestCase) (map[string]Status, error) {
+		return map[string]Status{"disallow-latest-tag": {Pass: 1, Fail: 1}}, nil
+	}
+	catalog, err := Build(dir, "kyverno-test.yaml", runner)
+	assert.NoError(t, err)
+	out := t.TempDir()
+	for _, format := range Formats {
+		assert.NoError(t, Write(catalog, out, format))
+	}
+	assert.Error(t, Write(catalog, out, "pdf"))
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(out, name))
+		assert.NoError(t, err)
+		return string(content)
+	}
+	index := read("README.md")
+	assert.Contains(t, index, "2 policies in 2 categories.")
+	assert.Contains(t, index, "| [Disallow Latest Tag](disallow-latest-tag.md) | ClusterPolicy | medium | Pod | 1 | 1 failed, 1 passed |")
+	assert.Contains(t, index, "| [baseline](team-baseline.md) | Policy |  |  | 1 | not run |")
+	page := read("disallow-latest-tag.md")
+	assert.Contains(t, page, "| validate-image-tag | validate | Pod | Audit |")
+	assert.Contains(t, page, "## Passing resources")
+	assert.Contains(t, page, "## Failing resources")
+	assert.Contains(t, page, "image: nginx:latest")
+	page = read("team-baseline.md")
+	assert.Contains(t, page, "### Pod Security Standards (rule `baseline`)")
+	assert.Contains(t, page, "- Host Ports for images nginx")
+	html := read("index.html")
+	assert.Contains(t, html, `<a href="disallow-latest-tag.html">Disallow Latest Tag</a>`)
+	assert.Contains(t, html, `<td class="fail">1 failed, 1 passed</td>`)
+	html = read("disallow-latest-tag.html")
+	assert.Contains(t, html, "The &#39;:latest&#39; tag is mutable.")
+	assert.Contains(t, html, "<h2>Failing resources</h2>")
+}
@@ -0,0 +1,123 @@
+package catalog
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+var Formats = []Format{Markdown, HTML}
+
+//go:embed templates
+var templates embed.FS
+
+type executor interface {
+	ExecuteTemplate(io.Writer, string, any) error
+}
+
+var funcs = map[string]any{
+	"page":        page,
+	"status":      status,
+	"statusClass": statusClass,
+	"examples":    examples,
+	"join":        strings.Join,
+}
+
+// Write renders the catalog in the given format, it produces an index page
+// and one page per policy in the output directory.
+func Write(catalog *Catalog, dir string, format Format) error {
+	var tmpl executor
+	var index, ext string
+	switch format {
+	case Markdown:
+		t, err := texttemplate.New("").Funcs(funcs).ParseFS(templates, "templates/*.md.tmpl")
+		if err != nil {
+			return err
+		}
+		tmpl, index, ext = t, "README.md", ".md"
+	case HTML:
+		t, err := htmltemplate.New("").Funcs(funcs).ParseFS(templates, "templates/*.html.tmpl")
+		if err != nil {
+			return err
+		}
+		tmpl, index, ext = t, "index.html", ".html"
+	default:
+		return fmt.Errorf("format not supported %s", format)
+	}
+	if err := os.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return err
+	}
+	if err := execute(tmpl, filepath.Join(dir, index), "index"+ext+".tmpl", catalog); err != nil {
+		return err
+	}
+	for _, policy := range catalog.Policies() {
+		if err := execute(tmpl, filepath.Join(dir, page(policy)+ext), "policy"+ext+".tmpl", policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func execute(tmpl executor, path string, name string, data any) error {
+	file, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := tmpl.ExecuteTemplate(file, name, data); err != nil {
+		return fmt.Errorf("failed to render %s (%w)", path, err)
+	}
+	return nil
+}
+
+// page returns the name of the page documenting a policy, without extension.
+func page(policy *Policy) string {
+	return policy.Page
+}
+
+func status(policy *Policy) string {
+	switch {
+	case !policy.Tested:
+		return "no tests"
+	case policy.Status.Error != "":
+		return "error: " + policy.Status.Error
+	case policy.Status.Total() == 0:
+		return "not run"
+	case policy.Status.Fail > 0:
+		return fmt.Sprintf("%d failed, %d passed", policy.Status.Fail, policy.Status.Pass+policy.Status.Skip)
+	default:
+		return fmt.Sprintf("%d passed", policy.Status.Pass+policy.Status.Skip)
+	}
+}
+
+func statusClass(policy *Policy) string {
+	if !policy.Tested || (policy.Status.Total() == 0 && policy.Status.Error == "") {
+		return ""
+	}
+	if policy.Status.Passed() {
+		return "pass"
+	}
+	return "fail"
+}
+
+func examples(result string, policy *Policy) []Example {
+	var out []Example
+	for _, example := range policy.Examples {
+		if example.Result == result {
+			out = append(out, example)
+		}
+	}
+	return out
+}
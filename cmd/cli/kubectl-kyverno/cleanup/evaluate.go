@@ -0,0 +1,77 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	"github.com/kyverno/kyverno/pkg/controllers/ttl"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// Deletion is a resource that would be deleted by a cleanup policy or because of its ttl label
+type Deletion struct {
+	// Policy is the name of the cleanup policy, in the form <namespace>/<name> for namespaced policies.
+	// It is empty when the resource is deleted because of its ttl label.
+	Policy string
+	// Resource is the deleted resource
+	Resource *unstructured.Unstructured
+}
+
+// Evaluate returns the resources that would be deleted at the given time by the cleanup policies or their ttl label.
+// Cleanup policies are evaluated with the same matching logic as the cleanup controller, the policy schedule is ignored.
+func Evaluate(
+	policies []kyvernov2.CleanupPolicyInterface,
+	resources []*unstructured.Unstructured,
+	now time.Time,
+	namespaceSelectors map[string]map[string]string,
+) ([]Deletion, error) {
+	ctx := context.Background()
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.NewWithClock(cfg, clocktesting.NewFakePassiveClock(now))
+	loaderFactory := factories.DefaultContextLoaderFactory(nil)
+	var deletions []Deletion
+	for _, policy := range policies {
+		name := policy.GetName()
+		if policy.IsNamespaced() {
+			name = policy.GetNamespace() + "/" + name
+		}
+		logger := log.Log.WithValues("policy", name)
+		enginectx := enginecontext.NewContext(jp)
+		if err := loaderFactory(nil, kyvernov1.Rule{}).Load(ctx, jp, nil, nil, policy.GetSpec().Context, enginectx); err != nil {
+			return nil, fmt.Errorf("failed to load context of cleanup policy %s (%w)", name, err)
+		}
+		for _, resource := range resources {
+			// namespaced policies only consider resources in their namespace
+			if policy.IsNamespaced() && resource.GetNamespace() != policy.GetNamespace() {
+				continue
+			}
+			matched, err := cleanup.Matches(logger, enginectx, cfg, policy, *resource, namespaceSelectors[resource.GetNamespace()])
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate cleanup policy %s (%w)", name, err)
+			}
+			if matched {
+				deletions = append(deletions, Deletion{Policy: name, Resource: resource})
+			}
+		}
+	}
+	for _, resource := range resources {
+		deletionTime, err := ttl.DeletionTime(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ttl label of %s/%s (%w)", resource.GetKind(), resource.GetName(), err)
+		}
+		if deletionTime != nil && now.After(*deletionTime) {
+			deletions = append(deletions, Deletion{Resource: resource})
+		}
+	}
+	return deletions, nil
+}
@@ -0,0 +1,112 @@
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const resources = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: old
+  namespace: default
+  creationTimestamp: "2024-05-01T00:00:00Z"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: recent
+  namespace: default
+  creationTimestamp: "2024-05-31T12:00:00Z"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: old
+  namespace: other
+  creationTimestamp: "2024-05-01T00:00:00Z"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: expired
+  namespace: default
+  creationTimestamp: "2024-05-31T00:00:00Z"
+  labels:
+    cleanup.kyverno.io/ttl: 2h
+`
+
+func TestEvaluate(t *testing.T) {
+	policies, err := load([]byte(`
+apiVersion: kyverno.io/v2
+kind: CleanupPolicy
+metadata:
+  name: old-pods
+  namespace: default
+spec:
+  schedule: "* * * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+  conditions:
+    all:
+    - key: "{{ time_since('', '{{ target.metadata.creationTimestamp }}', '') }}"
+      operator: DurationGreaterThan
+      value: 24h`))
+	assert.NoError(t, err)
+	objects, err := resource.GetUnstructuredResources([]byte(resources))
+	assert.NoError(t, err)
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{{
+		name: "before ttl expiration",
+		now:  time.Date(2024, 5, 31, 1, 0, 0, 0, time.UTC),
+		want: []string{"default/old-pods:Pod/default/old"},
+	}, {
+		name: "after ttl expiration",
+		now:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		want: []string{"default/old-pods:Pod/default/old", ":ConfigMap/default/expired"},
+	}, {
+		name: "after recent pod expiration",
+		now:  time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+		want: []string{"default/old-pods:Pod/default/old", "default/old-pods:Pod/default/recent", ":ConfigMap/default/expired"},
+	}, {
+		name: "before creation",
+		now:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		want: nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletions, err := Evaluate([]kyvernov2.CleanupPolicyInterface{policies[0]}, objects, tt.now, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, toStrings(deletions))
+		})
+	}
+}
+
+func TestEvaluateInvalidTtl(t *testing.T) {
+	object := &unstructured.Unstructured{}
+	object.SetKind("ConfigMap")
+	object.SetName("invalid")
+	object.SetLabels(map[string]string{"cleanup.kyverno.io/ttl": "invalid"})
+	_, err := Evaluate(nil, []*unstructured.Unstructured{object}, time.Now(), nil)
+	assert.Error(t, err)
+}
+
+func toStrings(deletions []Deletion) []string {
+	var out []string
+	for _, deletion := range deletions {
+		out = append(out, deletion.Policy+":"+deletion.Resource.GetKind()+"/"+deletion.Resource.GetNamespace()+"/"+deletion.Resource.GetName())
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package cleanup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
+	"github.com/kyverno/kyverno/ext/resource/convert"
+	resourceloader "github.com/kyverno/kyverno/ext/resource/loader"
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+)
+
+var (
+	cleanupPolicyV2beta1        = schema.GroupVersion(kyvernov2beta1.GroupVersion).WithKind("CleanupPolicy")
+	cleanupPolicyV2             = schema.GroupVersion(kyvernov2.GroupVersion).WithKind("CleanupPolicy")
+	clusterCleanupPolicyV2beta1 = schema.GroupVersion(kyvernov2beta1.GroupVersion).WithKind("ClusterCleanupPolicy")
+	clusterCleanupPolicyV2      = schema.GroupVersion(kyvernov2.GroupVersion).WithKind("ClusterCleanupPolicy")
+)
+
+func Load(paths ...string) ([]kyvernov2.CleanupPolicyInterface, error) {
+	var out []kyvernov2.CleanupPolicyInterface
+	for _, path := range paths {
+		bytes, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read yaml (%w)", err)
+		}
+		policies, err := load(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load cleanup policies (%w)", err)
+		}
+		out = append(out, policies...)
+	}
+	return out, nil
+}
+
+func load(content []byte) ([]kyvernov2.CleanupPolicyInterface, error) {
+	documents, err := yamlutils.SplitDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+	var policies []kyvernov2.CleanupPolicyInterface
+	crds, err := data.Crds()
+	if err != nil {
+		return nil, err
+	}
+	factory, err := resourceloader.New(openapiclient.NewComposite(openapiclient.NewLocalCRDFiles(crds)))
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		gvk, untyped, err := factory.Load(document)
+		if err != nil {
+			return nil, err
+		}
+		switch gvk {
+		case cleanupPolicyV2beta1, cleanupPolicyV2:
+			policy, err := convert.To[kyvernov2.CleanupPolicy](untyped)
+			if err != nil {
+				return nil, err
+			}
+			// like kubectl, namespaced policies without namespace are created in the default namespace
+			if policy.GetNamespace() == "" {
+				policy.SetNamespace("default")
+			}
+			policies = append(policies, policy)
+		case clusterCleanupPolicyV2beta1, clusterCleanupPolicyV2:
+			policy, err := convert.To[kyvernov2.ClusterCleanupPolicy](untyped)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, policy)
+		default:
+			return nil, fmt.Errorf("cleanup policy type not supported %s", gvk)
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,80 @@
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_load(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantLoaded int
+		wantErr    bool
+	}{{
+		name: "not a cleanup policy",
+		content: `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: test`,
+		wantErr: true,
+	}, {
+		name: "cleanup policies",
+		content: `
+apiVersion: kyverno.io/v2beta1
+kind: ClusterCleanupPolicy
+metadata:
+  name: cluster
+spec:
+  schedule: "* * * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+---
+apiVersion: kyverno.io/v2
+kind: CleanupPolicy
+metadata:
+  name: namespaced
+spec:
+  schedule: "* * * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod`,
+		wantLoaded: 2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := load([]byte(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, policies, tt.wantLoaded)
+			}
+		})
+	}
+}
+
+func Test_loadDefaultNamespace(t *testing.T) {
+	policies, err := load([]byte(`
+apiVersion: kyverno.io/v2
+kind: CleanupPolicy
+metadata:
+  name: namespaced
+spec:
+  schedule: "* * * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod`))
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+	assert.Equal(t, "default", policies[0].GetNamespace())
+}
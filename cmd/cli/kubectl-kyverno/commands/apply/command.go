@@ -14,12 +14,14 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/configuration"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/render"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
@@ -29,6 +31,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/spf13/cobra"
@@ -61,6 +64,7 @@ type ApplyCommandConfig struct {
 	RegistryAccess        bool
 	AuditWarn             bool
 	ResourcePaths         []string
+	HelmValues            []string
 	PolicyPaths           []string
 	TargetResourcePaths   []string
 	GitBranch             string
@@ -71,10 +75,13 @@ type ApplyCommandConfig struct {
 	inlineExceptions      bool
 	GenerateExceptions    bool
 	GeneratedExceptionTTL time.Duration
+	Explain               bool
+	ConfigPath            string
+	Pipeline              bool
 }
 
 func Command() *cobra.Command {
-	var removeColor, detailedResults, table bool
+	var removeColor, detailedResults, table, watch bool
 	applyCommandConfig := &ApplyCommandConfig{}
 	cmd := &cobra.Command{
 		Use:          "apply",
@@ -86,59 +93,20 @@ func Command() *cobra.Command {
 			out := cmd.OutOrStdout()
 			color.Init(removeColor)
 			applyCommandConfig.PolicyPaths = args
-			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
-			if err != nil {
-				return err
-			}
-			cmd.SilenceErrors = true
-			printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
-			if applyCommandConfig.PolicyReport {
-				printReports(out, responses, applyCommandConfig.AuditWarn)
-			} else if applyCommandConfig.GenerateExceptions {
-				printExceptions(out, responses, applyCommandConfig.AuditWarn, applyCommandConfig.GeneratedExceptionTTL)
-			} else if table {
-				printTable(out, detailedResults, applyCommandConfig.AuditWarn, responses...)
-			} else {
-				for _, response := range responses {
-					var failedRules []engineapi.RuleResponse
-					resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
-					for _, rule := range response.PolicyResponse.Rules {
-						if rule.Status() == engineapi.RuleStatusFail {
-							failedRules = append(failedRules, rule)
-						}
-						if rule.RuleType() == engineapi.Mutation {
-							if rule.Status() == engineapi.RuleStatusSkip {
-								fmt.Fprintln(out, "\nskipped mutate policy", response.Policy().GetName(), "->", "resource", resPath)
-							} else if rule.Status() == engineapi.RuleStatusError {
-								fmt.Fprintln(out, "\nerror while applying mutate policy", response.Policy().GetName(), "->", "resource", resPath, "\nerror: ", rule.Message())
-							}
-						}
-					}
-					if len(failedRules) > 0 {
-						auditWarn := false
-						if applyCommandConfig.AuditWarn && response.GetValidationFailureAction().Audit() {
-							auditWarn = true
-						}
-						if auditWarn {
-							fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed as audit warning:")
-						} else {
-							fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed:")
-						}
-						for i, rule := range failedRules {
-							fmt.Fprintln(out, i+1, "-", rule.Name(), rule.Message())
-						}
-						fmt.Fprintln(out, "")
-					}
-				}
-				printViolations(out, rc)
+			if watch {
+				return applyCommandConfig.watch(cmd.Context(), out, func() ([]engineapi.EngineResponse, error) {
+					return applyCommandConfig.execute(cmd, out, table, detailedResults)
+				})
 			}
-			return exit(out, rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
+			_, err = applyCommandConfig.execute(cmd, out, table, detailedResults)
+			return err
 		},
 	}
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resources", "", []string{}, "Path to resource files")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.TargetResourcePaths, "target-resource", "", []string{}, "Path to individual files containing target resources files for policies that have mutate existing")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.TargetResourcePaths, "target-resources", "", []string{}, "Path to a directory containing target resources files for policies that have mutate existing")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.HelmValues, "helm-values", nil, "Values files used to render Helm charts passed as resources")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated/generated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
@@ -164,9 +132,73 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&applyCommandConfig.inlineExceptions, "exceptions-with-resources", "", false, "Evaluate policy exceptions from the resources path")
 	cmd.Flags().BoolVarP(&applyCommandConfig.GenerateExceptions, "generate-exceptions", "", false, "Generate policy exceptions for each violation")
 	cmd.Flags().DurationVarP(&applyCommandConfig.GeneratedExceptionTTL, "generated-exception-ttl", "", time.Hour*24*30, "Default TTL for generated exceptions")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "If set to true, watch policy, resource and values files, and apply policies again on every change")
+	cmd.Flags().StringVar(&applyCommandConfig.ConfigPath, "config", "", "Path to a Kyverno ConfigMap manifest providing the configuration, including user-defined JMESPath functions")
+	cmd.Flags().BoolVar(&applyCommandConfig.Pipeline, "pipeline", false, "If set to true, only apply the policies evaluated by the admission webhooks (mutate existing rules are skipped) and report whether the resource would be admitted")
+	cmd.Flags().BoolVar(&applyCommandConfig.Explain, "explain", false, "If set to true, display the evaluation trace of every rule (substituted variables, failing pattern anchors, skipped foreach elements)")
 	return cmd
 }
 
+// execute applies policies and prints the results.
+func (c *ApplyCommandConfig) execute(cmd *cobra.Command, out io.Writer, table bool, detailedResults bool) ([]engineapi.EngineResponse, error) {
+	rc, _, skipInvalidPolicies, responses, err := c.applyCommandHelper(out)
+	if err != nil {
+		return nil, err
+	}
+	cmd.SilenceErrors = true
+	printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
+	if c.PolicyReport {
+		printReports(out, responses, c.AuditWarn)
+	} else if c.GenerateExceptions {
+		printExceptions(out, responses, c.AuditWarn, c.GeneratedExceptionTTL)
+	} else if table {
+		printTable(out, detailedResults, c.AuditWarn, responses...)
+		if c.Explain {
+			printExplain(out, responses...)
+		}
+	} else {
+		for _, response := range responses {
+			var failedRules []engineapi.RuleResponse
+			resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
+			if origin := response.Resource.GetAnnotations()[render.AnnotationOrigin]; origin != "" {
+				resPath = fmt.Sprintf("%s (%s)", resPath, origin)
+			}
+			for _, rule := range response.PolicyResponse.Rules {
+				if rule.Status() == engineapi.RuleStatusFail {
+					failedRules = append(failedRules, rule)
+				}
+				if rule.RuleType() == engineapi.Mutation {
+					if rule.Status() == engineapi.RuleStatusSkip {
+						fmt.Fprintln(out, "\nskipped mutate policy", response.Policy().GetName(), "->", "resource", resPath)
+					} else if rule.Status() == engineapi.RuleStatusError {
+						fmt.Fprintln(out, "\nerror while applying mutate policy", response.Policy().GetName(), "->", "resource", resPath, "\nerror: ", rule.Message())
+					}
+				}
+			}
+			if len(failedRules) > 0 {
+				auditWarn := false
+				if c.AuditWarn && response.GetValidationFailureAction().Audit() {
+					auditWarn = true
+				}
+				if auditWarn {
+					fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed as audit warning:")
+				} else {
+					fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed:")
+				}
+				for i, rule := range failedRules {
+					fmt.Fprintln(out, i+1, "-", rule.Name(), rule.Message())
+				}
+				fmt.Fprintln(out, "")
+			}
+		}
+		if c.Explain {
+			printExplain(out, responses...)
+		}
+		printViolations(out, rc)
+	}
+	return responses, exit(out, rc, c.warnExitCode, c.warnNoPassed)
+}
+
 func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error) {
 	rc, resources1, skipInvalidPolicies, responses1, err := c.checkArguments()
 	if err != nil {
@@ -319,6 +351,11 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 		vars.SetInStore(store)
 	}
 	var rc processor.ResultCounts
+	cfg, err := configuration.Load(c.ConfigPath)
+	if err != nil {
+		return &rc, resources, nil, err
+	}
+	jp := jmespath.New(cfg)
 	// validate policies
 	validPolicies := make([]kyvernov1.PolicyInterface, 0, len(policies))
 	for _, pol := range policies {
@@ -359,6 +396,10 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 			AuditWarn:            c.AuditWarn,
 			Subresources:         vars.Subresources(),
 			Out:                  out,
+			Explain:              c.Explain,
+			Configuration:        cfg,
+			JMESPath:             jp,
+			Pipeline:             c.Pipeline,
 		}
 		ers, err := processor.ApplyPoliciesOnResource()
 		if err != nil {
@@ -379,11 +420,20 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 }
 
 func (c *ApplyCommandConfig) loadResources(out io.Writer, paths []string, policies []kyvernov1.PolicyInterface, vap []admissionregistrationv1beta1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
+	var rendered []*unstructured.Unstructured
+	if !c.Cluster {
+		var err error
+		// kustomizations and charts are rendered, other paths are loaded as usual
+		rendered, paths, err = render.Render(paths, render.Options{HelmValues: c.HelmValues})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load resources (%w)", err)
+		}
+	}
 	resources, err := common.GetResourceAccordingToResourcePath(out, nil, paths, c.Cluster, policies, vap, dClient, c.Namespace, c.PolicyReport, "")
 	if err != nil {
 		return resources, fmt.Errorf("failed to load resources (%w)", err)
 	}
-	return resources, nil
+	return append(rendered, resources...), nil
 }
 
 func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPolicies) (*processor.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, []kyvernov1.PolicyInterface, []admissionregistrationv1beta1.ValidatingAdmissionPolicy, []admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding, error) {
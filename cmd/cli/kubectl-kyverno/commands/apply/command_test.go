@@ -454,6 +454,35 @@ func TestCommand(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCommandExplain(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"../../_testdata/apply/explain/policy.yaml",
+		"--resource",
+		"../../_testdata/apply/explain/resources.yaml",
+		"--explain",
+	})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `
+explain policy explain -> resource default/Pod/bad
+  rule team-label (Validation): fail
+   - variable: {{ team }} = ""
+   - variable: {{ team }} = ""
+  rule host-network (Validation): fail
+   - pattern: pattern failed by anchor /spec/=(hostNetwork): resource value 'true' does not match 'false' at path /spec/hostNetwork/
+  rule image-registry (Validation): fail
+   - variable: {{ element.name }} = "sidecar"
+   - foreach: element skipped: preconditions not met at request.object.spec.containers[0]
+   - variable: {{ element.name }} = "app"
+   - pattern: pattern failed: resource value 'docker.io/app' does not match 'registry.io/*' at path /image/`
+	assert.Contains(t, string(out), strings.TrimSpace(expected))
+}
+
 func TestCommandWithInvalidArg(t *testing.T) {
 	cmd := Command()
 	assert.NotNil(t, cmd)
@@ -516,3 +545,26 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestCommandWatchWithStdin(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"-", "--resource", "resources.yaml", "--watch"})
+	assert.Error(t, cmd.Execute())
+	assert.Equal(t, "Error: watch mode can't be used with stdin", strings.TrimSpace(b.String()))
+}
+
+func TestWatchedPaths(t *testing.T) {
+	config := ApplyCommandConfig{
+		PolicyPaths:   []string{"policies"},
+		ResourcePaths: []string{"resources.yaml"},
+		ValuesFile:    "values.yaml",
+	}
+	paths, err := config.watchedPaths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"policies", "resources.yaml", "values.yaml"}, paths)
+	config.PolicyPaths = []string{"https://github.com/kyverno/policies/main"}
+	_, err = config.watchedPaths()
+	assert.Error(t, err)
+}
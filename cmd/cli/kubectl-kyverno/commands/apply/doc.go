@@ -4,6 +4,14 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#apply`
 
 var description = []string{
 	`Applies policies on resources.`,
+	``,
+	`Policies are applied in the same order as the admission webhooks: mutation, image verification and validation,`,
+	`every policy operates on the resource produced by the previous one.`,
+	``,
+	`Resources can be kustomizations or local Helm charts. Charts are rendered without the Helm SDK,`,
+	`charts with dependencies or subcharts and library charts are rejected, .Capabilities reports`,
+	`Kubernetes v1.31.0 and charts using hooks, lookup, .Capabilities.APIVersions.Has or .Files.Glob`,
+	`fail with an error. Values are validated against values.schema.json.`,
 }
 
 var examples = [][]string{
@@ -15,6 +23,14 @@ var examples = [][]string{
 		"# Apply on a folder of resources",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/",
 	},
+	{
+		"# Apply on a kustomization overlay",
+		"kyverno apply /path/to/policy.yaml --resource=/path/to/overlays/production",
+	},
+	{
+		"# Apply on a local Helm chart rendered with values files",
+		"kyverno apply /path/to/policy.yaml --resource=/path/to/chart --helm-values=/path/to/values.yaml --helm-values=/path/to/production.yaml",
+	},
 	{
 		"# Apply on a cluster",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster",
@@ -31,4 +47,16 @@ var examples = [][]string{
 		"# Apply multiple policy with variable on multiple resource",
 		"kyverno apply /path/to/policy1.yaml /path/to/policy2.yaml --resource /path/to/resource1.yaml --resource /path/to/resource2.yaml -f /path/to/value.yaml",
 	},
+	{
+		"# Apply on a resource and explain how every rule was evaluated",
+		"kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --explain",
+	},
+	{
+		"# Apply the policies evaluated at admission and check the final resource is admitted",
+		"kyverno apply /path/to/policies --resource /path/to/resource.yaml --pipeline",
+	},
+	{
+		"# Apply policies again every time a policy or resource file changes",
+		"kyverno apply /path/to/policies --resource /path/to/resources --watch",
+	},
 }
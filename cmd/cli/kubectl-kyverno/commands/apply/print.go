@@ -166,3 +166,24 @@ func wildcardTagOrDigest(images []string) []string {
 func printViolations(out io.Writer, rc *processor.ResultCounts) {
 	fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n", rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 }
+
+func printExplain(out io.Writer, engineResponses ...engineapi.EngineResponse) {
+	for _, response := range engineResponses {
+		resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
+		printed := false
+		for _, rule := range response.PolicyResponse.Rules {
+			trace := rule.Trace()
+			if len(trace) == 0 {
+				continue
+			}
+			if !printed {
+				fmt.Fprintln(out, "\nexplain policy", response.Policy().GetName(), "->", "resource", resPath)
+				printed = true
+			}
+			fmt.Fprintf(out, "  rule %s (%s): %s\n", rule.Name(), rule.RuleType(), rule.Status())
+			for _, entry := range trace {
+				fmt.Fprintln(out, "   -", entry.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// watch applies policies, then applies them again on every change to the local files passed
+// to the command, until interrupted.
+func (c *ApplyCommandConfig) watch(ctx context.Context, out io.Writer, execute func() ([]engineapi.EngineResponse, error)) error {
+	paths, err := c.watchedPaths()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	var previous watch.Results
+	run := func(changed []string) {
+		if changed != nil {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Detected changes in", len(changed), "file(s), applying policies ...")
+		}
+		responses, err := execute()
+		if err != nil {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Error:", err)
+		}
+		// results are not comparable when policies could not be applied
+		if responses != nil || err == nil {
+			current := applyResults(responses)
+			if previous != nil {
+				watch.PrintDiff(out, previous, current)
+			}
+			previous = current
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Watching for changes, press Ctrl+C to stop ...")
+	}
+	run(nil)
+	return watch.Run(ctx, func() []string { return paths }, run)
+}
+
+// watchedPaths returns the local files and directories the command depends on.
+func (c *ApplyCommandConfig) watchedPaths() ([]string, error) {
+	if c.Stdin {
+		return nil, fmt.Errorf("watch mode can't be used with stdin")
+	}
+	var paths []string
+	add := func(files ...string) error {
+		for _, file := range files {
+			switch {
+			case file == "":
+			case file == "-":
+				return fmt.Errorf("watch mode can't be used with stdin")
+			case source.IsGit(file) || source.IsHttp(file):
+				return fmt.Errorf("watch mode is only supported for local files (%s)", file)
+			default:
+				paths = append(paths, file)
+			}
+		}
+		return nil
+	}
+	for _, files := range [][]string{
+		c.PolicyPaths,
+		c.ResourcePaths,
+		c.TargetResourcePaths,
+		c.HelmValues,
+		c.Exception,
+		{c.ValuesFile, c.UserInfoPath, c.ConfigPath},
+	} {
+		if err := add(files...); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// applyResults returns the status of every rule applied to every resource.
+func applyResults(responses []engineapi.EngineResponse) watch.Results {
+	results := watch.Results{}
+	for _, response := range responses {
+		resource := response.Resource
+		for _, rule := range response.PolicyResponse.Rules {
+			key := fmt.Sprintf("%s/%s %s/%s/%s", response.Policy().GetName(), rule.Name(), resource.GetNamespace(), resource.GetKind(), resource.GetName())
+			results[key] = string(rule.Status())
+		}
+	}
+	return results
+}
@@ -0,0 +1,373 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Latency summarizes a set of durations.
+type Latency struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// RuleReport holds the measurements of a single rule.
+type RuleReport struct {
+	Rule        string  `json:"rule"`
+	Type        string  `json:"type"`
+	Evaluations int     `json:"evaluations"`
+	Latency     Latency `json:"latency"`
+	// ContextLoading is the mean time spent loading context entries per evaluation
+	ContextLoading time.Duration `json:"contextLoading"`
+}
+
+// PolicyReport holds the measurements of a single policy.
+type PolicyReport struct {
+	Policy      string  `json:"policy"`
+	Evaluations int     `json:"evaluations"`
+	Latency     Latency `json:"latency"`
+	// Allocs is the mean number of heap allocations per evaluation
+	Allocs uint64 `json:"allocs"`
+	// Bytes is the mean number of bytes allocated per evaluation
+	Bytes uint64 `json:"bytes"`
+	// JMESPath is the mean number of JMESPath evaluations per evaluation
+	JMESPath float64      `json:"jmespath"`
+	Rules    []RuleReport `json:"rules,omitempty"`
+}
+
+// Report is the result of a benchmark run, it is also the format of baseline files.
+type Report struct {
+	Iterations int            `json:"iterations"`
+	Resources  int            `json:"resources"`
+	Policies   []PolicyReport `json:"policies"`
+}
+
+// Regression is a metric that increased by more than the allowed threshold compared to the baseline.
+type Regression struct {
+	Policy string `json:"policy"`
+	Rule   string `json:"rule,omitempty"`
+	// Metric is either p95 (latency, in nanoseconds) or allocs
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	// Increase is the increase in percent
+	Increase float64 `json:"increase"`
+}
+
+type runner struct {
+	store      *store.Store
+	variables  *variables.Variables
+	iterations int
+	warmup     int
+}
+
+type ruleKey struct {
+	name     string
+	ruleType engineapi.RuleType
+}
+
+type policyRecorder struct {
+	durations      []time.Duration
+	allocs         uint64
+	bytes          uint64
+	jmespath       int64
+	rules          map[ruleKey][]time.Duration
+	lock           sync.Mutex
+	contextLoading map[ruleKey]time.Duration
+}
+
+func (r *policyRecorder) recordContextLoading(rule ruleKey, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.contextLoading[rule] += duration
+}
+
+func (r *runner) run(policies []kyvernov1.PolicyInterface, resources []*unstructured.Unstructured) (*Report, error) {
+	report := Report{
+		Iterations: r.iterations,
+		Resources:  len(resources),
+	}
+	for _, policy := range policies {
+		policyReport, err := r.runPolicy(policy, resources)
+		if err != nil {
+			return nil, err
+		}
+		report.Policies = append(report.Policies, *policyReport)
+	}
+	sort.Slice(report.Policies, func(i, j int) bool {
+		return report.Policies[i].Policy < report.Policies[j].Policy
+	})
+	return &report, nil
+}
+
+func (r *runner) runPolicy(policy kyvernov1.PolicyInterface, resources []*unstructured.Unstructured) (*PolicyReport, error) {
+	recorder := &policyRecorder{
+		rules:          map[ruleKey][]time.Duration{},
+		contextLoading: map[ruleKey]time.Duration{},
+	}
+	cfg := config.NewDefaultConfiguration(false)
+	for iteration := 0; iteration < r.warmup+r.iterations; iteration++ {
+		measure := iteration >= r.warmup
+		for _, resource := range resources {
+			var jmespathCount int64
+			var rc processor.ResultCounts
+			processor := processor.PolicyProcessor{
+				Store:                r.store,
+				Policies:             []kyvernov1.PolicyInterface{policy},
+				Resource:             *resource,
+				Variables:            r.variables,
+				NamespaceSelectorMap: r.variables.NamespaceSelectors(),
+				Rc:                   &rc,
+				Subresources:         r.variables.Subresources(),
+				Out:                  io.Discard,
+				JMESPath:             countingJMESPath{inner: jmespath.New(cfg), count: &jmespathCount},
+				ContextLoaderFactory: timedContextLoaderFactory(store.ContextLoaderFactory(r.store, nil), func(rule ruleKey, duration time.Duration) {
+					if measure {
+						recorder.recordContextLoading(rule, duration)
+					}
+				}),
+			}
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			start := time.Now()
+			responses, err := processor.ApplyPoliciesOnResource()
+			duration := time.Since(start)
+			runtime.ReadMemStats(&after)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply policy %s on resource %s (%w)", policy.GetName(), resource.GetName(), err)
+			}
+			if !measure {
+				continue
+			}
+			recorder.durations = append(recorder.durations, duration)
+			recorder.allocs += after.Mallocs - before.Mallocs
+			recorder.bytes += after.TotalAlloc - before.TotalAlloc
+			recorder.jmespath += jmespathCount
+			for _, response := range responses {
+				for _, rule := range response.PolicyResponse.Rules {
+					key := ruleKey{name: rule.Name(), ruleType: rule.RuleType()}
+					recorder.rules[key] = append(recorder.rules[key], rule.Stats().ProcessingTime())
+				}
+			}
+		}
+	}
+	return recorder.report(policyName(policy)), nil
+}
+
+func (r *policyRecorder) report(policy string) *PolicyReport {
+	evaluations := len(r.durations)
+	report := &PolicyReport{
+		Policy:      policy,
+		Evaluations: evaluations,
+		Latency:     newLatency(r.durations),
+	}
+	if evaluations != 0 {
+		report.Allocs = r.allocs / uint64(evaluations)
+		report.Bytes = r.bytes / uint64(evaluations)
+		report.JMESPath = float64(r.jmespath) / float64(evaluations)
+	}
+	for key, durations := range r.rules {
+		report.Rules = append(report.Rules, RuleReport{
+			Rule:           key.name,
+			Type:           string(key.ruleType),
+			Evaluations:    len(durations),
+			Latency:        newLatency(durations),
+			ContextLoading: r.contextLoading[key] / time.Duration(len(durations)),
+		})
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		a, b := report.Rules[i], report.Rules[j]
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Type < b.Type
+	})
+	return report
+}
+
+func newLatency(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return Latency{
+		Min:  sorted[0],
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 50),
+		P90:  percentile(sorted, 90),
+		P95:  percentile(sorted, 95),
+		P99:  percentile(sorted, 99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile uses the nearest-rank method, sorted must not be empty
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Compare returns the policies and rules whose p95 latency or allocations increased by more than threshold percent.
+// Latency increases smaller than minDelta are ignored as they are usually noise.
+func Compare(baseline, current Report, threshold float64, minDelta time.Duration) []Regression {
+	baselinePolicies := map[string]PolicyReport{}
+	for _, policy := range baseline.Policies {
+		baselinePolicies[policy.Policy] = policy
+	}
+	var regressions []Regression
+	check := func(policy, rule, metric string, baseline, current float64) {
+		if baseline <= 0 || current <= baseline {
+			return
+		}
+		if metric == "p95" && current-baseline < float64(minDelta) {
+			return
+		}
+		increase := (current - baseline) / baseline * 100
+		if increase > threshold {
+			regressions = append(regressions, Regression{
+				Policy:   policy,
+				Rule:     rule,
+				Metric:   metric,
+				Baseline: baseline,
+				Current:  current,
+				Increase: increase,
+			})
+		}
+	}
+	for _, policy := range current.Policies {
+		old, ok := baselinePolicies[policy.Policy]
+		if !ok {
+			continue
+		}
+		check(policy.Policy, "", "p95", float64(old.Latency.P95), float64(policy.Latency.P95))
+		check(policy.Policy, "", "allocs", float64(old.Allocs), float64(policy.Allocs))
+		oldRules := map[string]RuleReport{}
+		for _, rule := range old.Rules {
+			oldRules[rule.Rule+"/"+rule.Type] = rule
+		}
+		for _, rule := range policy.Rules {
+			if oldRule, ok := oldRules[rule.Rule+"/"+rule.Type]; ok {
+				check(policy.Policy, rule.Rule, "p95", float64(oldRule.Latency.P95), float64(rule.Latency.P95))
+			}
+		}
+	}
+	return regressions
+}
+
+func policyName(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+// countingJMESPath counts JMESPath evaluations
+type countingJMESPath struct {
+	inner jmespath.Interface
+	count *int64
+}
+
+func (j countingJMESPath) Query(query string) (jmespath.Query, error) {
+	q, err := j.inner.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	return countingQuery{inner: q, count: j.count}, nil
+}
+
+func (j countingJMESPath) Search(query string, data interface{}) (interface{}, error) {
+	atomic.AddInt64(j.count, 1)
+	return j.inner.Search(query, data)
+}
+
+func (j countingJMESPath) Precompile(queries ...string) error {
+	return j.inner.Precompile(queries...)
+}
+
+type countingQuery struct {
+	inner jmespath.Query
+	count *int64
+}
+
+func (q countingQuery) Search(data interface{}) (interface{}, error) {
+	atomic.AddInt64(q.count, 1)
+	return q.inner.Search(data)
+}
+
+// timedContextLoaderFactory reports the time spent loading context entries for every rule
+func timedContextLoaderFactory(inner engineapi.ContextLoaderFactory, record func(ruleKey, time.Duration)) engineapi.ContextLoaderFactory {
+	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
+		return timedContextLoader{
+			inner:  inner(policy, rule),
+			rule:   ruleKey{name: rule.Name, ruleType: ruleType(rule)},
+			record: record,
+		}
+	}
+}
+
+// ruleType returns the type of the responses produced by a rule, it is used to match context loading with rule durations
+func ruleType(rule kyvernov1.Rule) engineapi.RuleType {
+	switch {
+	case rule.HasValidate():
+		return engineapi.Validation
+	case rule.HasMutate():
+		return engineapi.Mutation
+	case rule.HasGenerate():
+		return engineapi.Generation
+	case rule.HasVerifyImages():
+		return engineapi.ImageVerify
+	}
+	return ""
+}
+
+type timedContextLoader struct {
+	inner  engineapi.ContextLoader
+	rule   ruleKey
+	record func(ruleKey, time.Duration)
+}
+
+func (l timedContextLoader) Load(
+	ctx context.Context,
+	jp jmespath.Interface,
+	client engineapi.RawClient,
+	rclientFactory engineapi.RegistryClientFactory,
+	contextEntries []kyvernov1.ContextEntry,
+	jsonContext enginecontext.Interface,
+) error {
+	start := time.Now()
+	defer func() {
+		l.record(l.rule, time.Since(start))
+	}()
+	return l.inner.Load(ctx, jp, client, rclientFactory, contextEntries, jsonContext)
+}
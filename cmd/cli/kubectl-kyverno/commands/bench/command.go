@@ -0,0 +1,31 @@
+package bench
+
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "bench [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE:         options.run,
+	}
+	cmd.Flags().StringSliceVarP(&options.resourcePaths, "resource", "r", nil, "Path to resource files")
+	cmd.Flags().StringVarP(&options.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringSliceVarP(&options.variables, "set", "s", nil, "Variables that are required")
+	cmd.Flags().IntVar(&options.iterations, "iterations", 10, "Number of times every policy is applied to every resource")
+	cmd.Flags().IntVar(&options.warmup, "warmup", 1, "Number of iterations run before measuring")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json)")
+	cmd.Flags().StringVar(&options.baseline, "baseline", "", "Path to a baseline file to compare results against")
+	cmd.Flags().StringVar(&options.saveBaseline, "save-baseline", "", "Path to a file where results are saved as a baseline")
+	cmd.Flags().Float64Var(&options.threshold, "threshold", 20, "Maximum allowed increase (in percent) of p95 latency and allocations compared to the baseline")
+	cmd.Flags().DurationVar(&options.minDelta, "min-delta", 100*time.Microsecond, "Latency increases smaller than this value are never reported as regressions")
+	return cmd
+}
@@ -0,0 +1,189 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/bench/policies.yaml", "--resource", "../../_testdata/bench/resources.yaml", "--iterations", "3", "--output", "json"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	var report Report
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &report))
+	assert.Equal(t, 3, report.Iterations)
+	assert.Equal(t, 2, report.Resources)
+	assert.Len(t, report.Policies, 2)
+	assert.Equal(t, "add-default-label", report.Policies[0].Policy)
+	assert.Equal(t, 6, report.Policies[0].Evaluations)
+	assert.Len(t, report.Policies[0].Rules, 1)
+	assert.Equal(t, "add-label", report.Policies[0].Rules[0].Rule)
+	assert.Equal(t, "Mutation", report.Policies[0].Rules[0].Type)
+	assert.Equal(t, "require-labels", report.Policies[1].Policy)
+	assert.Equal(t, 6, report.Policies[1].Rules[0].Evaluations)
+}
+
+func TestCommandWithBaseline(t *testing.T) {
+	dir := t.TempDir()
+	baseline := filepath.Join(dir, "baseline.json")
+	cmd := Command()
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"../../_testdata/bench/policies.yaml", "--resource", "../../_testdata/bench/resources.yaml", "--iterations", "2", "--save-baseline", baseline})
+	assert.NoError(t, cmd.Execute())
+	report, err := loadBaseline(baseline)
+	assert.NoError(t, err)
+	assert.Len(t, report.Policies, 2)
+	// inflate the baseline so that the current run can't regress whatever the machine load,
+	// regression detection itself is covered by TestCompare
+	for i := range report.Policies {
+		report.Policies[i].Allocs = math.MaxUint32
+		report.Policies[i].Latency.P95 = time.Hour
+		for j := range report.Policies[i].Rules {
+			report.Policies[i].Rules[j].Latency.P95 = time.Hour
+		}
+	}
+	assert.NoError(t, saveBaseline(baseline, report))
+	cmd = Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/bench/policies.yaml", "--resource", "../../_testdata/bench/resources.yaml", "--iterations", "2", "--baseline", baseline})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, b.String(), "No regressions compared to baseline (threshold 20%)")
+}
+
+func TestCompare(t *testing.T) {
+	policy := func(name string, p95 time.Duration, allocs uint64, rules ...RuleReport) PolicyReport {
+		return PolicyReport{Policy: name, Latency: Latency{P95: p95}, Allocs: allocs, Rules: rules}
+	}
+	rule := func(name string, p95 time.Duration) RuleReport {
+		return RuleReport{Rule: name, Type: "Validation", Latency: Latency{P95: p95}}
+	}
+	tests := []struct {
+		name     string
+		baseline Report
+		current  Report
+		want     []Regression
+	}{{
+		name:     "no change",
+		baseline: Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100, rule("r", time.Millisecond))}},
+		current:  Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100, rule("r", time.Millisecond))}},
+	}, {
+		name:     "below threshold",
+		baseline: Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100)}},
+		current:  Report{Policies: []PolicyReport{policy("pol", 1200*time.Microsecond, 120)}},
+	}, {
+		name:     "latency increase below min delta",
+		baseline: Report{Policies: []PolicyReport{policy("pol", 10*time.Microsecond, 100, rule("fast", 10*time.Microsecond))}},
+		current:  Report{Policies: []PolicyReport{policy("pol", 50*time.Microsecond, 100, rule("fast", 50*time.Microsecond))}},
+	}, {
+		name:     "allocs and rule latency",
+		baseline: Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100, rule("fast", 10*time.Microsecond), rule("slow", time.Millisecond))}},
+		current:  Report{Policies: []PolicyReport{policy("pol", 1100*time.Microsecond, 150, rule("fast", 50*time.Microsecond), rule("slow", 2*time.Millisecond))}},
+		want: []Regression{
+			{Policy: "pol", Metric: "allocs", Baseline: 100, Current: 150, Increase: 50},
+			{Policy: "pol", Rule: "slow", Metric: "p95", Baseline: float64(time.Millisecond), Current: float64(2 * time.Millisecond), Increase: 100},
+		},
+	}, {
+		name:     "policy latency",
+		baseline: Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100)}},
+		current:  Report{Policies: []PolicyReport{policy("pol", 3*time.Millisecond, 100)}},
+		want: []Regression{
+			{Policy: "pol", Metric: "p95", Baseline: float64(time.Millisecond), Current: float64(3 * time.Millisecond), Increase: 200},
+		},
+	}, {
+		name:     "policies and rules missing from the baseline are ignored",
+		baseline: Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100)}},
+		current:  Report{Policies: []PolicyReport{policy("pol", time.Millisecond, 100, rule("new", time.Second)), policy("other", time.Second, 1000)}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compare(tt.baseline, tt.current, 20, 100*time.Microsecond))
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i > 0; i-- {
+		durations = append(durations, time.Duration(i))
+	}
+	latency := newLatency(durations)
+	assert.Equal(t, time.Duration(1), latency.Min)
+	assert.Equal(t, time.Duration(50), latency.P50)
+	assert.Equal(t, time.Duration(95), latency.P95)
+	assert.Equal(t, time.Duration(100), latency.Max)
+}
+
+func TestReportContextLoading(t *testing.T) {
+	mutate := ruleKey{name: "check-labels", ruleType: engineapi.Mutation}
+	validate := ruleKey{name: "check-labels", ruleType: engineapi.Validation}
+	recorder := policyRecorder{
+		durations: []time.Duration{10, 10},
+		rules: map[ruleKey][]time.Duration{
+			mutate:   {4, 4},
+			validate: {6, 6},
+		},
+		contextLoading: map[ruleKey]time.Duration{
+			validate: 8,
+		},
+	}
+	report := recorder.report("test")
+	assert.Len(t, report.Rules, 2)
+	assert.Equal(t, "Mutation", report.Rules[0].Type)
+	assert.Equal(t, time.Duration(0), report.Rules[0].ContextLoading)
+	assert.Equal(t, "Validation", report.Rules[1].Type)
+	assert.Equal(t, time.Duration(4), report.Rules[1].ContextLoading)
+}
+
+func TestCommandWithoutArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: policies are required`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
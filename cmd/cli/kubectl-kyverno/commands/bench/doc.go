@@ -0,0 +1,29 @@
+package bench
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Benchmarks policies against a set of resources.`,
+	``,
+	`Every policy is applied to every resource for the requested number of iterations and the command reports,`,
+	`per policy and per rule, latency percentiles, allocations, time spent loading context entries and the number`,
+	`of JMESPath evaluations.`,
+	``,
+	`Results can be saved as a baseline and later compared against to detect performance regressions.`,
+}
+
+var examples = [][]string{
+	{
+		`# Benchmark policies against local resources`,
+		`kyverno bench /path/to/policies --resource /path/to/resources`,
+	},
+	{
+		`# Run 100 iterations and save the results as a baseline`,
+		`kyverno bench /path/to/policies --resource /path/to/resources --iterations 100 --save-baseline baseline.json`,
+	},
+	{
+		`# Compare against a baseline and fail if the p95 latency of a policy or rule increased by more than 20%`,
+		`kyverno bench /path/to/policies --resource /path/to/resources --baseline baseline.json --threshold 20`,
+	},
+}
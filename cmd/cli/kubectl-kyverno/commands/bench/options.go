@@ -0,0 +1,154 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/config"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	resourcePaths []string
+	valuesFile    string
+	variables     []string
+	iterations    int
+	warmup        int
+	output        string
+	baseline      string
+	saveBaseline  string
+	threshold     float64
+	minDelta      time.Duration
+}
+
+func (o options) validate(policyPaths []string) error {
+	if len(policyPaths) == 0 {
+		return errors.New("policies are required")
+	}
+	if len(o.resourcePaths) == 0 {
+		return errors.New("resources are required (use --resource)")
+	}
+	if o.valuesFile != "" && o.variables != nil {
+		return errors.New("pass the values either using set flag or values-file flag")
+	}
+	if o.iterations < 1 {
+		return errors.New("iterations must be at least 1")
+	}
+	if o.warmup < 0 {
+		return errors.New("warmup must not be negative")
+	}
+	if o.threshold < 0 {
+		return errors.New("threshold must not be negative")
+	}
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %s (must be text or json)", o.output)
+	}
+	return nil
+}
+
+func (o *options) run(cmd *cobra.Command, policyPaths []string) error {
+	if err := o.validate(policyPaths); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	var baseline *Report
+	if o.baseline != "" {
+		report, err := loadBaseline(o.baseline)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline (%w)", err)
+		}
+		baseline = report
+	}
+	results, err := policy.Load(nil, "", policyPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	policies := validPolicies(results.Policies)
+	if len(policies) == 0 {
+		return errors.New("no valid policies found")
+	}
+	vars, err := variables.New(out, nil, "", o.valuesFile, nil, o.variables...)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	resources, err := common.GetResourceAccordingToResourcePath(io.Discard, nil, o.resourcePaths, false, policies, nil, nil, "", false, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	var s store.Store
+	s.SetLocal(true)
+	vars.SetInStore(&s)
+	runner := &runner{
+		store:      &s,
+		variables:  vars,
+		iterations: o.iterations,
+		warmup:     o.warmup,
+	}
+	report, err := runner.run(policies, resources)
+	if err != nil {
+		return err
+	}
+	var regressions []Regression
+	if baseline != nil {
+		regressions = Compare(*baseline, *report, o.threshold, o.minDelta)
+	}
+	printer := newOutput(out, o.output)
+	if err := printer.print(report, baseline != nil, o.threshold, regressions); err != nil {
+		return err
+	}
+	if o.saveBaseline != "" {
+		if err := saveBaseline(o.saveBaseline, report); err != nil {
+			return fmt.Errorf("failed to save baseline (%w)", err)
+		}
+	}
+	if len(regressions) != 0 {
+		cmd.SilenceErrors = true
+		return fmt.Errorf("%d regression(s) detected", len(regressions))
+	}
+	return nil
+}
+
+func validPolicies(policies []kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	valid := make([]kyvernov1.PolicyInterface, 0, len(policies))
+	for _, pol := range policies {
+		sa := config.KyvernoUserName(config.KyvernoServiceAccountName())
+		if _, err := policyvalidation.Validate(pol, nil, nil, nil, true, sa, sa); err != nil {
+			log.Log.Error(err, "skipping invalid policy", "name", pol.GetName())
+			continue
+		}
+		valid = append(valid, pol)
+	}
+	return valid
+}
+
+func loadBaseline(path string) (*Report, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func saveBaseline(path string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
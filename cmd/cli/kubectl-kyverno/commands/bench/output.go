@@ -0,0 +1,108 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+type output interface {
+	print(report *Report, hasBaseline bool, threshold float64, regressions []Regression) error
+}
+
+func newOutput(out io.Writer, format string) output {
+	if format == "json" {
+		return jsonOutput{out: out}
+	}
+	return textOutput{out: out}
+}
+
+type textOutput struct {
+	out io.Writer
+}
+
+func (o textOutput) print(report *Report, hasBaseline bool, threshold float64, regressions []Regression) error {
+	fmt.Fprintf(o.out, "Benchmark: %d iterations over %d resources\n\n", report.Iterations, report.Resources)
+	w := tabwriter.NewWriter(o.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POLICY/RULE\tEVALS\tMEAN\tP50\tP95\tP99\tCONTEXT\tALLOCS\tBYTES\tJMESPATH")
+	for _, policy := range report.Policies {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%.1f\n",
+			policy.Policy,
+			policy.Evaluations,
+			formatDuration(policy.Latency.Mean),
+			formatDuration(policy.Latency.P50),
+			formatDuration(policy.Latency.P95),
+			formatDuration(policy.Latency.P99),
+			"-",
+			policy.Allocs,
+			policy.Bytes,
+			policy.JMESPath,
+		)
+		for _, rule := range policy.Rules {
+			fmt.Fprintf(w, "  %s (%s)\t%d\t%s\t%s\t%s\t%s\t%s\t-\t-\t-\n",
+				rule.Rule,
+				rule.Type,
+				rule.Evaluations,
+				formatDuration(rule.Latency.Mean),
+				formatDuration(rule.Latency.P50),
+				formatDuration(rule.Latency.P95),
+				formatDuration(rule.Latency.P99),
+				formatDuration(rule.ContextLoading),
+			)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !hasBaseline {
+		return nil
+	}
+	fmt.Fprintln(o.out)
+	if len(regressions) == 0 {
+		fmt.Fprintf(o.out, "No regressions compared to baseline (threshold %.0f%%)\n", threshold)
+		return nil
+	}
+	fmt.Fprintf(o.out, "Regressions compared to baseline (threshold %.0f%%):\n", threshold)
+	for _, regression := range regressions {
+		name := regression.Policy
+		if regression.Rule != "" {
+			name += "/" + regression.Rule
+		}
+		if regression.Metric == "allocs" {
+			fmt.Fprintf(o.out, "- %s %s: %.0f -> %.0f (+%.1f%%)\n", name, regression.Metric, regression.Baseline, regression.Current, regression.Increase)
+		} else {
+			fmt.Fprintf(o.out, "- %s %s: %s -> %s (+%.1f%%)\n", name, regression.Metric, formatDuration(time.Duration(regression.Baseline)), formatDuration(time.Duration(regression.Current)), regression.Increase)
+		}
+	}
+	return nil
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
+
+type jsonOutput struct {
+	out io.Writer
+}
+
+func (o jsonOutput) print(report *Report, hasBaseline bool, threshold float64, regressions []Regression) error {
+	result := struct {
+		*Report
+		Threshold   *float64     `json:"threshold,omitempty"`
+		Regressions []Regression `json:"regressions,omitempty"`
+	}{
+		Report:      report,
+		Regressions: regressions,
+	}
+	if hasBaseline {
+		result.Threshold = &threshold
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(o.out, string(data))
+	return err
+}
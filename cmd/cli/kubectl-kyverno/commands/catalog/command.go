@@ -0,0 +1,30 @@
+package catalog
+
+import (
+	"log"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "catalog [dir]",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         options.run,
+	}
+	cmd.Flags().StringVarP(&options.output, "output", "o", "catalog", "Output directory")
+	cmd.Flags().StringSliceVar(&options.formats, "format", []string{"markdown", "html"}, "Output formats (markdown, html)")
+	cmd.Flags().StringVarP(&options.fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
+	cmd.Flags().BoolVar(&options.runTests, "run-tests", true, "If set to true, run the tests to report their status")
+	cmd.Flags().BoolVar(&options.registryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
+	if err := cmd.MarkFlagDirname("output"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
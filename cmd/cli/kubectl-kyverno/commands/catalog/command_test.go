@@ -0,0 +1,159 @@
+package catalog
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-latest-tag
+  annotations:
+    policies.kyverno.io/title: Disallow Latest Tag
+    policies.kyverno.io/category: Best Practices
+spec:
+  rules:
+  - name: validate-image-tag
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      failureAction: Enforce
+      message: Using a mutable image tag e.g. 'latest' is not allowed.
+      pattern:
+        spec:
+          containers:
+          - image: "!*:latest"
+`
+
+const resources = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: good
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.2
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: bad
+spec:
+  containers:
+  - name: nginx
+    image: nginx:latest
+`
+
+const kyvernoTest = `
+apiVersion: cli.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+policies:
+- policy.yaml
+resources:
+- resources.yaml
+results:
+- policy: disallow-latest-tag
+  rule: validate-image-tag
+  kind: Pod
+  resources:
+  - good
+  result: pass
+- policy: disallow-latest-tag
+  rule: validate-image-tag
+  kind: Pod
+  resources:
+  - bad
+  result: fail
+`
+
+func TestCommand(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"policy.yaml":       policy,
+		"resources.yaml":    resources,
+		"kyverno-test.yaml": kyvernoTest,
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	output := filepath.Join(t.TempDir(), "catalog")
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{dir, "--output", output})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "Catalog of 1 policy written to "+output, strings.TrimSpace(b.String()))
+	index, err := os.ReadFile(filepath.Join(output, "README.md"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "| [Disallow Latest Tag](disallow-latest-tag.md) | ClusterPolicy |  |  | 1 | 2 passed |")
+	for _, name := range []string{"disallow-latest-tag.md", "index.html", "disallow-latest-tag.html"} {
+		_, err := os.Stat(filepath.Join(output, name))
+		assert.NoError(t, err)
+	}
+}
+
+func TestCommandWithInvalidFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--format", "pdf"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Equal(t, "Error: invalid format pdf (must be markdown or html)", strings.TrimSpace(b.String()))
+}
+
+func TestCommandWithoutArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: accepts 1 arg(s), received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
@@ -0,0 +1,27 @@
+package catalog
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Generates a browsable catalog of policies.`,
+	``,
+	`Policies are documented from their policies.kyverno.io annotations (title, category, severity, subject, description...),`,
+	`their rules, matched kinds, failure actions and Pod Security Standards controls.`,
+	``,
+	`Test files found in the directory provide examples of passing and failing resources,`,
+	`tests are run to report their status unless --run-tests=false is set.`,
+	``,
+	`The catalog is written as Markdown (README.md) and static HTML (index.html) pages, one page per policy.`,
+}
+
+var examples = [][]string{
+	{
+		`# Generate the catalog of policies in a directory`,
+		`kyverno catalog /path/to/policies --output catalog`,
+	},
+	{
+		`# Generate a Markdown catalog without running tests`,
+		`kyverno catalog /path/to/policies --format markdown --run-tests=false`,
+	},
+}
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/catalog"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	testapi "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/ext/output/pluralize"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	output         string
+	formats        []string
+	fileName       string
+	runTests       bool
+	registryAccess bool
+}
+
+func (o options) validate() error {
+	if o.output == "" {
+		return fmt.Errorf("output is required")
+	}
+	if len(o.formats) == 0 {
+		return fmt.Errorf("at least one format is required")
+	}
+	for _, format := range o.formats {
+		if !slices.Contains(catalog.Formats, catalog.Format(format)) {
+			return fmt.Errorf("invalid format %s (must be markdown or html)", format)
+		}
+	}
+	return nil
+}
+
+func (o *options) run(cmd *cobra.Command, args []string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	var runner catalog.Runner
+	if o.runTests {
+		// test results are rendered in tables we discard, colors are not needed
+		color.Init(true)
+		runner = func(testCase testapi.TestCase) (map[string]catalog.Status, error) {
+			statuses, err := test.Run(io.Discard, testCase, o.registryAccess)
+			if err != nil {
+				return nil, err
+			}
+			out := map[string]catalog.Status{}
+			for policy, status := range statuses {
+				out[policy] = catalog.Status{Pass: status.Pass, Fail: status.Fail, Skip: status.Skip}
+			}
+			return out, nil
+		}
+	}
+	c, err := catalog.Build(args[0], o.fileName, runner)
+	if err != nil {
+		return err
+	}
+	for _, format := range o.formats {
+		if err := catalog.Write(c, o.output, catalog.Format(format)); err != nil {
+			return err
+		}
+	}
+	count := len(c.Policies())
+	fmt.Fprintf(cmd.OutOrStdout(), "Catalog of %d %s written to %s\n", count, pluralize.Pluralize(count, "policy", "policies"), o.output)
+	return nil
+}
@@ -3,11 +3,17 @@ package commands
 import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bench"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/catalog"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/convert"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/diff"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/fix"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/json"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lint"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lsp"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/migrate"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
@@ -37,7 +43,13 @@ func RootCommand(experimental bool) *cobra.Command {
 	)
 	if experimental {
 		cmd.AddCommand(
+			bench.Command(),
+			catalog.Command(),
+			convert.Command(),
+			diff.Command(),
 			fix.Command(),
+			lint.Command(),
+			lsp.Command(),
 			oci.Command(),
 		)
 	}
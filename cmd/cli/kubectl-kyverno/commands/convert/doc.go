@@ -0,0 +1,15 @@
+package convert
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Convert policies between Kyverno and other policy formats.`,
+}
+
+var examples = [][]string{
+	{
+		`# Convert Kyverno policies to ValidatingAdmissionPolicies`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap /path/to/policies`,
+	},
+}
@@ -0,0 +1,28 @@
+package vap
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "vap [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args...); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), cmd.ErrOrStderr(), args...)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.exceptionPaths, "exception", "e", nil, "Policy exception files taken into account during conversion")
+	cmd.Flags().StringVarP(&options.outputPath, "output", "o", "", "Write the converted manifests to a file instead of stdout")
+	cmd.Flags().BoolVar(&options.reportOnly, "report-only", false, "Only report which policies can be converted")
+	return cmd
+}
@@ -0,0 +1,116 @@
+package vap
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	out := bytes.NewBufferString("")
+	report := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetErr(report)
+	cmd.SetArgs([]string{"../../../_testdata/convert/vap/policies.yaml", "../../../_testdata/convert/vap/vap.yaml"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `
+check-deployment-replicas/replicas-limit: converted to ValidatingAdmissionPolicy/check-deployment-replicas-replicas-limit
+check-deployment-replicas/require-team-label: converted to ValidatingAdmissionPolicy/check-deployment-replicas-require-team-label
+check-deployment-replicas/check-image-tag: not converted: skip generating ValidatingAdmissionPolicy for non CEL rules.
+ValidatingAdmissionPolicy/check-statefulset-replicas: converted to ClusterPolicy/check-statefulset-replicas
+
+3 of 4 converted`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(report.String()))
+	// converted manifests can be loaded back
+	path := filepath.Join(t.TempDir(), "converted.yaml")
+	assert.NoError(t, os.WriteFile(path, out.Bytes(), 0o600))
+	results, err := policy.Load(nil, "", path)
+	assert.NoError(t, err)
+	assert.Len(t, results.VAPs, 2)
+	assert.Len(t, results.VAPBindings, 2)
+	assert.Len(t, results.Policies, 1)
+	assert.Equal(t, "apps", results.VAPs[0].Spec.MatchConstraints.ResourceRules[0].APIGroups[0])
+	assert.Equal(t, []string{"deployments"}, results.VAPs[0].Spec.MatchConstraints.ResourceRules[0].Resources)
+	assert.Equal(t, []string{"apps/v1/StatefulSet"}, results.Policies[0].GetSpec().Rules[0].MatchResources.Any[0].Kinds)
+	assert.Equal(t, []string{"production"}, results.Policies[0].GetSpec().Rules[0].MatchResources.Any[0].Namespaces)
+}
+
+func TestCommandReportOnly(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../../_testdata/convert/vap/vap.yaml", "--report-only"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `
+ValidatingAdmissionPolicy/check-statefulset-replicas: converted to ClusterPolicy/check-statefulset-replicas
+
+1 of 1 converted`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(b.String()))
+}
+
+func TestCommandWithOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vaps.yaml")
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"../../../_testdata/convert/vap/policies.yaml", "--output", path})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Empty(t, b.String())
+	results, err := policy.Load(nil, "", path)
+	assert.NoError(t, err)
+	assert.Len(t, results.VAPs, 2)
+}
+
+func TestCommandWithoutArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
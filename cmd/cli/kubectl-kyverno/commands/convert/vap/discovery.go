@@ -0,0 +1,138 @@
+package vap
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var gaVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// offlineDiscovery resolves built-in Kubernetes kinds and resources without a cluster,
+// it is backed by the client-go scheme and cannot resolve custom resources.
+type offlineDiscovery struct {
+	scheme *runtime.Scheme
+}
+
+func newOfflineDiscovery() dclient.IDiscovery {
+	return offlineDiscovery{scheme: scheme.Scheme}
+}
+
+func (d offlineDiscovery) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	gvk, err := d.findKind(group, version, kind)
+	if err != nil {
+		return nil, err
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	name := gvr.Resource
+	if subresource != "" {
+		name = name + "/" + subresource
+	}
+	return map[dclient.TopLevelApiDescription]metav1.APIResource{
+		{
+			GroupVersion: gvk.GroupVersion(),
+			Kind:         gvk.Kind,
+			Resource:     gvr.Resource,
+			SubResource:  subresource,
+		}: {
+			Name:    name,
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind,
+		},
+	}, nil
+}
+
+func (d offlineDiscovery) GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	if !d.scheme.Recognizes(gvk) {
+		return schema.GroupVersionResource{}, fmt.Errorf("kind %s is not a built-in kind", gvk)
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	return gvr, nil
+}
+
+func (d offlineDiscovery) GetGVKFromGVR(gvr schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	for gvk := range d.scheme.AllKnownTypes() {
+		if gvk.Group != gvr.Group || gvk.Version != gvr.Version {
+			continue
+		}
+		if plural, _ := meta.UnsafeGuessKindToResource(gvk); plural.Resource == gvr.Resource {
+			return gvk, nil
+		}
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("resource %s is not a built-in resource", gvr)
+}
+
+func (d offlineDiscovery) OpenAPISchema() (*openapiv2.Document, error) {
+	return nil, nil
+}
+
+func (d offlineDiscovery) CachedDiscoveryInterface() discovery.CachedDiscoveryInterface {
+	return nil
+}
+
+// findKind returns the preferred group version of a kind, group and version can be wildcards
+func (d offlineDiscovery) findKind(group, version, kind string) (schema.GroupVersionKind, error) {
+	var candidates []schema.GroupVersionKind
+	for gvk := range d.scheme.AllKnownTypes() {
+		if gvk.Kind != kind || gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		if group != "*" && gvk.Group != group {
+			continue
+		}
+		if version != "*" && gvk.Version != version {
+			continue
+		}
+		candidates = append(candidates, gvk)
+	}
+	if len(candidates) == 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("kind %s is not a built-in kind", kind)
+	}
+	// the same kind can exist in multiple groups, groups serving a GA version win over deprecated ones
+	// and the core group wins over the others
+	if groups := groupsOf(candidates); len(groups) > 1 {
+		gaGroups := map[string]struct{}{}
+		for _, candidate := range candidates {
+			if gaVersion.MatchString(candidate.Version) {
+				gaGroups[candidate.Group] = struct{}{}
+			}
+		}
+		if _, ok := gaGroups[""]; ok {
+			gaGroups = map[string]struct{}{"": {}}
+		}
+		if len(gaGroups) != 1 {
+			return schema.GroupVersionKind{}, fmt.Errorf("kind %s is ambiguous, specify the group", kind)
+		}
+		candidates = slices.DeleteFunc(candidates, func(gvk schema.GroupVersionKind) bool {
+			_, ok := gaGroups[gvk.Group]
+			return !ok
+		})
+	}
+	// pick the preferred version of the group
+	for _, gv := range d.scheme.PrioritizedVersionsForGroup(candidates[0].Group) {
+		for _, candidate := range candidates {
+			if candidate.GroupVersion() == gv {
+				return candidate, nil
+			}
+		}
+	}
+	return candidates[0], nil
+}
+
+func groupsOf(gvks []schema.GroupVersionKind) map[string]struct{} {
+	groups := map[string]struct{}{}
+	for _, gvk := range gvks {
+		groups[gvk.Group] = struct{}{}
+	}
+	return groups
+}
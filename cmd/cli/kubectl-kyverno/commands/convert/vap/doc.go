@@ -0,0 +1,34 @@
+package vap
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Convert Kyverno policies to ValidatingAdmissionPolicies and back.`,
+	``,
+	`Kyverno ClusterPolicies are converted rule by rule, every rule that can be expressed as a ValidatingAdmissionPolicy`,
+	`produces a ValidatingAdmissionPolicy and its binding. Rules that cannot be converted are reported along with the reason.`,
+	``,
+	`ValidatingAdmissionPolicies (and their bindings) found in the input are converted to Kyverno ClusterPolicies with a CEL rule.`,
+	``,
+	`Conversion happens offline, only built-in Kubernetes kinds can be resolved.`,
+}
+
+var examples = [][]string{
+	{
+		`# Convert Kyverno policies to ValidatingAdmissionPolicies`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap /path/to/policies`,
+	},
+	{
+		`# Only report which rules can be converted`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap /path/to/policies --report-only`,
+	},
+	{
+		`# Take policy exceptions into account and write the manifests to a file`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap /path/to/policies --exception /path/to/exceptions --output vaps.yaml`,
+	},
+	{
+		`# Convert ValidatingAdmissionPolicies and their bindings to Kyverno policies`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap /path/to/vaps`,
+	},
+}
@@ -0,0 +1,242 @@
+package vap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	exceptionPaths []string
+	outputPath     string
+	reportOnly     bool
+}
+
+// result is the outcome of the conversion of a single rule or ValidatingAdmissionPolicy
+type result struct {
+	source  string
+	target  string
+	objects []runtime.Object
+	reason  string
+}
+
+func (o options) validate(paths ...string) error {
+	if len(paths) == 0 {
+		return errors.New("at least one policy path is required")
+	}
+	if o.reportOnly && o.outputPath != "" {
+		return errors.New("--output cannot be used with --report-only")
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, errOut io.Writer, paths ...string) error {
+	loaded, err := policy.Load(nil, "", paths...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	exceptions, err := exception.Load(o.exceptionPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load exceptions (%w)", err)
+	}
+	discoveryClient := newOfflineDiscovery()
+	var results []result
+	for _, pol := range loaded.Policies {
+		results = append(results, convertPolicy(discoveryClient, pol, exceptions)...)
+	}
+	for _, vap := range loaded.VAPs {
+		results = append(results, convertVAP(discoveryClient, vap, loaded.VAPBindings))
+	}
+	if len(results) == 0 {
+		return errors.New("no policies found")
+	}
+	report := out
+	if !o.reportOnly {
+		report = errOut
+		manifests := out
+		if o.outputPath != "" {
+			file, err := os.Create(filepath.Clean(o.outputPath))
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			manifests = file
+		}
+		if err := printManifests(manifests, results); err != nil {
+			return err
+		}
+	}
+	printReport(report, results)
+	return nil
+}
+
+func convertPolicy(discoveryClient dclient.IDiscovery, pol kyvernov1.PolicyInterface, exceptions []*kyvernov2.PolicyException) []result {
+	var results []result
+	rules := pol.GetSpec().Rules
+	for _, rule := range rules {
+		res := result{
+			source: pol.GetName() + "/" + rule.Name,
+		}
+		if pol.IsNamespaced() {
+			res.reason = "namespaced policies are not applicable."
+			results = append(results, res)
+			continue
+		}
+		// every rule is converted to its own ValidatingAdmissionPolicy
+		name := pol.GetName()
+		if len(rules) > 1 {
+			name = name + "-" + rule.Name
+		}
+		single := pol.CreateDeepCopy()
+		single.SetName(name)
+		single.GetSpec().Rules = []kyvernov1.Rule{rule}
+		var ruleExceptions []kyvernov2.PolicyException
+		for _, polex := range exceptions {
+			if polex.Contains(pol.GetName(), rule.Name) {
+				ruleExceptions = append(ruleExceptions, *polex)
+			}
+		}
+		if ok, msg := validatingadmissionpolicy.CanGenerateVAP(single.GetSpec(), ruleExceptions); !ok {
+			res.reason = msg
+			results = append(results, res)
+			continue
+		}
+		vap := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admissionregistrationv1beta1.SchemeGroupVersion.String(),
+				Kind:       "ValidatingAdmissionPolicy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+		if err := validatingadmissionpolicy.BuildValidatingAdmissionPolicy(discoveryClient, vap, single, ruleExceptions); err != nil {
+			res.reason = err.Error()
+			results = append(results, res)
+			continue
+		}
+		binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admissionregistrationv1beta1.SchemeGroupVersion.String(),
+				Kind:       "ValidatingAdmissionPolicyBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name + "-binding",
+			},
+		}
+		if err := validatingadmissionpolicy.BuildValidatingAdmissionPolicyBinding(binding, single); err != nil {
+			res.reason = err.Error()
+			results = append(results, res)
+			continue
+		}
+		// generated resources are not owned nor managed by Kyverno
+		for _, obj := range []metav1.Object{vap, binding} {
+			obj.SetOwnerReferences(nil)
+			obj.SetLabels(nil)
+		}
+		res.target = "ValidatingAdmissionPolicy/" + name
+		res.objects = []runtime.Object{vap, binding}
+		results = append(results, res)
+	}
+	return results
+}
+
+func convertVAP(
+	discoveryClient dclient.IDiscovery,
+	vap admissionregistrationv1beta1.ValidatingAdmissionPolicy,
+	bindings []admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding,
+) result {
+	res := result{
+		source: "ValidatingAdmissionPolicy/" + vap.Name,
+	}
+	var binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding
+	for i := range bindings {
+		if bindings[i].Spec.PolicyName == vap.Name {
+			if binding != nil {
+				res.reason = "multiple bindings are not applicable."
+				return res
+			}
+			binding = &bindings[i]
+		}
+	}
+	pol, err := validatingadmissionpolicy.BuildKyvernoPolicy(discoveryClient, vap, binding)
+	if err != nil {
+		res.reason = err.Error()
+		return res
+	}
+	res.target = "ClusterPolicy/" + pol.GetName()
+	res.objects = []runtime.Object{pol}
+	return res
+}
+
+func printManifests(out io.Writer, results []result) error {
+	for _, res := range results {
+		for _, obj := range res.objects {
+			data, err := marshal(obj)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "---")
+			fmt.Fprint(out, string(data))
+		}
+	}
+	return nil
+}
+
+func marshal(obj runtime.Object) ([]byte, error) {
+	u, err := kubeutils.ObjToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+	return yaml.Marshal(prune(u.Object))
+}
+
+// prune removes null and empty values left by fields without omitempty
+func prune(in map[string]interface{}) map[string]interface{} {
+	for key, value := range in {
+		switch typed := value.(type) {
+		case nil:
+			delete(in, key)
+		case map[string]interface{}:
+			if len(prune(typed)) == 0 {
+				delete(in, key)
+			}
+		case []interface{}:
+			for _, item := range typed {
+				if m, ok := item.(map[string]interface{}); ok {
+					prune(m)
+				}
+			}
+		}
+	}
+	return in
+}
+
+func printReport(out io.Writer, results []result) {
+	converted := 0
+	for _, res := range results {
+		if res.reason != "" {
+			fmt.Fprintf(out, "%s: not converted: %s\n", res.source, res.reason)
+		} else {
+			converted++
+			fmt.Fprintf(out, "%s: converted to %s\n", res.source, res.target)
+		}
+	}
+	fmt.Fprintf(out, "\n%d of %d converted\n", converted, len(results))
+}
@@ -0,0 +1,35 @@
+package diff
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "diff",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         options.run,
+	}
+	cmd.Flags().StringSliceVar(&options.oldPolicyPaths, "old", nil, "Path to the old policies (files, directories or git repositories)")
+	cmd.Flags().StringSliceVar(&options.newPolicyPaths, "new", nil, "Path to the new policies (files, directories or git repositories)")
+	cmd.Flags().StringVar(&options.oldGitBranch, "old-git-branch", "", "Git branch used to load the old policies from a git repository")
+	cmd.Flags().StringVar(&options.newGitBranch, "new-git-branch", "", "Git branch used to load the new policies from a git repository")
+	cmd.Flags().BoolVar(&options.oldCluster, "old-cluster", false, "Load the old policies from the cluster in the current context")
+	cmd.Flags().StringSliceVarP(&options.resourcePaths, "resource", "r", nil, "Path to resource files")
+	cmd.Flags().BoolVarP(&options.cluster, "cluster", "c", false, "Load resources from the cluster in the current context")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", "", "Namespace used to load policies and resources from the cluster")
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringSliceVarP(&options.variables, "set", "s", nil, "Variables that are required")
+	cmd.Flags().BoolVar(&options.registryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json)")
+	cmd.Flags().BoolVar(&options.failOnDiff, "fail-on-diff", false, "If set to true, exit with an error when at least one resource changed outcome")
+	return cmd
+}
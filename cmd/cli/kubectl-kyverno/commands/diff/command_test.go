@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--old", "../../_testdata/diff/old",
+		"--new", "../../_testdata/diff/new",
+		"--resource", "../../_testdata/diff/resources/pods.yaml",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `
+Resource v1/Pod/default/with-team
+  + require-labels/add-managed-by (Mutation): pass
+  ~ mutated resource changed:
+      add /metadata/labels/managed-by
+
+Resource v1/Pod/default/without-team
+  + require-labels/add-managed-by (Mutation): pass
+  ~ require-labels/check-app-label (Validation): pass -> fail
+      validation error: The labels ` + "`app` and `team`" + ` are required. rule check-app-label failed at path /metadata/labels/team/
+  ~ mutated resource changed:
+      add /metadata/labels/managed-by
+
+Diff Summary: 2 out of 2 resources changed`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandJSON(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--old", "../../_testdata/diff/old",
+		"--new", "../../_testdata/diff/new",
+		"--resource", "../../_testdata/diff/resources/pods.yaml",
+		"--output", "json",
+		"--fail-on-diff",
+	})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	var diffs []ResourceDiff
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &diffs))
+	assert.Len(t, diffs, 2)
+}
+
+func TestCommandNoDiff(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--old", "../../_testdata/diff/new",
+		"--new", "../../_testdata/diff/new",
+		"--resource", "../../_testdata/diff/resources/pods.yaml",
+		"--fail-on-diff",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "Diff Summary: 0 out of 2 resources changed", strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithoutPolicies(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--new", "../../_testdata/diff/new", "--resource", "../../_testdata/diff/resources/pods.yaml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: old policies are required (use --old or --old-cluster)`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown command "foo" for "diff"`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
@@ -0,0 +1,260 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RuleKey identifies a rule result for a given resource.
+type RuleKey struct {
+	Policy string
+	Rule   string
+	Type   engineapi.RuleType
+}
+
+// RuleOutcome is the result of a single rule applied to a resource.
+type RuleOutcome struct {
+	Status    engineapi.RuleStatus
+	Message   string
+	Generated []*unstructured.Unstructured
+}
+
+// Result holds everything a policy set produced for a single resource.
+type Result struct {
+	Resource *unstructured.Unstructured
+	Patched  unstructured.Unstructured
+	Rules    map[RuleKey]RuleOutcome
+}
+
+// Results maps a resource key to the policy set results for that resource.
+type Results map[string]*Result
+
+type RuleDiff struct {
+	Policy           string `json:"policy"`
+	Rule             string `json:"rule"`
+	Type             string `json:"type"`
+	OldStatus        string `json:"oldStatus,omitempty"`
+	NewStatus        string `json:"newStatus,omitempty"`
+	OldMessage       string `json:"oldMessage,omitempty"`
+	NewMessage       string `json:"newMessage,omitempty"`
+	GeneratedChanged bool   `json:"generatedChanged,omitempty"`
+}
+
+type ResourceDiff struct {
+	Resource string                         `json:"resource"`
+	Rules    []RuleDiff                     `json:"rules,omitempty"`
+	Patch    []jsonpatch.JsonPatchOperation `json:"patch,omitempty"`
+}
+
+type evaluator struct {
+	store     *store.Store
+	variables *variables.Variables
+	client    dclient.Interface
+	cluster   bool
+}
+
+func (e *evaluator) evaluate(policies []kyvernov1.PolicyInterface, resources []*unstructured.Unstructured) (Results, error) {
+	results := Results{}
+	var rc processor.ResultCounts
+	for _, resource := range resources {
+		processor := processor.PolicyProcessor{
+			Store:                e.store,
+			Policies:             policies,
+			Resource:             *resource,
+			Variables:            e.variables,
+			PolicyReport:         true,
+			NamespaceSelectorMap: e.variables.NamespaceSelectors(),
+			Rc:                   &rc,
+			Cluster:              e.cluster,
+			Client:               e.client,
+			Subresources:         e.variables.Subresources(),
+			Out:                  io.Discard,
+		}
+		responses, err := processor.ApplyPoliciesOnResource()
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply policies on resource %s (%w)", resource.GetName(), err)
+		}
+		results[resourceKey(resource)] = newResult(resource, responses...)
+	}
+	return results, nil
+}
+
+func newResult(resource *unstructured.Unstructured, responses ...engineapi.EngineResponse) *Result {
+	result := &Result{
+		Resource: resource,
+		Patched:  *resource,
+		Rules:    map[RuleKey]RuleOutcome{},
+	}
+	for _, response := range responses {
+		policy := response.Policy()
+		policyName := policy.GetName()
+		if policy.GetNamespace() != "" {
+			policyName = policy.GetNamespace() + "/" + policyName
+		}
+		patches := false
+		for _, rule := range response.PolicyResponse.Rules {
+			key := RuleKey{
+				Policy: policyName,
+				Rule:   rule.Name(),
+				Type:   rule.RuleType(),
+			}
+			result.Rules[key] = RuleOutcome{
+				Status:    rule.Status(),
+				Message:   rule.Message(),
+				Generated: rule.GeneratedResources(),
+			}
+			if rule.RuleType() == engineapi.Mutation || rule.RuleType() == engineapi.ImageVerify {
+				patches = true
+			}
+		}
+		if patches {
+			result.Patched = response.PatchedResource
+		}
+	}
+	return result
+}
+
+func resourceKey(resource *unstructured.Unstructured) string {
+	if resource.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s/%s", resource.GetAPIVersion(), resource.GetKind(), resource.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+}
+
+// Compare returns the resources whose outcome differs between the old and new results, sorted by resource key.
+func Compare(oldResults, newResults Results) ([]ResourceDiff, error) {
+	keys := map[string]struct{}{}
+	for key := range oldResults {
+		keys[key] = struct{}{}
+	}
+	for key := range newResults {
+		keys[key] = struct{}{}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+	var diffs []ResourceDiff
+	for _, key := range sortedKeys {
+		diff, err := compareResult(key, oldResults[key], newResults[key])
+		if err != nil {
+			return nil, err
+		}
+		if diff != nil {
+			diffs = append(diffs, *diff)
+		}
+	}
+	return diffs, nil
+}
+
+func compareResult(key string, oldResult, newResult *Result) (*ResourceDiff, error) {
+	if oldResult == nil {
+		oldResult = &Result{Rules: map[RuleKey]RuleOutcome{}}
+	}
+	if newResult == nil {
+		newResult = &Result{Rules: map[RuleKey]RuleOutcome{}}
+	}
+	diff := ResourceDiff{
+		Resource: key,
+	}
+	ruleKeys := map[RuleKey]struct{}{}
+	for ruleKey := range oldResult.Rules {
+		ruleKeys[ruleKey] = struct{}{}
+	}
+	for ruleKey := range newResult.Rules {
+		ruleKeys[ruleKey] = struct{}{}
+	}
+	for ruleKey := range ruleKeys {
+		oldOutcome, inOld := oldResult.Rules[ruleKey]
+		newOutcome, inNew := newResult.Rules[ruleKey]
+		generatedChanged := inOld && inNew && !sameResources(oldOutcome.Generated, newOutcome.Generated)
+		if inOld && inNew && oldOutcome.Status == newOutcome.Status && !generatedChanged {
+			continue
+		}
+		ruleDiff := RuleDiff{
+			Policy:           ruleKey.Policy,
+			Rule:             ruleKey.Rule,
+			Type:             string(ruleKey.Type),
+			GeneratedChanged: generatedChanged,
+		}
+		if inOld {
+			ruleDiff.OldStatus = string(oldOutcome.Status)
+			ruleDiff.OldMessage = oldOutcome.Message
+		}
+		if inNew {
+			ruleDiff.NewStatus = string(newOutcome.Status)
+			ruleDiff.NewMessage = newOutcome.Message
+		}
+		diff.Rules = append(diff.Rules, ruleDiff)
+	}
+	sort.Slice(diff.Rules, func(i, j int) bool {
+		a, b := diff.Rules[i], diff.Rules[j]
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Type < b.Type
+	})
+	if oldResult.Resource != nil && newResult.Resource != nil {
+		patch, err := comparePatched(oldResult.Patched, newResult.Patched)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare mutated resource %s (%w)", key, err)
+		}
+		diff.Patch = patch
+	}
+	if len(diff.Rules) == 0 && len(diff.Patch) == 0 {
+		return nil, nil
+	}
+	return &diff, nil
+}
+
+// comparePatched returns the JSON patch operations turning the old mutated resource into the new one.
+func comparePatched(oldPatched, newPatched unstructured.Unstructured) ([]jsonpatch.JsonPatchOperation, error) {
+	if reflect.DeepEqual(oldPatched.Object, newPatched.Object) {
+		return nil, nil
+	}
+	oldBytes, err := oldPatched.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	newBytes, err := newPatched.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.CreatePatch(oldBytes, newBytes)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(patch, func(i, j int) bool {
+		return patch[i].Path < patch[j].Path
+	})
+	return patch, nil
+}
+
+func sameResources(a, b []*unstructured.Unstructured) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	index := func(resources []*unstructured.Unstructured) map[string]map[string]interface{} {
+		out := map[string]map[string]interface{}{}
+		for _, r := range resources {
+			out[resourceKey(r)] = r.Object
+		}
+		return out
+	}
+	return reflect.DeepEqual(index(a), index(b))
+}
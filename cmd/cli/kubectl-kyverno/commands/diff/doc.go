@@ -0,0 +1,28 @@
+package diff
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Compares the outcome of two policy sets against the same resources.`,
+	``,
+	`Both the old and the new policy sets are applied to every resource and the command reports resources`,
+	`whose rule results, mutated output or generated resources differ between the two.`,
+	``,
+	`Policies can be loaded from local files, directories, git repositories or, for the old policy set, from the cluster.`,
+}
+
+var examples = [][]string{
+	{
+		`# Compare two policy directories against local resources`,
+		`kyverno diff --old /path/to/old/policies --new /path/to/new/policies --resource /path/to/resources`,
+	},
+	{
+		`# Compare the policies deployed in the cluster with local changes, against cluster resources`,
+		`kyverno diff --old-cluster --new /path/to/new/policies --cluster`,
+	},
+	{
+		`# Compare a policy from a git branch with a local copy and print results as json`,
+		`kyverno diff --old https://github.com/kyverno/policies/best-practices/ --old-git-branch main --new /path/to/policies --resource /path/to/resources --output json`,
+	},
+}
@@ -0,0 +1,255 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+type options struct {
+	oldPolicyPaths []string
+	newPolicyPaths []string
+	oldGitBranch   string
+	newGitBranch   string
+	oldCluster     bool
+	resourcePaths  []string
+	cluster        bool
+	namespace      string
+	kubeConfig     string
+	context        string
+	valuesFile     string
+	variables      []string
+	registryAccess bool
+	output         string
+	failOnDiff     bool
+}
+
+func (o options) validate() error {
+	if len(o.oldPolicyPaths) == 0 && !o.oldCluster {
+		return errors.New("old policies are required (use --old or --old-cluster)")
+	}
+	if len(o.oldPolicyPaths) != 0 && o.oldCluster {
+		return errors.New("--old and --old-cluster are mutually exclusive")
+	}
+	if len(o.newPolicyPaths) == 0 {
+		return errors.New("new policies are required (use --new)")
+	}
+	if len(o.resourcePaths) == 0 && !o.cluster {
+		return errors.New("resource file(s) or cluster required")
+	}
+	if o.valuesFile != "" && o.variables != nil {
+		return errors.New("pass the values either using set flag or values-file flag")
+	}
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %s (must be text or json)", o.output)
+	}
+	return nil
+}
+
+func (o *options) run(cmd *cobra.Command, _ []string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	var dClient dclient.Interface
+	if o.cluster || o.oldCluster {
+		client, err := o.createClient()
+		if err != nil {
+			return err
+		}
+		dClient = client
+	}
+	var oldPolicies []kyvernov1.PolicyInterface
+	if o.oldCluster {
+		policies, err := loadClusterPolicies(dClient, o.namespace)
+		if err != nil {
+			return fmt.Errorf("failed to load old policies from cluster (%w)", err)
+		}
+		oldPolicies = policies
+	} else {
+		policies, err := loadPolicies(o.oldGitBranch, o.oldPolicyPaths...)
+		if err != nil {
+			return fmt.Errorf("failed to load old policies (%w)", err)
+		}
+		oldPolicies = policies
+	}
+	newPolicies, err := loadPolicies(o.newGitBranch, o.newPolicyPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load new policies (%w)", err)
+	}
+	oldPolicies = validPolicies(oldPolicies)
+	newPolicies = validPolicies(newPolicies)
+	vars, err := variables.New(out, nil, "", o.valuesFile, nil, o.variables...)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	// resources are selected using both policy sets so that a resource matched by only one of them is still compared
+	allPolicies := make([]kyvernov1.PolicyInterface, 0, len(oldPolicies)+len(newPolicies))
+	allPolicies = append(allPolicies, oldPolicies...)
+	allPolicies = append(allPolicies, newPolicies...)
+	resources, err := common.GetResourceAccordingToResourcePath(io.Discard, nil, o.resourcePaths, o.cluster, allPolicies, nil, dClient, o.namespace, true, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	var s store.Store
+	s.SetLocal(true)
+	s.SetRegistryAccess(o.registryAccess)
+	if o.cluster {
+		s.AllowApiCall(true)
+	}
+	if vars != nil {
+		vars.SetInStore(&s)
+	}
+	engine := &evaluator{
+		store:     &s,
+		variables: vars,
+		client:    dClient,
+		cluster:   o.cluster,
+	}
+	oldResults, err := engine.evaluate(oldPolicies, resources)
+	if err != nil {
+		return fmt.Errorf("failed to apply old policies (%w)", err)
+	}
+	newResults, err := engine.evaluate(newPolicies, resources)
+	if err != nil {
+		return fmt.Errorf("failed to apply new policies (%w)", err)
+	}
+	diffs, err := Compare(oldResults, newResults)
+	if err != nil {
+		return err
+	}
+	printer := newOutput(out, o.output)
+	if err := printer.print(len(resources), diffs); err != nil {
+		return err
+	}
+	if o.failOnDiff && len(diffs) != 0 {
+		cmd.SilenceErrors = true
+		return fmt.Errorf("%d resource(s) changed outcome", len(diffs))
+	}
+	return nil
+}
+
+func (o options) createClient() (dclient.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dclient.NewClient(context.Background(), dynamicClient, kubeClient, 15*time.Minute)
+}
+
+func loadPolicies(gitBranch string, paths ...string) ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	for _, path := range paths {
+		if source.IsGit(path) {
+			gitSourceURL, err := url.Parse(path)
+			if err != nil {
+				return nil, err
+			}
+			pathElems := strings.Split(gitSourceURL.Path[1:], "/")
+			if len(pathElems) <= 1 {
+				return nil, fmt.Errorf("invalid URL path %s - expected https://<any_git_source_domain>/:owner/:repository/:branch (without --old-git-branch/--new-git-branch flags) OR https://<any_git_source_domain>/:owner/:repository/:directory (with --old-git-branch/--new-git-branch flags)", gitSourceURL.Path)
+			}
+			gitSourceURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
+			repoURL := gitSourceURL.String()
+			branch, gitPathToYamls := common.GetGitBranchOrPolicyPaths(gitBranch, repoURL, path)
+			fs := memfs.New()
+			if _, err := gitutils.Clone(repoURL, fs, branch); err != nil {
+				return nil, fmt.Errorf("failed to clone repository (%w)", err)
+			}
+			policyYamls, err := gitutils.ListYamls(fs, gitPathToYamls)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list YAMLs in repository (%w)", err)
+			}
+			for _, policyYaml := range policyYamls {
+				results, err := policy.Load(fs, "", policyYaml)
+				if err != nil {
+					return nil, fmt.Errorf("failed to load policies from %s (%w)", policyYaml, err)
+				}
+				policies = append(policies, results.Policies...)
+			}
+		} else {
+			results, err := policy.Load(nil, "", path)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, results.Policies...)
+		}
+	}
+	return policies, nil
+}
+
+func loadClusterPolicies(dClient dclient.Interface, namespace string) ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	clusterPolicies, err := dClient.ListResource(context.TODO(), kyvernov1.SchemeGroupVersion.String(), "ClusterPolicy", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusterPolicies.Items {
+		var p kyvernov1.ClusterPolicy
+		if err := fromUnstructured(&clusterPolicies.Items[i], &p); err != nil {
+			return nil, err
+		}
+		p.GetSpec().UseServerSideApply = false
+		policies = append(policies, &p)
+	}
+	namespacedPolicies, err := dClient.ListResource(context.TODO(), kyvernov1.SchemeGroupVersion.String(), "Policy", namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range namespacedPolicies.Items {
+		var p kyvernov1.Policy
+		if err := fromUnstructured(&namespacedPolicies.Items[i], &p); err != nil {
+			return nil, err
+		}
+		p.GetSpec().UseServerSideApply = false
+		policies = append(policies, &p)
+	}
+	return policies, nil
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, out interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), out)
+}
+
+func validPolicies(policies []kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	valid := make([]kyvernov1.PolicyInterface, 0, len(policies))
+	for _, pol := range policies {
+		sa := config.KyvernoUserName(config.KyvernoServiceAccountName())
+		if _, err := policyvalidation.Validate(pol, nil, nil, nil, true, sa, sa); err != nil {
+			log.Log.Error(err, "skipping invalid policy", "name", pol.GetName())
+			continue
+		}
+		valid = append(valid, pol)
+	}
+	return valid
+}
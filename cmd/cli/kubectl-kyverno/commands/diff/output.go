@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kyverno/kyverno/ext/output/pluralize"
+)
+
+type output interface {
+	print(resourceCount int, diffs []ResourceDiff) error
+}
+
+type textOutput struct {
+	out io.Writer
+}
+
+func (t *textOutput) print(resourceCount int, diffs []ResourceDiff) error {
+	for _, diff := range diffs {
+		fmt.Fprintln(t.out, "Resource", diff.Resource)
+		for _, rule := range diff.Rules {
+			name := fmt.Sprintf("%s/%s (%s)", rule.Policy, rule.Rule, rule.Type)
+			switch {
+			case rule.OldStatus == "":
+				fmt.Fprintf(t.out, "  + %s: %s\n", name, rule.NewStatus)
+			case rule.NewStatus == "":
+				fmt.Fprintf(t.out, "  - %s: %s\n", name, rule.OldStatus)
+			case rule.OldStatus != rule.NewStatus:
+				fmt.Fprintf(t.out, "  ~ %s: %s -> %s\n", name, rule.OldStatus, rule.NewStatus)
+				if rule.NewMessage != "" {
+					fmt.Fprintf(t.out, "      %s\n", rule.NewMessage)
+				}
+			}
+			if rule.GeneratedChanged {
+				fmt.Fprintf(t.out, "  ~ %s: generated resources changed\n", name)
+			}
+		}
+		if len(diff.Patch) != 0 {
+			fmt.Fprintln(t.out, "  ~ mutated resource changed:")
+			for _, op := range diff.Patch {
+				fmt.Fprintf(t.out, "      %s %s\n", op.Operation, op.Path)
+			}
+		}
+		fmt.Fprintln(t.out)
+	}
+	fmt.Fprintf(t.out, "Diff Summary: %d out of %d %s changed\n", len(diffs), resourceCount, pluralize.Pluralize(resourceCount, "resource", "resources"))
+	return nil
+}
+
+type jsonOutput struct {
+	out io.Writer
+}
+
+func (t *jsonOutput) print(_ int, diffs []ResourceDiff) error {
+	if diffs == nil {
+		diffs = []ResourceDiff{}
+	}
+	payload, err := json.MarshalIndent(diffs, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(t.out, string(payload))
+	return nil
+}
+
+func newOutput(out io.Writer, format string) output {
+	if format == "json" {
+		return &jsonOutput{out: out}
+	}
+	return &textOutput{out: out}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp/function"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp/parse"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp/query"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp/repl"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +25,7 @@ func Command() *cobra.Command {
 		function.Command(),
 		parse.Command(),
 		query.Command(),
+		repl.Command(),
 	)
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 	"slices"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/configuration"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/spf13/cobra"
@@ -14,20 +15,28 @@ import (
 )
 
 func Command() *cobra.Command {
-	return &cobra.Command{
+	var configPath string
+	cmd := &cobra.Command{
 		Use:          "function [function_name]...",
 		Short:        command.FormatDescription(true, websiteUrl, false, description...),
 		Long:         command.FormatDescription(false, websiteUrl, false, description...),
 		Example:      command.FormatExamples(examples...),
 		SilenceUsage: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			printFunctions(cmd.OutOrStdout(), args...)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configuration.Load(configPath)
+			if err != nil {
+				return err
+			}
+			printFunctions(cmd.OutOrStdout(), cfg, args...)
+			return nil
 		},
 	}
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to a Kyverno ConfigMap manifest declaring user-defined functions")
+	return cmd
 }
 
-func printFunctions(out io.Writer, names ...string) {
-	functions := jmespath.GetFunctions(config.NewDefaultConfiguration(false))
+func printFunctions(out io.Writer, cfg config.Configuration, names ...string) {
+	functions := jmespath.GetFunctions(cfg)
 	slices.SortFunc(functions, func(a, b jmespath.FunctionEntry) int {
 		return cmp.Compare(a.String(), b.String())
 	})
@@ -10,7 +10,7 @@ import (
 
 	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
-	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/configuration"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
@@ -18,7 +18,7 @@ import (
 
 func Command() *cobra.Command {
 	var compact, unquoted bool
-	var input string
+	var input, configPath string
 	var queries []string
 	cmd := &cobra.Command{
 		Use:          "query [-i input] [-q query|query]...",
@@ -52,8 +52,13 @@ func Command() *cobra.Command {
 				}
 				input = i
 			}
+			cfg, err := configuration.Load(configPath)
+			if err != nil {
+				return err
+			}
+			jp := jmespath.New(cfg)
 			for _, query := range queries {
-				result, err := evaluate(input, query)
+				result, err := evaluate(jp, input, query)
 				if err != nil {
 					return err
 				}
@@ -68,6 +73,7 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&unquoted, "unquoted", "u", false, "If the final result is a string, it will be printed without quotes")
 	cmd.Flags().StringSliceVarP(&queries, "query", "q", nil, "Read JMESPath expression from the specified file")
 	cmd.Flags().StringVarP(&input, "input", "i", "", "Read input from a JSON or YAML file instead of stdin")
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to a Kyverno ConfigMap manifest declaring user-defined functions")
 	return cmd
 }
 
@@ -148,8 +154,7 @@ func loadInput(cmd *cobra.Command, file string) (interface{}, error) {
 	return input, nil
 }
 
-func evaluate(input interface{}, query string) (interface{}, error) {
-	jp := jmespath.New(config.NewDefaultConfiguration(false))
+func evaluate(jp jmespath.Interface, input interface{}, query string) (interface{}, error) {
 	q, err := jp.Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile JMESPath: %s, error: %v", query, err)
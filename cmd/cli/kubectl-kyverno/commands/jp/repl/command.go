@@ -0,0 +1,30 @@
+package repl
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "repl",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         options.run,
+	}
+	cmd.Flags().StringVarP(&options.resource, "resource", "r", "", "Path to the resource made available as request.object")
+	cmd.Flags().StringVar(&options.oldResource, "old-resource", "", "Path to the resource made available as request.oldObject")
+	cmd.Flags().StringVar(&options.operation, "operation", "CREATE", "Admission operation (CREATE, UPDATE, DELETE or CONNECT)")
+	cmd.Flags().StringVar(&options.request, "request", "", "Path to an admission request or admission review, mutually exclusive with --resource")
+	cmd.Flags().StringVarP(&options.userInfo, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
+	cmd.Flags().StringVarP(&options.policy, "policy", "p", "", "Path to a policy declaring the context entries to load")
+	cmd.Flags().StringVar(&options.rule, "rule", "", "Name of the rule declaring the context entries to load, required if the policy has more than one rule")
+	cmd.Flags().StringVarP(&options.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringSliceVarP(&options.variables, "set", "s", nil, "Variables added to the context")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "json", "Output format (json, compact or yaml)")
+	return cmd
+}
@@ -0,0 +1,213 @@
+package repl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  namespace: default
+  labels:
+    app.kubernetes.io/name: nginx
+spec:
+  containers:
+  - name: nginx
+    image: ghcr.io/nginx/nginx:1.2
+`
+
+const testPolicy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: registries
+spec:
+  rules:
+  - name: check-registries
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: allowed
+      variable:
+        value:
+        - ghcr.io
+        - docker.io
+    - name: first
+      variable:
+        jmesPath: allowed[0]
+    validate:
+      message: registry not allowed
+      deny:
+        conditions:
+          all:
+          - key: '{{ images.containers.*.registry }}'
+            operator: AnyNotIn
+            value: '{{ allowed }}'
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestCommand(t *testing.T) {
+	resource := writeFile(t, "pod.yaml", testPod)
+	policy := writeFile(t, "policy.yaml", testPolicy)
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader(strings.Join([]string{
+		"request.object.metadata.name",
+		"{{ images.containers.nginx.registry }}",
+		"first",
+		"foo",
+		"request.operation",
+		"to_upper(request.object.metadata.name)",
+		"bad((",
+		":output yaml",
+		"allowed",
+		":quit",
+		"request.object.metadata.namespace",
+	}, "\n")))
+	cmd.SetArgs([]string{"-r", resource, "-p", policy, "-s", "foo=bar", "-o", "compact"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `
+"nginx"
+"ghcr.io"
+"ghcr.io"
+"bar"
+"CREATE"
+"NGINX"
+Error: incorrect query bad((: SyntaxError: Incomplete expression
+- ghcr.io
+- docker.io`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(out.String()))
+}
+
+func TestCommandWithInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{{
+		name:     "request and resource",
+		args:     []string{"--request", "request.yaml", "--resource", "pod.yaml"},
+		expected: "Error: --request cannot be used together with --resource or --old-resource",
+	}, {
+		name:     "rule without policy",
+		args:     []string{"--rule", "foo"},
+		expected: "Error: --rule requires --policy",
+	}, {
+		name:     "operation",
+		args:     []string{"--operation", "PATCH"},
+		expected: `Error: invalid operation "PATCH", must be one of CREATE, UPDATE, DELETE or CONNECT`,
+	}, {
+		name:     "output",
+		args:     []string{"--output", "xml"},
+		expected: `Error: invalid output "xml", must be one of json, compact, yaml`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := Command()
+			b := bytes.NewBufferString("")
+			cmd.SetErr(b)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.Error(t, err)
+			assert.Equal(t, tt.expected, strings.TrimSpace(b.String()))
+		})
+	}
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestComplete(t *testing.T) {
+	o := options{
+		resource:  writeFile(t, "pod.yaml", testPod),
+		operation: "CREATE",
+	}
+	pc, err := o.buildContext(context.Background(), io.Discard)
+	assert.NoError(t, err)
+	r := newRepl(pc.JSONContext(), io.Discard, "json")
+	tests := []struct {
+		line       string
+		start      int
+		candidates []string
+	}{{
+		line:       "reque",
+		start:      0,
+		candidates: []string{"request"},
+	}, {
+		line:       "to_u",
+		start:      0,
+		candidates: []string{"to_upper("},
+	}, {
+		line:       "length(request.object.me",
+		start:      7,
+		candidates: []string{"request.object.metadata"},
+	}, {
+		line:       "request.object.metadata.labels.",
+		start:      0,
+		candidates: []string{`request.object.metadata.labels."app.kubernetes.io/name"`},
+	}, {
+		line:       "images.containers.nginx.re",
+		start:      0,
+		candidates: []string{"images.containers.nginx.reference", "images.containers.nginx.referenceWithTag", "images.containers.nginx.registry"},
+	}, {
+		line:  "request.object.spec.containers.",
+		start: 0,
+	}, {
+		line:       ":fu",
+		start:      0,
+		candidates: []string{":functions"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			start, candidates := r.complete(tt.line)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.candidates, candidates)
+		})
+	}
+	assert.Equal(t, "images.containers.nginx.re", commonPrefix([]string{"images.containers.nginx.reference", "images.containers.nginx.registry"}))
+}
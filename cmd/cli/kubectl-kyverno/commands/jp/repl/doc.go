@@ -0,0 +1,32 @@
+package repl
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#jp`
+
+var description = []string{
+	`Starts an interactive JMESPath shell evaluating expressions against a Kyverno context.`,
+	``,
+	`The context is built the same way the engine builds it: request, userinfo, service account, images and namespace`,
+	`are populated from the given resource or admission request, and the context entries of a policy rule can be loaded.`,
+	``,
+	`Expressions can be entered with or without {{ }}. Lines starting with : are shell commands, enter :help to list them.`,
+	`When running in a terminal, up/down arrows navigate the history and tab completes functions and variables.`,
+}
+
+var examples = [][]string{
+	{
+		`# Explore the context of a resource`,
+		`kyverno jp repl --resource pod.yaml`,
+	},
+	{
+		`# Explore the context of an update request made by a given user`,
+		`kyverno jp repl --resource pod.yaml --old-resource old-pod.yaml --operation UPDATE --userinfo user.yaml`,
+	},
+	{
+		`# Load the context entries of a policy rule, mocking values`,
+		`kyverno jp repl --request admission-review.json --policy policy.yaml --rule check-registries --values-file values.yaml`,
+	},
+	{
+		`# Evaluate expressions from a file`,
+		`kyverno jp repl --resource pod.yaml < queries.txt`,
+	},
+}
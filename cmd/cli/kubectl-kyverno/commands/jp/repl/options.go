@@ -0,0 +1,243 @@
+package repl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	"github.com/kyverno/kyverno/pkg/toggle"
+	"github.com/spf13/cobra"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	resource    string
+	oldResource string
+	operation   string
+	request     string
+	userInfo    string
+	policy      string
+	rule        string
+	valuesFile  string
+	variables   []string
+	output      string
+}
+
+func (o options) validate() error {
+	if o.request != "" && (o.resource != "" || o.oldResource != "") {
+		return errors.New("--request cannot be used together with --resource or --old-resource")
+	}
+	if o.rule != "" && o.policy == "" {
+		return errors.New("--rule requires --policy")
+	}
+	switch kyvernov1.AdmissionOperation(strings.ToUpper(o.operation)) {
+	case kyvernov1.Create, kyvernov1.Update, kyvernov1.Delete, kyvernov1.Connect:
+	default:
+		return fmt.Errorf("invalid operation %q, must be one of CREATE, UPDATE, DELETE or CONNECT", o.operation)
+	}
+	if !isOutputMode(o.output) {
+		return fmt.Errorf("invalid output %q, must be one of %s", o.output, strings.Join(outputModes, ", "))
+	}
+	return nil
+}
+
+func (o *options) run(cmd *cobra.Command, _ []string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	pc, err := o.buildContext(cmd.Context(), cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	r := newRepl(pc.JSONContext(), cmd.OutOrStdout(), o.output)
+	return r.run(cmd.InOrStdin())
+}
+
+func (o options) buildContext(ctx context.Context, warn io.Writer) (*policycontext.PolicyContext, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	var admissionInfo *kyvernov2.RequestInfo
+	if o.userInfo != "" {
+		info, err := userinfo.Load(nil, o.userInfo, "")
+		if err != nil {
+			return nil, err
+		}
+		admissionInfo = &info.RequestInfo
+	}
+	var pc *policycontext.PolicyContext
+	if o.request != "" {
+		request, err := loadRequest(o.request)
+		if err != nil {
+			return nil, err
+		}
+		if admissionInfo == nil {
+			admissionInfo = &kyvernov2.RequestInfo{AdmissionUserInfo: request.UserInfo}
+		}
+		gvk := schema.GroupVersionKind{Group: request.Kind.Group, Version: request.Kind.Version, Kind: request.Kind.Kind}
+		pc, err = policycontext.NewPolicyContextFromAdmissionRequest(jp, *request, *admissionInfo, gvk, cfg)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var newResource, oldResource unstructured.Unstructured
+		if o.resource != "" {
+			r, err := loadResource(o.resource)
+			if err != nil {
+				return nil, err
+			}
+			newResource = *r
+		}
+		if o.oldResource != "" {
+			r, err := loadResource(o.oldResource)
+			if err != nil {
+				return nil, err
+			}
+			oldResource = *r
+		}
+		operation := kyvernov1.AdmissionOperation(strings.ToUpper(o.operation))
+		resource := newResource
+		if operation == kyvernov1.Delete && o.resource == "" {
+			resource = oldResource
+		}
+		var err error
+		pc, err = policycontext.NewPolicyContext(jp, resource, operation, admissionInfo, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if o.oldResource != "" && operation != kyvernov1.Delete {
+			if err := pc.SetResources(oldResource, newResource); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var s store.Store
+	s.SetLocal(true)
+	vars, err := variables.New(warn, nil, "", o.valuesFile, nil, o.variables...)
+	if err != nil {
+		return nil, err
+	}
+	values, err := vars.ComputeVariables(&s, "", "", "", sets.New[string]())
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range values {
+		// request.operation defaults to CREATE, keep the one from the context
+		if key == "request.operation" {
+			continue
+		}
+		if err := pc.JSONContext().AddVariable(key, value); err != nil {
+			return nil, err
+		}
+	}
+	if o.policy != "" {
+		vars.SetInStore(&s)
+		if err := o.loadContextEntries(ctx, jp, &s, pc, warn); err != nil {
+			return nil, err
+		}
+	}
+	return pc, nil
+}
+
+func (o options) loadContextEntries(ctx context.Context, jp jmespath.Interface, s *store.Store, pc *policycontext.PolicyContext, warn io.Writer) error {
+	results, err := policy.Load(nil, "", o.policy)
+	if err != nil {
+		return err
+	}
+	if len(results.Policies) != 1 {
+		return fmt.Errorf("expected exactly one policy in %s, found %d", o.policy, len(results.Policies))
+	}
+	pol := results.Policies[0]
+	name := o.rule
+	if name == "" {
+		if rules := pol.GetSpec().Rules; len(rules) != 1 {
+			return fmt.Errorf("policy %s has %d rules, use --rule to select one", pol.GetName(), len(rules))
+		} else {
+			name = rules[0].Name
+		}
+	}
+	var rule *kyvernov1.Rule
+	rules := autogen.Default.ComputeRules(pol, "")
+	for i := range rules {
+		if rules[i].Name == name {
+			rule = &rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		return fmt.Errorf("rule %s not found in policy %s", name, pol.GetName())
+	}
+	loader := store.ContextLoaderFactory(s, nil)(pol, *rule)
+	// entries are loaded eagerly and one by one so that a failing entry (api call, config map...)
+	// is reported upfront and does not prevent the other ones from being available
+	ctx = toggle.NewContext(ctx, eagerLoading{toggle.FromContext(ctx)})
+	for _, entry := range rule.Context {
+		if err := loader.Load(ctx, jp, nil, nil, []kyvernov1.ContextEntry{entry}, pc.JSONContext()); err != nil {
+			fmt.Fprintf(warn, "WARNING: failed to load context entry %s: %s\n", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+type eagerLoading struct {
+	toggle.Toggles
+}
+
+func (eagerLoading) EnableDeferredLoading() bool {
+	return false
+}
+
+func loadResource(path string) (*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	resources, err := resource.GetUnstructuredResources(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("expected exactly one resource in %s, found %d", path, len(resources))
+	}
+	return resources[0], nil
+}
+
+func loadRequest(path string) (*admissionv1.AdmissionRequest, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var review admissionv1.AdmissionReview
+	if err := yaml.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("failed to decode admission request (%w)", err)
+	}
+	if review.Kind == "AdmissionReview" {
+		if review.Request == nil {
+			return nil, fmt.Errorf("admission review %s has no request", path)
+		}
+		return review.Request, nil
+	}
+	var request admissionv1.AdmissionRequest
+	if err := yaml.Unmarshal(data, &request); err != nil {
+		return nil, fmt.Errorf("failed to decode admission request (%w)", err)
+	}
+	return &request, nil
+}
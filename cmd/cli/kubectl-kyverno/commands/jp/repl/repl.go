@@ -0,0 +1,293 @@
+package repl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"golang.org/x/term"
+	"sigs.k8s.io/yaml"
+)
+
+const prompt = "jp> "
+
+var outputModes = []string{"json", "compact", "yaml"}
+
+func isOutputMode(mode string) bool {
+	return slices.Contains(outputModes, mode)
+}
+
+var errQuit = errors.New("quit")
+
+type repl struct {
+	ctx       enginecontext.EvalInterface
+	out       io.Writer
+	output    string
+	history   []string
+	functions []jmespath.FunctionEntry
+}
+
+func newRepl(ctx enginecontext.EvalInterface, out io.Writer, output string) *repl {
+	functions := jmespath.GetFunctions(config.NewDefaultConfiguration(false))
+	slices.SortFunc(functions, func(a, b jmespath.FunctionEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return &repl{
+		ctx:       ctx,
+		out:       out,
+		output:    output,
+		functions: functions,
+	}
+}
+
+func (r *repl) run(in io.Reader) error {
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		return r.interactive(f)
+	}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := r.eval(scanner.Text()); err != nil {
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (r *repl) interactive(in *os.File) error {
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = term.Restore(int(in.Fd()), state) }()
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, r.out}, prompt)
+	t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		start, candidates := r.complete(line[:pos])
+		if len(candidates) == 0 {
+			return "", 0, false
+		}
+		if len(candidates) > 1 {
+			fmt.Fprintln(t, strings.Join(candidates, "  "))
+		}
+		replacement := commonPrefix(candidates)
+		return line[:start] + replacement + line[pos:], start + len(replacement), true
+	}
+	r.out = t
+	fmt.Fprintln(t, "Enter a JMESPath expression, :help for help or :quit to exit.")
+	for {
+		line, err := t.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := r.eval(line); err != nil {
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// eval processes a single input line, errors returned are fatal,
+// evaluation errors are printed and the session goes on.
+func (r *repl) eval(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	if strings.HasPrefix(line, ":") {
+		return r.command(line)
+	}
+	r.history = append(r.history, line)
+	query := line
+	if strings.HasPrefix(query, "{{") && strings.HasSuffix(query, "}}") {
+		query = strings.TrimSpace(query[2 : len(query)-2])
+	}
+	result, err := r.ctx.Query(query)
+	if err != nil {
+		fmt.Fprintln(r.out, "Error:", err)
+		return nil
+	}
+	return r.print(result)
+}
+
+func (r *repl) command(line string) error {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case ":q", ":quit", ":exit":
+		return errQuit
+	case ":h", ":help":
+		fmt.Fprintln(r.out, help)
+	case ":history":
+		for i, entry := range r.history {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, entry)
+		}
+	case ":context":
+		result, err := r.ctx.Query("@")
+		if err != nil {
+			fmt.Fprintln(r.out, "Error:", err)
+			return nil
+		}
+		return r.print(result)
+	case ":functions":
+		names := fields[1:]
+		for _, function := range r.functions {
+			if len(names) == 0 || slices.Contains(names, function.Name) {
+				fmt.Fprintln(r.out, function.String())
+			}
+		}
+	case ":output":
+		if len(fields) != 2 || !isOutputMode(fields[1]) {
+			fmt.Fprintf(r.out, "Error: usage :output %s\n", strings.Join(outputModes, "|"))
+			return nil
+		}
+		r.output = fields[1]
+	default:
+		fmt.Fprintf(r.out, "Error: unknown command %s, enter :help for help\n", fields[0])
+	}
+	return nil
+}
+
+func (r *repl) print(result interface{}) error {
+	var data []byte
+	var err error
+	switch r.output {
+	case "yaml":
+		data, err = yaml.Marshal(result)
+		data = []byte(strings.TrimSuffix(string(data), "\n"))
+	case "compact":
+		data, err = json.Marshal(result)
+	default:
+		data, err = json.MarshalIndent(result, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(r.out, string(data))
+	return nil
+}
+
+// complete returns the start of the word being completed in line
+// and the candidates that can replace it.
+func (r *repl) complete(line string) (int, []string) {
+	start := len(line)
+	for start > 0 && isIdentifier(line[start-1]) {
+		start--
+	}
+	word := line[start:]
+	if strings.HasPrefix(line, ":") && !strings.Contains(line, " ") {
+		return 0, matching(commands, line)
+	}
+	var candidates []string
+	if dot := strings.LastIndex(word, "."); dot >= 0 {
+		path, prefix := word[:dot], word[dot+1:]
+		for _, key := range r.keys(path) {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			if needsQuoting(key) {
+				key = strconv.Quote(key)
+			}
+			candidates = append(candidates, path+"."+key)
+		}
+		return start, candidates
+	}
+	for _, key := range r.keys("@") {
+		if strings.HasPrefix(key, word) {
+			candidates = append(candidates, key)
+		}
+	}
+	for _, function := range r.functions {
+		if strings.HasPrefix(function.Name, word) {
+			candidates = append(candidates, function.Name+"(")
+		}
+	}
+	return start, candidates
+}
+
+func (r *repl) keys(path string) []string {
+	result, err := r.ctx.Query(path)
+	if err != nil {
+		return nil
+	}
+	object, ok := result.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func isIdentifier(c byte) bool {
+	return c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// needsQuoting returns true if key is not a valid unquoted JMESPath identifier.
+func needsQuoting(key string) bool {
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		return true
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] == '.' || !isIdentifier(key[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matching(candidates []string, prefix string) []string {
+	var out []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			out = append(out, candidate)
+		}
+	}
+	return out
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+var commands = []string{":context", ":exit", ":functions", ":help", ":history", ":output", ":quit"}
+
+const help = `Enter a JMESPath expression to evaluate it against the context, {{ }} around the expression is optional.
+
+Commands:
+  :context                     print the whole context
+  :functions [name]...         list available functions
+  :history                     print expressions entered so far
+  :output json|compact|yaml    change the output format
+  :help                        print this help
+  :quit, :exit                 exit the shell`
@@ -0,0 +1,24 @@
+package lint
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "lint [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE:         options.run,
+	}
+	cmd.Flags().StringVar(&options.config, "config", "", "Path to the linter configuration file")
+	cmd.Flags().StringSliceVar(&options.disable, "disable", nil, "Checks to disable")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json)")
+	cmd.Flags().StringVar(&options.failOn, "fail-on", "error", "Minimum severity of findings that makes the command fail (info, warning, error or none)")
+	return cmd
+}
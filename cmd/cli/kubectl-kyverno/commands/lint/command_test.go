@@ -0,0 +1,106 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/validation/lint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/lint/policies.yaml"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `
+check-images/check-user: [warning] external-context-in-foreach: imageRegistry context entry imageData is evaluated for every foreach element, consider moving it to the rule context (spec.rules[0].validate.foreach[0].context[0])
+check-images/check-user: [warning] unused-context-entry: context entry config is never referenced (spec.rules[0].context[0])
+require-labels/check-team: [info] unconditional-pod-rule: rule matches all Pods and has no preconditions or exclusions (spec.rules[0].match)
+
+Lint Summary: 3 findings found in 2 policies`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithConfig(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/lint/policies.yaml", "--config", "../../_testdata/lint/config.yaml", "--disable", "external-context-in-foreach", "--output", "json"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	var findings []lint.Finding
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &findings))
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "unused-context-entry", findings[0].Check)
+	assert.Equal(t, lint.SeverityError, findings[0].Severity)
+}
+
+func TestCommandFailOn(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"../../_testdata/lint/policies.yaml", "--fail-on", "warning"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Equal(t, "2 findings at or above severity warning", err.Error())
+}
+
+func TestCommandWithUnknownCheck(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"../../_testdata/lint/policies.yaml", "--disable", "unused-context"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Equal(t, "invalid config (unknown check unused-context)", err.Error())
+}
+
+func TestCommandWithoutArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
@@ -0,0 +1,33 @@
+package lint
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Lints policies for valid but slow or fragile patterns.`,
+	``,
+	`Policies are expected to be valid, the linter reports anti-patterns that can hurt admission latency or produce unexpected results.`,
+	``,
+	`Checks:`,
+	`  - external-context-in-foreach: apiCall or imageRegistry context entries declared inside a foreach`,
+	`  - unused-context-entry: context entries that are never referenced`,
+	`  - unconditional-pod-rule: rules matching every Pod without any selector or precondition`,
+	`  - background-request-variables: background policies referencing request variables`,
+	`  - wildcard-kind: rules matching wildcard kinds`,
+	`  - overlapping-mutate-rules: mutate rules patching the same fields of the same kinds`,
+}
+
+var examples = [][]string{
+	{
+		`# Lint policies in a directory`,
+		`kyverno lint /path/to/policies`,
+	},
+	{
+		`# Lint policies, ignoring a check and printing findings as json`,
+		`kyverno lint /path/to/policies --disable unconditional-pod-rule --output json`,
+	},
+	{
+		`# Lint policies using a configuration file and fail on warnings`,
+		`kyverno lint /path/to/policies --config lint.yaml --fail-on warning`,
+	},
+}
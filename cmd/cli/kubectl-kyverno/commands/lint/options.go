@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/ext/output/pluralize"
+	"github.com/kyverno/kyverno/pkg/validation/lint"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var severityLevels = map[lint.Severity]int{
+	lint.SeverityInfo:    1,
+	lint.SeverityWarning: 2,
+	lint.SeverityError:   3,
+}
+
+type options struct {
+	config  string
+	disable []string
+	output  string
+	failOn  string
+}
+
+func (o options) validate() error {
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %s (must be text or json)", o.output)
+	}
+	if _, ok := severityLevels[lint.Severity(o.failOn)]; !ok && o.failOn != "none" {
+		return fmt.Errorf("invalid severity %s (must be info, warning, error or none)", o.failOn)
+	}
+	return nil
+}
+
+func (o *options) run(cmd *cobra.Command, args []string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	config, err := o.loadConfig()
+	if err != nil {
+		return err
+	}
+	results, err := policy.Load(nil, "", args...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	findings := lint.Lint(config, results.Policies...)
+	if err := printFindings(cmd.OutOrStdout(), o.output, len(results.Policies), findings); err != nil {
+		return err
+	}
+	if count := o.failing(findings); count != 0 {
+		cmd.SilenceErrors = true
+		return fmt.Errorf("%d %s at or above severity %s", count, pluralize.Pluralize(count, "finding", "findings"), o.failOn)
+	}
+	return nil
+}
+
+func (o options) loadConfig() (lint.Config, error) {
+	var config lint.Config
+	if o.config != "" {
+		data, err := os.ReadFile(filepath.Clean(o.config))
+		if err != nil {
+			return config, fmt.Errorf("failed to read config file (%w)", err)
+		}
+		if err := yaml.UnmarshalStrict(data, &config); err != nil {
+			return config, fmt.Errorf("failed to parse config file (%w)", err)
+		}
+	}
+	config.Disable = append(config.Disable, o.disable...)
+	if err := config.Validate(); err != nil {
+		return config, fmt.Errorf("invalid config (%w)", err)
+	}
+	return config, nil
+}
+
+func (o options) failing(findings []lint.Finding) int {
+	threshold, ok := severityLevels[lint.Severity(o.failOn)]
+	if !ok {
+		return 0
+	}
+	count := 0
+	for _, finding := range findings {
+		if severityLevels[finding.Severity] >= threshold {
+			count++
+		}
+	}
+	return count
+}
+
+func printFindings(out io.Writer, format string, policyCount int, findings []lint.Finding) error {
+	if format == "json" {
+		if findings == nil {
+			findings = []lint.Finding{}
+		}
+		payload, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(payload))
+		return nil
+	}
+	for _, finding := range findings {
+		name := finding.Policy
+		if finding.Rule != "" {
+			name += "/" + finding.Rule
+		}
+		fmt.Fprintf(out, "%s: [%s] %s: %s", name, finding.Severity, finding.Check, finding.Message)
+		if finding.Path != "" {
+			fmt.Fprintf(out, " (%s)", finding.Path)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "\nLint Summary: %d %s found in %d %s\n", len(findings), pluralize.Pluralize(len(findings), "finding", "findings"), policyCount, pluralize.Pluralize(policyCount, "policy", "policies"))
+	return nil
+}
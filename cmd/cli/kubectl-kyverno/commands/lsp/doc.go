@@ -0,0 +1,27 @@
+package lsp
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Starts a language server for Kyverno policies.`,
+	``,
+	`The server implements the Language Server Protocol over stdin/stdout and is meant to be started by an editor.`,
+	``,
+	`Features:`,
+	`  - diagnostics: policies are validated when opened or changed`,
+	`  - completion: custom JMESPath functions, request.* fields, builtin variables, context entries and condition operators`,
+	`  - hover: documentation of anchors, condition operators, JMESPath functions and variables`,
+	`  - definition: jump from a variable to the context entry declaring it`,
+}
+
+var examples = [][]string{
+	{
+		`# Start the language server (usually done by the editor)`,
+		`KYVERNO_EXPERIMENTAL=true kyverno lsp`,
+	},
+	{
+		`# Neovim configuration using lspconfig`,
+		`require('lspconfig.configs').kyverno = { default_config = { cmd = { 'kyverno', 'lsp' }, filetypes = { 'yaml' }, root_dir = require('lspconfig.util').find_git_ancestor } }`,
+	},
+}
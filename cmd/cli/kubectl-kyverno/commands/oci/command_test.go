@@ -3,9 +3,17 @@ package oci
 import (
 	"bytes"
 	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/registry"
+	testcommand "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -64,3 +72,72 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestCommandPushPullSigned(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/kyverno/policies:latest"
+
+	dir := t.TempDir()
+	password := func(bool) ([]byte, error) { return []byte("secret"), nil }
+	keys, err := cosign.GenerateKeyPair(password)
+	assert.NoError(t, err)
+	otherKeys, err := cosign.GenerateKeyPair(password)
+	assert.NoError(t, err)
+	for name, content := range map[string][]byte{"cosign.key": keys.PrivateBytes, "cosign.pub": keys.PublicBytes, "other.pub": otherKeys.PublicBytes} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o600))
+	}
+	t.Setenv("COSIGN_PASSWORD", "secret")
+
+	run := func(args ...string) error {
+		cmd := Command()
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	bundle := "../../_testdata/oci/bundle"
+	// unsigned images are refused
+	assert.NoError(t, run("push", bundle, "-i", imageRef))
+	err = run("pull", filepath.Join(dir, "unsigned"), "-i", imageRef, "--key", filepath.Join(dir, "cosign.pub"), "--ignore-tlog", "--ignore-sct")
+	assert.ErrorContains(t, err, "no signatures found")
+	// signed images are verified against the given key
+	assert.NoError(t, run("push", bundle, "-i", imageRef, "--key", filepath.Join(dir, "cosign.key"), "--tlog-upload=false"))
+	err = run("pull", filepath.Join(dir, "mismatch"), "-i", imageRef, "--key", filepath.Join(dir, "other.pub"), "--ignore-tlog", "--ignore-sct")
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "mismatch", "require-team-label.yaml"))
+	assert.True(t, os.IsNotExist(err))
+	out := filepath.Join(dir, "pulled")
+	assert.NoError(t, run("pull", out, "-i", imageRef, "--key", filepath.Join(dir, "cosign.pub"), "--ignore-tlog", "--ignore-sct"))
+	for _, file := range []string{
+		"require-team-label.yaml",
+		"exceptions/kube-system-system-pods.yaml",
+		"globalcontextentries/deployments.yaml",
+		"tests/tests/kyverno-test.yaml",
+		"tests/tests/resources.yaml",
+		"tests/policies/require-team-label.yaml",
+	} {
+		_, err := os.Stat(filepath.Join(out, file))
+		assert.NoError(t, err, file)
+	}
+}
+
+func TestCommandPushPullTest(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/kyverno/mutate:latest"
+
+	run := func(cmd *cobra.Command, args ...string) error {
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	// the test references a patched resource, it must be packed with the test
+	assert.NoError(t, run(Command(), "push", "../../_testdata/oci/mutate", "-i", imageRef))
+	out := t.TempDir()
+	assert.NoError(t, run(Command(), "pull", out, "-i", imageRef, "--insecure-skip-verify"))
+	_, err := os.Stat(filepath.Join(out, "tests", "tests", "patched.yaml"))
+	assert.NoError(t, err)
+	assert.NoError(t, run(testcommand.Command(), filepath.Join(out, "tests")))
+}
@@ -2,14 +2,20 @@ package internal
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/policybundle"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 const (
-	PolicyConfigMediaType = "application/vnd.cncf.kyverno.config.v1+json"
-	PolicyLayerMediaType  = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
-	AnnotationKind        = "io.kyverno.image.kind"
-	AnnotationName        = "io.kyverno.image.name"
-	AnnotationApiVersion  = "io.kyverno.image.apiVersion"
+	PolicyConfigMediaType            = policybundle.ConfigMediaType
+	PolicyLayerMediaType             = policybundle.PolicyLayerMediaType
+	ExceptionLayerMediaType          = policybundle.ExceptionLayerMediaType
+	GlobalContextEntryLayerMediaType = policybundle.GlobalContextEntryLayerMediaType
+	TestLayerMediaType               = policybundle.TestLayerMediaType
+	AnnotationKind                   = "io.kyverno.image.kind"
+	AnnotationName                   = "io.kyverno.image.name"
+	AnnotationApiVersion             = "io.kyverno.image.apiVersion"
+	AnnotationPath                   = "io.kyverno.image.path"
 )
 
 func Annotations(policy kyvernov1.PolicyInterface) map[string]string {
@@ -27,3 +33,21 @@ func Annotations(policy kyvernov1.PolicyInterface) map[string]string {
 		AnnotationApiVersion: "kyverno.io/v1",
 	}
 }
+
+func ResourceAnnotations(resource *unstructured.Unstructured) map[string]string {
+	if resource == nil {
+		return nil
+	}
+	return map[string]string{
+		AnnotationKind:       resource.GetKind(),
+		AnnotationName:       resource.GetName(),
+		AnnotationApiVersion: resource.GetAPIVersion(),
+	}
+}
+
+func AnnotationsForTest(path string) map[string]string {
+	return map[string]string{
+		AnnotationKind: "Test",
+		AnnotationPath: path,
+	}
+}
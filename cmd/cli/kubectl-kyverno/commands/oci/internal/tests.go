@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+// maxTestFileSize limits the size of files extracted from test layers
+const maxTestFileSize = 64 << 20
+
+// PackTest creates a gzipped tarball containing a test file and all the local files it references.
+// Paths in the tarball are relative to root, files outside of root can't be packed.
+func PackTest(root string, tc test.TestCase) ([]byte, error) {
+	testDir := filepath.Dir(tc.Path)
+	paths := []string{tc.Path}
+	refs := tc.References()
+	for _, ref := range refs {
+		if source.IsHttp(ref) || source.IsGit(ref) {
+			return nil, fmt.Errorf("remote path %s is not supported", ref)
+		}
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(testDir, ref)
+		}
+		paths = append(paths, ref)
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	written := map[string]struct{}{}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			if !entry.Type().IsRegular() {
+				return fmt.Errorf("%s is not a regular file", file)
+			}
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("%s is outside of %s", file, root)
+			}
+			rel = filepath.ToSlash(rel)
+			if _, ok := written[rel]; ok {
+				return nil
+			}
+			written[rel] = struct{}{}
+			content, err := os.ReadFile(filepath.Clean(file))
+			if err != nil {
+				return err
+			}
+			if err := tw.WriteHeader(&tar.Header{
+				Name:     rel,
+				Mode:     0o600,
+				Size:     int64(len(content)),
+				Typeflag: tar.TypeReg,
+			}); err != nil {
+				return err
+			}
+			_, err = tw.Write(content)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnpackTest extracts a tarball created by PackTest into dir.
+func UnpackTest(content io.Reader, dir string) ([]string, error) {
+	gz, err := gzip.NewReader(content)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	var files []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("%s is not a regular file", header.Name)
+		}
+		if header.Size > maxTestFileSize {
+			return nil, fmt.Errorf("%s exceeds the maximum file size", header.Name)
+		}
+		path, err := securejoin.SecureJoin(dir, header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxTestFileSize))
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return nil, err
+		}
+		files = append(files, path)
+	}
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackUnpackTest(t *testing.T) {
+	root := "../../../_testdata/oci/bundle"
+	testPath := filepath.Join(root, "tests", "kyverno-test.yaml")
+	tc := test.TestCase{
+		Path: testPath,
+		Test: &v1alpha1.Test{
+			Policies:  []string{"../policies/require-team-label.yaml"},
+			Resources: []string{"resources.yaml"},
+		},
+	}
+	content, err := PackTest(root, tc)
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	files, err := UnpackTest(bytes.NewReader(content), dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "tests", "kyverno-test.yaml"),
+		filepath.Join(dir, "policies", "require-team-label.yaml"),
+		filepath.Join(dir, "tests", "resources.yaml"),
+	}, files)
+	expected, err := os.ReadFile(testPath)
+	assert.NoError(t, err)
+	actual, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPackTestOutsideRoot(t *testing.T) {
+	root := "../../../_testdata/oci/bundle/tests"
+	tc := test.TestCase{
+		Path: filepath.Join(root, "kyverno-test.yaml"),
+		Test: &v1alpha1.Test{
+			Policies: []string{"../policies/require-team-label.yaml"},
+		},
+	}
+	_, err := PackTest(root, tc)
+	assert.ErrorContains(t, err, "is outside of")
+	tc.Test.Policies = []string{"https://example.com/policy.yaml"}
+	_, err = PackTest(root, tc)
+	assert.ErrorContains(t, err, "remote path")
+}
@@ -26,6 +26,15 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVar(&options.key, "key", "", "path to the cosign public key (or KMS reference) used to verify the image signature")
+	cmd.Flags().StringVar(&options.certificateIdentity, "certificate-identity", "", "identity expected in the keyless signing certificate")
+	cmd.Flags().StringVar(&options.certificateIdentityRegexp, "certificate-identity-regexp", "", "regular expression matching the identity in the keyless signing certificate")
+	cmd.Flags().StringVar(&options.certificateOidcIssuer, "certificate-oidc-issuer", "", "OIDC issuer expected in the keyless signing certificate")
+	cmd.Flags().StringVar(&options.certificateOidcIssuerRegexp, "certificate-oidc-issuer-regexp", "", "regular expression matching the OIDC issuer in the keyless signing certificate")
+	cmd.Flags().StringVar(&options.rekorURL, "rekor-url", "https://rekor.sigstore.dev", "address of the Rekor server")
+	cmd.Flags().BoolVar(&options.ignoreTlog, "ignore-tlog", false, "skip the transparency log verification")
+	cmd.Flags().BoolVar(&options.ignoreSCT, "ignore-sct", false, "skip the signed certificate timestamp verification")
+	cmd.Flags().BoolVar(&options.insecureSkipVerify, "insecure-skip-verify", false, "pull the image without verifying its signature")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
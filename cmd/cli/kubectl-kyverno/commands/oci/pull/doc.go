@@ -4,11 +4,26 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#pulling`
 
 var description = []string{
 	`Pulls policie(s) that are included in an OCI image from OCI registry and saves them to a local directory.`,
+	``,
+	`Policy exceptions are saved in the exceptions directory, global context entries in the globalcontextentries directory`,
+	`and tests in the tests directory.`,
+	``,
+	`The image signature is verified with a cosign public key or a keyless identity before anything is saved,`,
+	`unsigned images and images signed by someone else are refused.`,
+	`The signature must have a Rekor transparency log entry, use --ignore-tlog for images pushed with --tlog-upload=false.`,
 }
 
 var examples = [][]string{
 	{
-		`# Pull policy from an OCI image and save it to the specific directory`,
-		`kyverno oci pull . -i <imgref>`,
+		`# Pull policy from an OCI image signed with a key and save it to the specific directory`,
+		`kyverno oci pull . -i <imgref> --key cosign.pub`,
+	},
+	{
+		`# Pull policy from an OCI image signed keyless and save it to the specific directory`,
+		`kyverno oci pull . -i <imgref> --certificate-identity <identity> --certificate-oidc-issuer <issuer>`,
+	},
+	{
+		`# Pull policy from an unsigned OCI image and save it to the specific directory`,
+		`kyverno oci pull . -i <imgref> --insecure-skip-verify`,
 	},
 }
@@ -7,18 +7,34 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
 type options struct {
-	imageRef string
+	imageRef                    string
+	key                         string
+	certificateIdentity         string
+	certificateIdentityRegexp   string
+	certificateOidcIssuer       string
+	certificateOidcIssuerRegexp string
+	rekorURL                    string
+	ignoreTlog                  bool
+	ignoreSCT                   bool
+	insecureSkipVerify          bool
 }
 
 func (o options) validate(dir string) error {
@@ -28,6 +44,27 @@ func (o options) validate(dir string) error {
 	if dir == "" {
 		return errors.New("dir is required")
 	}
+	keyless := o.certificateIdentity != "" || o.certificateIdentityRegexp != "" || o.certificateOidcIssuer != "" || o.certificateOidcIssuerRegexp != ""
+	if o.insecureSkipVerify {
+		if o.key != "" || keyless {
+			return errors.New("--insecure-skip-verify cannot be used with --key or certificate flags")
+		}
+		return nil
+	}
+	if o.key != "" && keyless {
+		return errors.New("--key cannot be used with certificate flags")
+	}
+	if o.key == "" && !keyless {
+		return errors.New("either --key or --certificate-identity and --certificate-oidc-issuer are required to verify the image, use --insecure-skip-verify to pull unsigned images")
+	}
+	if keyless {
+		if o.certificateIdentity == "" && o.certificateIdentityRegexp == "" {
+			return errors.New("--certificate-identity or --certificate-identity-regexp is required for keyless verification")
+		}
+		if o.certificateOidcIssuer == "" && o.certificateOidcIssuerRegexp == "" {
+			return errors.New("--certificate-oidc-issuer or --certificate-oidc-issuer-regexp is required for keyless verification")
+		}
+	}
 	return nil
 }
 
@@ -43,6 +80,7 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 			return err
 		}
 	}
+
 	fi, err := os.Lstat(dir)
 	// Dir does not need to exist, as it can later be created.
 	if err != nil && errors.Is(err, os.ErrNotExist) {
@@ -53,10 +91,21 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err == nil && !fi.IsDir() {
 		return fmt.Errorf("dir '%s' must be a directory", dir)
 	}
-	ref, err := name.ParseReference(o.imageRef)
+
+	var ref name.Reference
+	ref, err = name.ParseReference(o.imageRef)
 	if err != nil {
 		return fmt.Errorf("parsing image reference: %v", err)
 	}
+	if !o.insecureSkipVerify {
+		digest, err := o.verify(ctx, ref, keychain)
+		if err != nil {
+			return fmt.Errorf("verifying image %s: %v", ref.Name(), err)
+		}
+		// pull the verified digest, the tag could have been moved in the meantime
+		ref = ref.Context().Digest(digest)
+	}
+
 	fmt.Fprintf(os.Stderr, "Downloading policies from an image [%s]...\n", ref.Name())
 	rmt, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
 	if err != nil {
@@ -66,25 +115,27 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err != nil {
 		return fmt.Errorf("getting image: %v", err)
 	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return fmt.Errorf("getting image manifest: %v", err)
+	}
+	if manifest.Config.MediaType != internal.PolicyConfigMediaType {
+		return fmt.Errorf("image config media type %s is not %s", manifest.Config.MediaType, internal.PolicyConfigMediaType)
+	}
 	l, err := img.Layers()
 	if err != nil {
 		return fmt.Errorf("getting image layers: %v", err)
 	}
-	for _, layer := range l {
+	for i, layer := range l {
 		lmt, err := layer.MediaType()
 		if err != nil {
 			return fmt.Errorf("getting layer media type: %v", err)
 		}
-		if lmt == internal.PolicyLayerMediaType {
-			blob, err := layer.Compressed()
+		switch lmt {
+		case internal.PolicyLayerMediaType:
+			layerBytes, err := readLayer(layer)
 			if err != nil {
-				return fmt.Errorf("getting layer blob: %v", err)
-			}
-			defer blob.Close()
-
-			layerBytes, err := io.ReadAll(blob)
-			if err != nil {
-				return fmt.Errorf("reading layer blob: %v", err)
+				return err
 			}
 			policies, _, _, err := yamlutils.GetPolicy(layerBytes)
 			if err != nil {
@@ -101,8 +152,112 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 					return fmt.Errorf("creating file: %v", err)
 				}
 			}
+		case internal.ExceptionLayerMediaType:
+			if err := saveResource(layer, filepath.Join(dir, "exceptions")); err != nil {
+				return err
+			}
+		case internal.GlobalContextEntryLayerMediaType:
+			if err := saveResource(layer, filepath.Join(dir, "globalcontextentries")); err != nil {
+				return err
+			}
+		case internal.TestLayerMediaType:
+			testDir := filepath.Join(dir, "tests")
+			fmt.Fprintf(os.Stderr, "Saving test into disk [%s]...\n", filepath.Join(testDir, manifest.Layers[i].Annotations[internal.AnnotationPath]))
+			if err := unpackTest(layer, testDir); err != nil {
+				return err
+			}
 		}
 	}
 	fmt.Fprintf(os.Stderr, "Done.")
 	return nil
 }
+
+// verify checks the image signature and returns the verified digest
+func (o options) verify(ctx context.Context, ref name.Reference, keychain authn.Keychain) (string, error) {
+	key := o.key
+	// keys can also be KMS or Kubernetes secret references
+	if key != "" && !strings.Contains(key, "://") {
+		content, err := os.ReadFile(filepath.Clean(key))
+		if err != nil {
+			return "", fmt.Errorf("reading public key: %v", err)
+		}
+		key = string(content)
+	}
+	rclient, err := registryclient.New(registryclient.WithKeychain(keychain))
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(os.Stderr, "Verifying image signature [%s]...\n", ref.Name())
+	response, err := cosign.NewVerifier().VerifySignature(ctx, images.Options{
+		ImageRef:      ref.String(),
+		Client:        rclient,
+		Key:           key,
+		Subject:       o.certificateIdentity,
+		SubjectRegExp: o.certificateIdentityRegexp,
+		Issuer:        o.certificateOidcIssuer,
+		IssuerRegExp:  o.certificateOidcIssuerRegexp,
+		RekorURL:      o.rekorURL,
+		IgnoreTlog:    o.ignoreTlog,
+		IgnoreSCT:     o.ignoreSCT,
+	})
+	if err != nil {
+		return "", err
+	}
+	if response.Digest == "" {
+		return "", errors.New("signature does not contain the image digest")
+	}
+	return response.Digest, nil
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	blob, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("getting layer blob: %v", err)
+	}
+	defer blob.Close()
+	layerBytes, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("reading layer blob: %v", err)
+	}
+	return layerBytes, nil
+}
+
+func saveResource(layer v1.Layer, dir string) error {
+	layerBytes, err := readLayer(layer)
+	if err != nil {
+		return err
+	}
+	var resource unstructured.Unstructured
+	if err := yaml.Unmarshal(layerBytes, &resource.Object); err != nil {
+		return fmt.Errorf("unmarshaling layer blob: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("unable to create directory %s: %w", dir, err)
+	}
+	fileName := resource.GetName() + ".yaml"
+	if resource.GetNamespace() != "" {
+		fileName = resource.GetNamespace() + "-" + fileName
+	}
+	path, err := securejoin.SecureJoin(dir, fileName)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Saving %s into disk [%s]...\n", resource.GetKind(), path)
+	if err := os.WriteFile(path, layerBytes, 0o600); err != nil {
+		return fmt.Errorf("creating file: %v", err)
+	}
+	return nil
+}
+
+// unpackTest extracts a test layer, the blob is closed before the next layer is processed
+func unpackTest(layer v1.Layer, dir string) error {
+	blob, err := layer.Compressed()
+	if err != nil {
+		return fmt.Errorf("getting layer blob: %v", err)
+	}
+	defer blob.Close()
+	if _, err := internal.UnpackTest(blob, dir); err != nil {
+		return fmt.Errorf("extracting test: %v", err)
+	}
+	return nil
+}
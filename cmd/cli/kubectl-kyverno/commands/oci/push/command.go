@@ -26,6 +26,10 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVarP(&options.testFileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
+	cmd.Flags().StringVar(&options.key, "key", "", "path to the cosign private key used to sign the image, the key password is read from COSIGN_PASSWORD (keyless signing is not supported)")
+	cmd.Flags().BoolVar(&options.tlogUpload, "tlog-upload", true, "upload the signature to the Rekor transparency log, ignored when --key is not set")
+	cmd.Flags().StringVar(&options.rekorURL, "rekor-url", "https://rekor.sigstore.dev", "address of the Rekor server")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
@@ -4,6 +4,16 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#pushing`
 
 var description = []string{
 	`Push policie(s) that are included in an OCI image to OCI registry.`,
+	``,
+	`Policy exceptions, global context entries and tests found in the directory are added to the image.`,
+	`Tests are packed together with the local files they reference, these files must be in the directory.`,
+	``,
+	`The image is signed with cosign when a private key is provided and the signature is uploaded to the Rekor`,
+	`transparency log, as kyverno oci pull verifies it by default. Use --tlog-upload=false for private registries`,
+	`without access to Rekor, images must then be pulled with --ignore-tlog.`,
+	`Signed images are pushed by digest and only tagged once the signature is uploaded.`,
+	``,
+	`Keyless signing is not supported, images can be signed keyless with cosign after they are pushed.`,
 }
 
 var examples = [][]string{
@@ -15,4 +25,12 @@ var examples = [][]string{
 		`# Push multiple policies to an OCI image from a given directory that includes policies`,
 		`kyverno oci push . -i <imgref>`,
 	},
+	{
+		`# Push and sign policies, exceptions and tests from a given directory`,
+		`COSIGN_PASSWORD=<password> kyverno oci push . -i <imgref> --key cosign.key`,
+	},
+	{
+		`# Push and sign policies without uploading the signature to the transparency log`,
+		`COSIGN_PASSWORD=<password> kyverno oci push . -i <imgref> --key cosign.key --tlog-upload=false`,
+	},
 }
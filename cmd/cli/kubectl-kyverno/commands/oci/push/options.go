@@ -1,27 +1,56 @@
 package push
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/static"
 	"github.com/google/go-containerregistry/pkg/v1/types"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/experimental"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	exceptionV2beta1     = schema.GroupVersion(kyvernov2beta1.GroupVersion).WithKind("PolicyException")
+	exceptionV2          = schema.GroupVersion(kyvernov2.GroupVersion).WithKind("PolicyException")
+	globalContextEntryV2 = schema.GroupVersion(kyvernov2alpha1.GroupVersion).WithKind("GlobalContextEntry")
 )
 
 type options struct {
-	imageRef string
+	imageRef     string
+	testFileName string
+	key          string
+	tlogUpload   bool
+	rekorURL     string
 }
 
 func (o options) validate(policy string) error {
@@ -35,7 +64,7 @@ func (o options) validate(policy string) error {
 }
 
 func (o options) execute(ctx context.Context, dir string, keychain authn.Keychain) error {
-	results, err := policy.Load(nil, "", dir)
+	results, err := policy.LoadWithLoader(policyLoader, nil, "", dir)
 	if err != nil {
 		return fmt.Errorf("unable to read policy file or directory %s (%w)", dir, err)
 	}
@@ -45,6 +74,10 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 			return fmt.Errorf("validating policy %s: %v", policy.GetName(), err)
 		}
 	}
+	exceptions, globalContextEntries, err := loadResources(dir)
+	if err != nil {
+		return fmt.Errorf("unable to read resources from %s (%w)", dir, err)
+	}
 	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
 	img = mutate.ConfigMediaType(img, internal.PolicyConfigMediaType)
 	ref, err := name.ParseReference(o.imageRef)
@@ -61,19 +94,196 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 		if err != nil {
 			return fmt.Errorf("converting policy to yaml: %v", err)
 		}
-		policyLayer := static.NewLayer(policyBytes, internal.PolicyLayerMediaType)
-		img, err = mutate.Append(img, mutate.Addendum{
-			Layer:       policyLayer,
-			Annotations: internal.Annotations(policy),
-		})
+		img, err = appendLayer(img, policyBytes, internal.PolicyLayerMediaType, internal.Annotations(policy))
+		if err != nil {
+			return err
+		}
+	}
+	for _, exception := range exceptions {
+		fmt.Fprintf(os.Stderr, "Adding policy exception [%s/%s]\n", exception.GetNamespace(), exception.GetName())
+		img, err = appendResource(img, exception, internal.ExceptionLayerMediaType)
+		if err != nil {
+			return err
+		}
+	}
+	for _, entry := range globalContextEntries {
+		fmt.Fprintf(os.Stderr, "Adding global context entry [%s]\n", entry.GetName())
+		img, err = appendResource(img, entry, internal.GlobalContextEntryLayerMediaType)
+		if err != nil {
+			return err
+		}
+	}
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		tests, err := test.LoadTests(dir, o.testFileName)
 		if err != nil {
-			return fmt.Errorf("mutating image: %v", err)
+			return fmt.Errorf("unable to read tests from %s (%w)", dir, err)
+		}
+		for _, test := range tests {
+			if test.Err != nil {
+				return fmt.Errorf("unable to read test %s (%w)", test.Path, test.Err)
+			}
+			path, err := filepath.Rel(dir, test.Path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Adding test [%s]\n", path)
+			testBytes, err := internal.PackTest(dir, test)
+			if err != nil {
+				return fmt.Errorf("packing test %s: %v", path, err)
+			}
+			img, err = appendLayer(img, testBytes, internal.TestLayerMediaType, internal.AnnotationsForTest(filepath.ToSlash(path)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	options := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+	if o.key == "" {
+		fmt.Fprintf(os.Stderr, "Uploading [%s]...\n", ref.Name())
+		if err = remote.Write(ref, img, options...); err != nil {
+			return fmt.Errorf("writing image: %v", err)
 		}
+		fmt.Fprintf(os.Stderr, "Done.")
+		return nil
 	}
-	fmt.Fprintf(os.Stderr, "Uploading [%s]...\n", ref.Name())
-	if err = remote.Write(ref, img, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)); err != nil {
+	// push by digest and sign before tagging, the tag must never point to an unsigned image
+	digest, err := img.Digest()
+	if err != nil {
+		return fmt.Errorf("getting image digest: %v", err)
+	}
+	digestRef := ref.Context().Digest(digest.String())
+	fmt.Fprintf(os.Stderr, "Uploading [%s]...\n", digestRef.Name())
+	if err = remote.Write(digestRef, img, options...); err != nil {
 		return fmt.Errorf("writing image: %v", err)
 	}
+	if err := o.sign(ctx, digestRef, keychain); err != nil {
+		return err
+	}
+	if tag, ok := ref.(name.Tag); ok {
+		fmt.Fprintf(os.Stderr, "Tagging [%s]...\n", tag.Name())
+		if err := remote.Tag(tag, img, options...); err != nil {
+			return fmt.Errorf("tagging image: %v", err)
+		}
+	}
 	fmt.Fprintf(os.Stderr, "Done.")
 	return nil
 }
+
+func (o options) sign(ctx context.Context, digestRef name.Digest, keychain authn.Keychain) error {
+	key, err := os.ReadFile(filepath.Clean(o.key))
+	if err != nil {
+		return fmt.Errorf("reading private key: %v", err)
+	}
+	rclient, err := registryclient.New(registryclient.WithKeychain(keychain))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Signing [%s]...\n", digestRef.Name())
+	if _, err := cosign.Sign(ctx, cosign.SignOptions{
+		ImageRef:   digestRef.String(),
+		Client:     rclient,
+		Key:        key,
+		Password:   []byte(os.Getenv("COSIGN_PASSWORD")),
+		TlogUpload: o.tlogUpload,
+		RekorURL:   o.rekorURL,
+	}); err != nil {
+		return fmt.Errorf("signing image: %v", err)
+	}
+	return nil
+}
+
+// policyLoader loads policies only, the other documents of the bundle (exceptions, test resources...) are skipped
+func policyLoader(path string, content []byte) (*policy.LoaderResults, error) {
+	documents, err := extyaml.SplitDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+	var policies [][]byte
+	for _, document := range documents {
+		resource, err := resource.YamlToUnstructured(document)
+		if err != nil {
+			continue
+		}
+		if resource.GroupVersionKind().Group == kyvernov1.GroupName {
+			switch resource.GetKind() {
+			case "ClusterPolicy", "Policy":
+				policies = append(policies, document)
+			}
+		}
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	content = bytes.Join(policies, []byte("\n---\n"))
+	if experimental.UseKubectlValidate() {
+		return policy.KubectlValidateLoader(path, content)
+	}
+	return policy.LegacyLoader(path, content)
+}
+
+// loadResources returns the policy exceptions and global context entries found in path
+func loadResources(path string) ([]*unstructured.Unstructured, []*unstructured.Unstructured, error) {
+	var exceptions, globalContextEntries []*unstructured.Unstructured
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file != path && strings.HasPrefix(entry.Name(), ".") {
+			// skip hidden files and dirs
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !gitutils.IsYaml(info) {
+			return nil
+		}
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		resources, err := resource.GetUnstructuredResources(content)
+		if err != nil {
+			// not every yaml file contains kubernetes resources (values files for example)
+			return nil
+		}
+		for _, resource := range resources {
+			switch resource.GroupVersionKind() {
+			case exceptionV2beta1, exceptionV2:
+				exceptions = append(exceptions, resource)
+			case globalContextEntryV2:
+				// global context entries are cluster scoped
+				resource.SetNamespace("")
+				globalContextEntries = append(globalContextEntries, resource)
+			}
+		}
+		return nil
+	})
+	return exceptions, globalContextEntries, err
+}
+
+func appendResource(img v1.Image, resource *unstructured.Unstructured, mediaType types.MediaType) (v1.Image, error) {
+	resourceBytes, err := yaml.Marshal(resource.Object)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s to yaml: %v", resource.GetKind(), err)
+	}
+	return appendLayer(img, resourceBytes, mediaType, internal.ResourceAnnotations(resource))
+}
+
+func appendLayer(img v1.Image, content []byte, mediaType types.MediaType, annotations map[string]string) (v1.Image, error) {
+	img, err := mutate.Append(img, mutate.Addendum{
+		Layer:       static.NewLayer(content, mediaType),
+		Annotations: annotations,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mutating image: %v", err)
+	}
+	return img, nil
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+// testCache stores the results of test cases that passed, keyed by test file path.
+type testCache struct {
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+type cacheEntry struct {
+	Hash string `json:"hash"`
+	Pass int    `json:"pass"`
+	Skip int    `json:"skip"`
+}
+
+// loadCache reads the cache file, a missing file gives an empty cache.
+func loadCache(path string) (*testCache, error) {
+	cache := &testCache{Entries: map[string]cacheEntry{}}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, cache); err != nil {
+		return nil, err
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string]cacheEntry{}
+	}
+	return cache, nil
+}
+
+func (c *testCache) lookup(path string, hash string) (cacheEntry, bool) {
+	if hash == "" {
+		return cacheEntry{}, false
+	}
+	entry, ok := c.Entries[path]
+	if !ok || entry.Hash != hash {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *testCache) store(path string, hash string, rc resultCounts) {
+	c.Entries[path] = cacheEntry{Hash: hash, Pass: rc.Pass, Skip: rc.Skip}
+}
+
+func (c *testCache) remove(path string) {
+	delete(c.Entries, path)
+}
+
+func (c *testCache) save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), content, 0o600)
+}
+
+// testHash computes a digest of the files a test case depends on, the CLI version and the test selector.
+// It returns an empty string when the test case can't be cached (git repositories or remote files).
+func testHash(tc test.TestCase, selector string) string {
+	paths, ok := testFiles(tc)
+	if !ok {
+		return ""
+	}
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, version.Version()+"\n"+version.Hash()+"\n"+selector+"\n")
+	for _, path := range paths {
+		if err := hashPath(hash, path); err != nil {
+			return ""
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// testFiles returns the test file and the local files it references,
+// it returns false for test cases loaded from git repositories or referencing remote files.
+func testFiles(tc test.TestCase) ([]string, bool) {
+	if tc.Fs != nil || tc.Test == nil {
+		return nil, false
+	}
+	paths := []string{tc.Path}
+	remote := false
+	for _, file := range tc.References() {
+		if source.IsHttp(file) {
+			remote = true
+		} else {
+			paths = append(paths, filepath.Join(tc.Dir(), file))
+		}
+	}
+	return paths, !remote
+}
+
+// hashPath adds the names and contents of a file, or of all files in a directory, to the hash.
+func hashPath(hash io.Writer, root string) error {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, path := range files {
+		file, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		_, _ = io.WriteString(hash, path+"\n")
+		_, err = io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	cache, err := loadCache(path)
+	assert.NoError(t, err)
+	assert.Empty(t, cache.Entries)
+	cache.store("a/kyverno-test.yaml", "123", resultCounts{Pass: 2, Skip: 1, Fail: 0})
+	cache.store("b/kyverno-test.yaml", "456", resultCounts{Pass: 1})
+	cache.remove("b/kyverno-test.yaml")
+	assert.NoError(t, cache.save(path))
+	cache, err = loadCache(path)
+	assert.NoError(t, err)
+	entry, ok := cache.lookup("a/kyverno-test.yaml", "123")
+	assert.True(t, ok)
+	assert.Equal(t, cacheEntry{Hash: "123", Pass: 2, Skip: 1}, entry)
+	_, ok = cache.lookup("a/kyverno-test.yaml", "789")
+	assert.False(t, ok)
+	_, ok = cache.lookup("a/kyverno-test.yaml", "")
+	assert.False(t, ok)
+	_, ok = cache.lookup("b/kyverno-test.yaml", "456")
+	assert.False(t, ok)
+}
+
+func TestLoadCacheInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err := loadCache(path)
+	assert.Error(t, err)
+}
+
+func TestTestHash(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("kyverno-test.yaml", "test")
+	write("policy.yaml", "policy")
+	write("resources.yaml", "resources")
+	tc := test.TestCase{
+		Path: filepath.Join(dir, "kyverno-test.yaml"),
+		Test: &v1alpha1.Test{
+			Policies:  []string{"policy.yaml"},
+			Resources: []string{"resources.yaml"},
+		},
+	}
+	hash := testHash(tc, "")
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, hash, testHash(tc, ""))
+	assert.NotEqual(t, hash, testHash(tc, "policy=foo"))
+	write("resources.yaml", "changed")
+	assert.NotEqual(t, hash, testHash(tc, ""))
+	// missing files, remote files and git repositories are not cached
+	assert.Empty(t, testHash(test.TestCase{Path: tc.Path, Test: &v1alpha1.Test{Policies: []string{"missing.yaml"}}}, ""))
+	assert.Empty(t, testHash(test.TestCase{Path: tc.Path, Test: &v1alpha1.Test{Policies: []string{"https://example.com/policy.yaml"}}}, ""))
+	assert.Empty(t, testHash(test.TestCase{Path: tc.Path, Fs: memfs.New(), Test: tc.Test}, ""))
+}
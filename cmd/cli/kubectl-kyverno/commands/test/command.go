@@ -8,10 +8,10 @@ import (
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
-	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/filter"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -22,7 +22,9 @@ import (
 func Command() *cobra.Command {
 	var testCase string
 	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, detailedResults bool
+	var registryAccess, failOnly, removeColor, detailedResults, incremental, watch bool
+	var parallel int
+	var cacheFile string
 	cmd := &cobra.Command{
 		Use:          "test [local folder or git repository]...",
 		Short:        command.FormatDescription(true, websiteUrl, false, description...),
@@ -32,7 +34,16 @@ func Command() *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, dirPath []string) (err error) {
 			color.Init(removeColor)
-			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults)
+			if parallel < 1 {
+				return fmt.Errorf("invalid parallel value %d (must be at least 1)", parallel)
+			}
+			if watch {
+				if incremental {
+					return fmt.Errorf("--incremental can't be used with --watch")
+				}
+				return testCommandWatch(cmd.Context(), cmd.OutOrStdout(), dirPath, fileName, testCase, registryAccess, failOnly, detailedResults, parallel)
+			}
+			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults, parallel, incremental, cacheFile)
 		},
 	}
 	cmd.Flags().StringVarP(&fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
@@ -42,6 +53,10 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
+	cmd.Flags().IntVarP(&parallel, "parallel", "j", 1, "Number of test cases run concurrently, output is printed in the same order as a sequential run")
+	cmd.Flags().BoolVar(&incremental, "incremental", false, "If set to true, skip local test cases that passed in a previous run and whose files did not change since")
+	cmd.Flags().StringVar(&cacheFile, "cache-file", ".kyverno-test-cache.json", "File storing the results of previous runs, used with --incremental")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "If set to true, watch test files and the files they reference, and run affected test cases again on every change")
 	return cmd
 }
 
@@ -60,6 +75,9 @@ func testCommandExecute(
 	registryAccess bool,
 	failOnly bool,
 	detailedResults bool,
+	parallel int,
+	incremental bool,
+	cacheFile string,
 ) (err error) {
 	// check input dir
 	if len(dirPath) == 0 {
@@ -101,11 +119,31 @@ func testCommandExecute(
 			return errors[0]
 		}
 	}
-	rc := &resultCounts{}
-	var fullTable table.Table
+	var cache *testCache
+	if incremental {
+		cache, err = loadCache(cacheFile)
+		if err != nil {
+			return fmt.Errorf("failed to load test cache (%w)", err)
+		}
+	}
+	runs := buildRuns(tests, filter, cache, testCase)
+	rc, fullTable, err := executeRuns(out, runs, parallel, registryAccess, detailedResults, cache)
+	if err != nil {
+		return err
+	}
+	if cache != nil {
+		if err := cache.save(cacheFile); err != nil {
+			return fmt.Errorf("failed to save test cache (%w)", err)
+		}
+	}
+	return printSummary(out, rc, fullTable, failOnly, detailedResults)
+}
+
+// buildRuns returns the test cases to run, in order, with their results filtered.
+func buildRuns(tests test.TestCases, filter filter.Filter, cache *testCache, testCase string) []*testRun {
+	var runs []*testRun
 	for _, test := range tests {
 		if test.Err == nil {
-			deprecations.CheckTest(out, test.Path, test.Test)
 			// filter results
 			var filteredResults []v1alpha1.TestResult
 			for _, res := range test.Test.Results {
@@ -113,29 +151,75 @@ func testCommandExecute(
 					filteredResults = append(filteredResults, res)
 				}
 			}
-			if len(filteredResults) == 0 {
+			// filter cleanup results
+			var filteredCleanupResults []v1alpha1.CleanupResult
+			if test.Test.Cleanup != nil {
+				for _, res := range test.Test.Cleanup.Results {
+					if filter.Apply(v1alpha1.TestResult{TestResultBase: v1alpha1.TestResultBase{Policy: res.Policy}}) {
+						filteredCleanupResults = append(filteredCleanupResults, res)
+					}
+				}
+			}
+			if len(filteredResults) == 0 && len(filteredCleanupResults) == 0 {
 				continue
 			}
-			resourcePath := filepath.Dir(test.Path)
-			responses, err := runTest(out, test, registryAccess)
-			if err != nil {
-				return fmt.Errorf("failed to run test (%w)", err)
+			run := &testRun{
+				test:           test,
+				results:        filteredResults,
+				cleanupResults: filteredCleanupResults,
 			}
-			fmt.Fprintln(out, "  Checking results ...")
-			var resultsTable table.Table
-			if err := printTestResult(filteredResults, responses, rc, &resultsTable, test.Fs, resourcePath); err != nil {
-				return fmt.Errorf("failed to print test result (%w)", err)
+			if cache != nil {
+				run.hash = testHash(test, testCase)
+				if entry, ok := cache.lookup(test.Path, run.hash); ok {
+					run.cached = &entry
+				}
 			}
-			if err := printCheckResult(test.Test.Checks, *responses, rc, &resultsTable); err != nil {
-				return fmt.Errorf("failed to print test result (%w)", err)
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// executeRuns runs test cases, concurrently if requested, and prints outputs in order.
+func executeRuns(out io.Writer, runs []*testRun, parallel int, registryAccess bool, detailedResults bool, cache *testCache) (*resultCounts, table.Table, error) {
+	stop := runTests(runs, parallel, registryAccess)
+	defer stop()
+	rc := &resultCounts{}
+	var fullTable table.Table
+	for _, run := range runs {
+		<-run.done
+		if run.cached != nil {
+			fmt.Fprintln(out, "Skipping test", run.test.Test.Name, "(", run.test.Path, ")", "unchanged since last successful run")
+			rc.Pass += run.cached.Pass
+			rc.Skip += run.cached.Skip
+			continue
+		}
+		if _, err := io.Copy(out, &run.output); err != nil {
+			return rc, fullTable, err
+		}
+		if run.err != nil {
+			return rc, fullTable, run.err
+		}
+		rc.Pass += run.rc.Pass
+		rc.Skip += run.rc.Skip
+		rc.Fail += run.rc.Fail
+		fullTable.AddFailed(run.table.RawRows...)
+		printer := table.NewTablePrinter(out)
+		fmt.Fprintln(out)
+		printer.Print(run.table.Rows(detailedResults))
+		fmt.Fprintln(out)
+		if cache != nil && run.hash != "" {
+			if run.rc.Fail == 0 {
+				cache.store(run.test.Path, run.hash, run.rc)
+			} else {
+				cache.remove(run.test.Path)
 			}
-			fullTable.AddFailed(resultsTable.RawRows...)
-			printer := table.NewTablePrinter(out)
-			fmt.Fprintln(out)
-			printer.Print(resultsTable.Rows(detailedResults))
-			fmt.Fprintln(out)
 		}
 	}
+	return rc, fullTable, nil
+}
+
+func printSummary(out io.Writer, rc *resultCounts, fullTable table.Table, failOnly bool, detailedResults bool) error {
 	if !failOnly {
 		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
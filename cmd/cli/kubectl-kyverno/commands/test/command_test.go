@@ -3,6 +3,8 @@ package test
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -48,3 +50,83 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestCommandParallel(t *testing.T) {
+	run := func(parallel string) string {
+		cmd := Command()
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{
+			"../../../../../test/cli/test/exclude",
+			"../../../../../test/cli/test/foreach",
+			"../../../../../test/cli/test/autogen",
+			"--remove-color",
+			"--parallel", parallel,
+		})
+		assert.NoError(t, cmd.Execute())
+		return b.String()
+	}
+	assert.Equal(t, run("1"), run("3"))
+}
+
+func TestCommandWithInvalidParallel(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--parallel", "0"})
+	assert.Error(t, cmd.Execute())
+	assert.Equal(t, "Error: invalid parallel value 0 (must be at least 1)", strings.TrimSpace(b.String()))
+}
+
+func TestCommandIncremental(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"kyverno-test.yaml", "policy.yaml", "resources.yaml"} {
+		content, err := os.ReadFile(filepath.Join("../../../../../test/cli/test/exclude", name))
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o600))
+	}
+	cacheFile := filepath.Join(t.TempDir(), "cache.json")
+	run := func() string {
+		cmd := Command()
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{dir, "--remove-color", "--incremental", "--cache-file", cacheFile})
+		assert.NoError(t, cmd.Execute())
+		return b.String()
+	}
+	first := run()
+	assert.NotContains(t, first, "unchanged since last successful run")
+	second := run()
+	assert.Contains(t, second, "unchanged since last successful run")
+	assert.Equal(t, lastLine(first), lastLine(second))
+	// changing a referenced file invalidates the cache
+	resources, err := os.OpenFile(filepath.Join(dir, "resources.yaml"), os.O_APPEND|os.O_WRONLY, 0o600)
+	assert.NoError(t, err)
+	_, err = resources.WriteString("\n")
+	assert.NoError(t, err)
+	assert.NoError(t, resources.Close())
+	assert.NotContains(t, run(), "unchanged since last successful run")
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return lines[len(lines)-1]
+}
+
+func TestCommandWatchWithIncremental(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--watch", "--incremental"})
+	assert.Error(t, cmd.Execute())
+	assert.Equal(t, "Error: --incremental can't be used with --watch", strings.TrimSpace(b.String()))
+}
+
+func TestCommandWatchWithGit(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"https://github.com/kyverno/policies/pod-security", "--watch"})
+	assert.Error(t, cmd.Execute())
+	assert.Equal(t, "Error: watch mode is not supported for git repositories (https://github.com/kyverno/policies/pod-security)", strings.TrimSpace(b.String()))
+}
@@ -10,6 +10,11 @@ var description = []string{
 	``,
 	`Users provide the path to the folder containing a kyverno-test.yaml file where the location could be`,
 	`on a local filesystem or a remote git repository.`,
+	``,
+	`Resources can be kustomizations or local Helm charts. Charts are rendered without the Helm SDK,`,
+	`charts with dependencies or subcharts and library charts are rejected, .Capabilities reports`,
+	`Kubernetes v1.31.0 and charts using hooks, lookup, .Capabilities.APIVersions.Has or .Files.Glob`,
+	`fail with an error. Values are validated against values.schema.json.`,
 }
 
 var examples = [][]string{
@@ -25,4 +30,12 @@ var examples = [][]string{
 		`# Test some specific test cases out of many test cases in a local folder`,
 		`kyverno test . --test-case-selector "policy=disallow-latest-tag, rule=require-image-tag, resource=test-require-image-tag-pass"`,
 	},
+	{
+		`# Run test cases concurrently and skip the ones that passed and did not change since the last run`,
+		`kyverno test . --parallel 4 --incremental`,
+	},
+	{
+		`# Run test cases again every time a test manifest or a file it references changes`,
+		`kyverno test . --watch`,
+	},
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno-json/pkg/engine/assert"
+	"github.com/kyverno/kyverno/api/kyverno"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
@@ -404,3 +405,77 @@ func printFailedTestResult(out io.Writer, resultsTable table.Table, detailedResu
 	fmt.Fprintln(out)
 	printer.Print(resultsTable.Rows(detailedResults))
 }
+
+func printCleanupResult(
+	results []v1alpha1.CleanupResult,
+	responses TestResponse,
+	rc *resultCounts,
+	resultsTable *table.Table,
+) {
+	testCount := 1
+	for _, result := range results {
+		policy := result.Policy
+		if policy == "" {
+			policy = kyverno.LabelCleanupTtl
+		}
+		for _, resource := range result.Resources {
+			namespace, name := "", resource
+			if parts := strings.Split(resource, "/"); len(parts) == 2 {
+				namespace, name = parts[0], parts[1]
+			}
+			row := table.Row{
+				RowCompact: table.RowCompact{
+					ID:       testCount,
+					Policy:   color.Policy("", policy),
+					Resource: color.Resource(result.Kind, namespace, name),
+				},
+			}
+			found := false
+			for key := range responses.Trigger {
+				parts := strings.Split(key, ",")
+				if parts[1] == result.Kind && parts[2] == namespace && parts[3] == name {
+					found = true
+					break
+				}
+			}
+			deleted := false
+			for _, deletion := range responses.Cleanup {
+				if deletion.Policy == result.Policy &&
+					deletion.Resource.GetKind() == result.Kind &&
+					deletion.Resource.GetNamespace() == namespace &&
+					deletion.Resource.GetName() == name {
+					deleted = true
+					break
+				}
+			}
+			switch {
+			case !found:
+				row.Result = color.ResultFail()
+				row.Reason = color.NotFound()
+				row.Message = color.NotFound()
+				row.IsFailure = true
+				rc.Fail++
+			case deleted == result.Deleted:
+				row.Result = color.ResultPass()
+				row.Reason = "Ok"
+				row.Message = cleanupStatus(deleted)
+				rc.Pass++
+			default:
+				row.Result = color.ResultFail()
+				row.Reason = fmt.Sprintf("Want %s, got %s", cleanupStatus(result.Deleted), cleanupStatus(deleted))
+				row.Message = cleanupStatus(deleted)
+				row.IsFailure = true
+				rc.Fail++
+			}
+			resultsTable.Add(row)
+			testCount++
+		}
+	}
+}
+
+func cleanupStatus(deleted bool) string {
+	if deleted {
+		return "deleted"
+	}
+	return "retained"
+}
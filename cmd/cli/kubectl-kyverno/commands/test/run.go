@@ -0,0 +1,88 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+// testRun holds a test case to run and the outcome of its execution,
+// output is buffered so that it can be printed in order once all runs completed.
+type testRun struct {
+	test           test.TestCase
+	results        []v1alpha1.TestResult
+	cleanupResults []v1alpha1.CleanupResult
+	// hash of the test case files, only set in incremental mode
+	hash string
+	// cached is set when the test case passed in a previous run and did not change since
+	cached *cacheEntry
+	output bytes.Buffer
+	rc     resultCounts
+	table  table.Table
+	err    error
+	// done is closed when the run completed
+	done chan struct{}
+}
+
+func (r *testRun) execute(registryAccess bool) {
+	out := &r.output
+	deprecations.CheckTest(out, r.test.Path, r.test.Test)
+	responses, err := runTest(out, r.test, registryAccess)
+	if err != nil {
+		r.err = fmt.Errorf("failed to run test (%w)", err)
+		return
+	}
+	fmt.Fprintln(out, "  Checking results ...")
+	resourcePath := filepath.Dir(r.test.Path)
+	if err := printTestResult(r.results, responses, &r.rc, &r.table, r.test.Fs, resourcePath); err != nil {
+		r.err = fmt.Errorf("failed to print test result (%w)", err)
+		return
+	}
+	if err := printCheckResult(r.test.Test.Checks, *responses, &r.rc, &r.table); err != nil {
+		r.err = fmt.Errorf("failed to print test result (%w)", err)
+		return
+	}
+	printCleanupResult(r.cleanupResults, *responses, &r.rc, &r.table)
+}
+
+// runTests starts running test cases using the given number of workers, cached test cases are not run.
+// Callers wait for each run to be done, the returned function stops scheduling new runs.
+func runTests(runs []*testRun, workers int, registryAccess bool) func() {
+	queue := make(chan *testRun)
+	stop := make(chan struct{})
+	for _, run := range runs {
+		run.done = make(chan struct{})
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for run := range queue {
+				run.execute(registryAccess)
+				close(run.done)
+			}
+		}()
+	}
+	go func() {
+		defer close(queue)
+		for _, run := range runs {
+			if run.cached != nil {
+				close(run.done)
+				continue
+			}
+			select {
+			case queue <- run:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
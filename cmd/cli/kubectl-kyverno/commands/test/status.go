@@ -0,0 +1,52 @@
+package test
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+// Status holds the outcome of the results checked for a policy.
+type Status struct {
+	Skip int
+	Pass int
+	Fail int
+}
+
+// Run runs a test case and checks its results the same way the test command does.
+// The returned statuses are keyed by policy, as referenced in the test results.
+func Run(out io.Writer, testCase test.TestCase, registryAccess bool) (map[string]Status, error) {
+	responses, err := runTest(out, testCase, registryAccess)
+	if err != nil {
+		return nil, err
+	}
+	results := map[string][]v1alpha1.TestResult{}
+	for _, result := range testCase.Test.Results {
+		results[result.Policy] = append(results[result.Policy], result)
+	}
+	cleanupResults := map[string][]v1alpha1.CleanupResult{}
+	if testCase.Test.Cleanup != nil {
+		for _, result := range testCase.Test.Cleanup.Results {
+			cleanupResults[result.Policy] = append(cleanupResults[result.Policy], result)
+		}
+	}
+	statuses := map[string]Status{}
+	for policy, results := range results {
+		var rc resultCounts
+		var resultsTable table.Table
+		if err := printTestResult(results, responses, &rc, &resultsTable, testCase.Fs, filepath.Dir(testCase.Path)); err != nil {
+			return nil, err
+		}
+		statuses[policy] = Status(rc)
+	}
+	for policy, results := range cleanupResults {
+		rc := resultCounts(statuses[policy])
+		var resultsTable table.Table
+		printCleanupResult(results, *responses, &rc, &resultsTable)
+		statuses[policy] = Status(rc)
+	}
+	return statuses, nil
+}
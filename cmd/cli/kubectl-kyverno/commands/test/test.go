@@ -3,15 +3,18 @@ package test
 import (
 	"fmt"
 	"io"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/cleanup"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/render"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
@@ -24,7 +27,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,6 +38,26 @@ import (
 type TestResponse struct {
 	Trigger map[string][]engineapi.EngineResponse
 	Target  map[string][]engineapi.EngineResponse
+	Cleanup []cleanup.Deletion
+}
+
+// loadResources renders kustomizations and charts of local tests and loads the other resources
+func loadResources(out io.Writer, testCase test.TestCase, paths []string, policies []kyvernov1.PolicyInterface, vaps []admissionregistrationv1beta1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
+	testDir := testCase.Dir()
+	var rendered []*unstructured.Unstructured
+	if testCase.Fs == nil {
+		var err error
+		helmValues := path.GetFullPaths(testCase.Test.HelmValues, testDir, false)
+		rendered, paths, err = render.Render(paths, render.Options{HelmValues: helmValues})
+		if err != nil {
+			return nil, err
+		}
+	}
+	resources, err := common.GetResourceAccordingToResourcePath(out, testCase.Fs, paths, false, policies, vaps, dClient, "", false, testDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(rendered, resources...), nil
 }
 
 func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestResponse, error) {
@@ -72,7 +97,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	// resources
 	fmt.Fprintln(out, "  Loading resources", "...")
 	resourceFullPath := path.GetFullPaths(testCase.Test.Resources, testDir, isGit)
-	resources, err := common.GetResourceAccordingToResourcePath(out, testCase.Fs, resourceFullPath, false, results.Policies, results.VAPs, dClient, "", false, testDir)
+	resources, err := loadResources(out, testCase, resourceFullPath, results.Policies, results.VAPs, dClient)
 	if err != nil {
 		return nil, fmt.Errorf("error: failed to load resources (%s)", err)
 	}
@@ -84,7 +109,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	}
 
 	targetResourcesPath := path.GetFullPaths(testCase.Test.TargetResources, testDir, isGit)
-	targetResources, err := common.GetResourceAccordingToResourcePath(out, testCase.Fs, targetResourcesPath, false, results.Policies, results.VAPs, dClient, "", false, testDir)
+	targetResources, err := loadResources(out, testCase, targetResourcesPath, results.Policies, results.VAPs, dClient)
 	if err != nil {
 		return nil, fmt.Errorf("error: failed to load target resources (%s)", err)
 	}
@@ -116,6 +141,18 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	var store store.Store
 	store.SetLocal(true)
 	store.SetRegistryAccess(registryAccess)
+	if len(testCase.Test.ImageLayouts) != 0 {
+		if isGit {
+			return nil, fmt.Errorf("error: image layouts are not supported with git repositories")
+		}
+		fmt.Fprintln(out, "  Loading image layouts", "...")
+		imageLayoutFullPath := path.GetFullPaths(testCase.Test.ImageLayouts, testDir, isGit)
+		registryClient, err := registryclient.NewFromLayout(imageLayoutFullPath...)
+		if err != nil {
+			return nil, fmt.Errorf("error: failed to load image layouts (%s)", err)
+		}
+		store.SetRegistryClient(registryClient)
+	}
 	if vars != nil {
 		vars.SetInStore(&store)
 	}
@@ -246,6 +283,32 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 		resourceKey := generateResourceKey(resource)
 		testResponse.Trigger[resourceKey] = append(testResponse.Trigger[resourceKey], ers...)
 	}
+	// cleanup policies and ttl labels
+	if testCase.Test.Cleanup != nil {
+		if isGit {
+			return nil, fmt.Errorf("error: cleanup policies are not supported with git repositories")
+		}
+		fmt.Fprintln(out, "  Loading cleanup policies", "...")
+		cleanupPolicyFullPath := path.GetFullPaths(testCase.Test.Cleanup.Policies, testDir, isGit)
+		cleanupPolicies, err := cleanup.Load(cleanupPolicyFullPath...)
+		if err != nil {
+			return nil, fmt.Errorf("error: failed to load cleanup policies (%s)", err)
+		}
+		now := time.Now()
+		if testCase.Test.Cleanup.Time != nil {
+			now = testCase.Test.Cleanup.Time.Time
+		}
+		fmt.Fprintln(out, "  Evaluating", len(cleanupPolicies), pluralize.Pluralize(len(cleanupPolicies), "cleanup policy", "cleanup policies"), "and ttl labels at", now.UTC().Format(time.RFC3339), "...")
+		cleanupResources := make([]*unstructured.Unstructured, 0, len(uniques))
+		for _, resource := range uniques {
+			cleanupResources = append(cleanupResources, resource)
+		}
+		deletions, err := cleanup.Evaluate(cleanupPolicies, cleanupResources, now, vars.NamespaceSelectors())
+		if err != nil {
+			return nil, fmt.Errorf("error: failed to evaluate cleanup policies (%s)", err)
+		}
+		testResponse.Cleanup = deletions
+	}
 	// this is an array of responses of all policies, generated by all of their rules
 	return &testResponse, nil
 }
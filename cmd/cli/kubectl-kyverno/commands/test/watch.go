@@ -0,0 +1,131 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/filter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+)
+
+// testCommandWatch runs the tests, then runs again the test cases affected by every change to
+// test manifests or the files they reference, until interrupted.
+func testCommandWatch(
+	ctx context.Context,
+	out io.Writer,
+	dirPath []string,
+	fileName string,
+	testCase string,
+	registryAccess bool,
+	failOnly bool,
+	detailedResults bool,
+	parallel int,
+) error {
+	for _, path := range dirPath {
+		if source.IsGit(path) {
+			return fmt.Errorf("watch mode is not supported for git repositories (%s)", path)
+		}
+	}
+	filter, errors := filter.ParseFilter(testCase)
+	if len(errors) > 0 {
+		// TODO aggregate errors
+		return errors[0]
+	}
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	var tests test.TestCases
+	// results of the previous run, per test case
+	previous := map[string]watch.Results{}
+	run := func(changed []string) {
+		if changed != nil {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Detected changes in", len(changed), "file(s), running affected tests ...")
+		}
+		loaded, err := loadTests(dirPath, fileName, "")
+		if err != nil {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Error loading tests:", err)
+			return
+		}
+		if errs := loaded.Errors(); len(errs) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Test errors:")
+			for _, e := range errs {
+				fmt.Fprintln(out, "  Path:", e.Path)
+				fmt.Fprintln(out, "    Error:", e.Err)
+			}
+		}
+		tests = loaded
+		// forget test cases that were removed
+		for path := range previous {
+			found := false
+			for _, tc := range tests {
+				found = found || tc.Path == path
+			}
+			if !found {
+				delete(previous, path)
+			}
+		}
+		var affected test.TestCases
+		for _, tc := range tests {
+			files, _ := testFiles(tc)
+			if _, ok := previous[tc.Path]; !ok || watch.Affects(files, changed) {
+				affected = append(affected, tc)
+			}
+		}
+		runs := buildRuns(affected, filter, nil, testCase)
+		rc, fullTable, err := executeRuns(out, runs, parallel, registryAccess, detailedResults, nil)
+		if err != nil {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Error:", err)
+		} else {
+			_ = printSummary(out, rc, fullTable, failOnly, detailedResults)
+		}
+		before, after := watch.Results{}, watch.Results{}
+		for _, run := range runs {
+			// runs not completed because of an error are not reported
+			select {
+			case <-run.done:
+			default:
+				continue
+			}
+			for key, value := range previous[run.test.Path] {
+				before[key] = value
+			}
+			results := testResults(run)
+			for key, value := range results {
+				after[key] = value
+			}
+			previous[run.test.Path] = results
+		}
+		if changed != nil {
+			watch.PrintDiff(out, before, after)
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Watching for changes, press Ctrl+C to stop ...")
+	}
+	run(nil)
+	paths := func() []string {
+		paths := append([]string{}, dirPath...)
+		for _, tc := range tests {
+			files, _ := testFiles(tc)
+			paths = append(paths, files...)
+		}
+		return paths
+	}
+	return watch.Run(ctx, paths, run)
+}
+
+// testResults returns the status of every result of a test case run.
+func testResults(run *testRun) watch.Results {
+	results := watch.Results{}
+	for _, row := range run.table.RawRows {
+		results[fmt.Sprintf("%s: %s/%s %s", run.test.Test.Name, row.Policy, row.Rule, row.Resource)] = row.Result
+	}
+	return results
+}
@@ -0,0 +1,33 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Load returns the default configuration updated with the Kyverno ConfigMap stored at the given path.
+// The default configuration is returned if path is empty.
+func Load(path string) (config.Configuration, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	if path == "" {
+		return cfg, nil
+	}
+	bytes, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read configuration file %s (%w)", path, err)
+	}
+	var cm corev1.ConfigMap
+	if err := yaml.Unmarshal(bytes, &cm); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration file %s (%w)", path, err)
+	}
+	if cm.Kind != "ConfigMap" {
+		return nil, fmt.Errorf("configuration file %s must contain a ConfigMap, found %q", path, cm.Kind)
+	}
+	cfg.Load(&cm)
+	return cfg, nil
+}
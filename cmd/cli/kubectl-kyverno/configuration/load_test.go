@@ -0,0 +1,58 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    []config.JMESPathFunction
+		wantErr bool
+	}{{
+		name: "default",
+	}, {
+		name: "configmap",
+		path: write("configmap.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kyverno
+data:
+  jmespathFunctions: '[{"name": "normalize_team", "arguments": ["team"], "jmesPath": "to_lower(team)"}]'
+`),
+		want: []config.JMESPathFunction{{Name: "normalize_team", Arguments: []string{"team"}, JMESPath: "to_lower(team)"}},
+	}, {
+		name:    "not a configmap",
+		path:    write("secret.yaml", "apiVersion: v1\nkind: Secret\n"),
+		wantErr: true,
+	}, {
+		name:    "not found",
+		path:    filepath.Join(dir, "missing.yaml"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.GetJMESPathFunctions())
+			assert.Equal(t, "docker.io", cfg.GetDefaultRegistry())
+		})
+	}
+}
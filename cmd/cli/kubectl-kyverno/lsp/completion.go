@@ -0,0 +1,88 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
+)
+
+// complete returns the completion items at the given position
+func (s *Server) complete(doc *document, pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	line := doc.line(pos.Line)
+	expression, ok := expressionAt(line, pos.Character)
+	if !ok {
+		// condition operators
+		if strings.HasPrefix(strings.TrimLeft(line, " \t-"), "operator:") {
+			for _, name := range operatorNames() {
+				items = append(items, CompletionItem{
+					Label:         name,
+					Kind:          CompletionKindEnum,
+					Documentation: operatorDocs[name],
+				})
+			}
+		}
+		return items
+	}
+	identifier := identifierBefore(expression)
+	if dot := strings.LastIndex(identifier, "."); dot >= 0 {
+		// complete fields of a well known variable
+		if v := lookupVariable(builtinVariables, strings.Split(identifier[:dot], ".")); v != nil {
+			for _, field := range v.fields {
+				items = append(items, CompletionItem{
+					Label:         field.name,
+					Kind:          CompletionKindField,
+					Documentation: field.doc,
+				})
+			}
+		}
+		return items
+	}
+	for _, v := range builtinVariables {
+		items = append(items, CompletionItem{
+			Label:         v.name,
+			Kind:          CompletionKindVariable,
+			Detail:        "builtin variable",
+			Documentation: v.doc,
+		})
+	}
+	if root := doc.documentAt(pos.Line); root != nil {
+		seen := map[string]bool{}
+		for _, entry := range contextEntries(root, pos.Line) {
+			if seen[entry.name] {
+				continue
+			}
+			seen[entry.name] = true
+			items = append(items, CompletionItem{
+				Label:  entry.name,
+				Kind:   CompletionKindVariable,
+				Detail: "context entry (" + entry.kind() + ")",
+			})
+		}
+	}
+	for _, function := range s.functions {
+		signature := function
+		signature.Note = ""
+		items = append(items, CompletionItem{
+			Label:         function.Name,
+			Kind:          CompletionKindFunction,
+			Detail:        signature.String(),
+			Documentation: function.Note,
+			InsertText:    function.Name + "(",
+		})
+	}
+	return items
+}
+
+func operatorNames() []string {
+	names := make([]string, 0, len(kyvernov1.ConditionOperators))
+	for name, op := range kyvernov1.ConditionOperators {
+		if !operator.IsOperatorDeprecated(op) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,81 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// conn reads and writes json-rpc messages framed with a Content-Length header
+type conn struct {
+	reader *bufio.Reader
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+func newConn(in io.Reader, out io.Writer) *conn {
+	return &conn{
+		reader: bufio.NewReader(in),
+		writer: out,
+	}
+}
+
+func (c *conn) read() (*message, error) {
+	length := -1
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid content length %q (%w)", value, err)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing content length header")
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, content); err != nil {
+		return nil, err
+	}
+	var msg message
+	if err := json.Unmarshal(content, &msg); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(msg message) error {
+	msg.JSONRPC = jsonrpcVersion
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(content)); err != nil {
+		return err
+	}
+	_, err = c.writer.Write(content)
+	return err
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,106 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/config"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	yaml "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+const diagnosticSource = "kyverno"
+
+var (
+	// fieldPath finds field paths like spec.rules[0].validate in error messages
+	fieldPath = regexp.MustCompile(`(?:spec|metadata)(?:\.[\w-]+|\[\d+\])*`)
+	// rulePointer finds json pointers relative to a rule, like "rule check-registry ... at path /preconditions/all/1/key"
+	rulePointer = regexp.MustCompile(`rule ([\w.-]+)\b.* at path (/\S+)`)
+)
+
+// diagnose returns the diagnostics of the kyverno policies found in the document.
+// Other resources are ignored.
+func diagnose(doc *document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	if doc.parseErr != nil {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    doc.lineRange(doc.parseErrLine),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  doc.parseErr.Error(),
+		})
+	}
+	for _, node := range doc.nodes {
+		if !isPolicy(node) {
+			continue
+		}
+		diagnostics = append(diagnostics, diagnosePolicy(doc, node)...)
+	}
+	return diagnostics
+}
+
+func isPolicy(node *yaml.Node) bool {
+	kind := value(node, "kind")
+	return kind != nil && (kind.Value == "ClusterPolicy" || kind.Value == "Policy")
+}
+
+func diagnosePolicy(doc *document, node *yaml.Node) []Diagnostic {
+	var diagnostics []Diagnostic
+	report := func(severity DiagnosticSeverity, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    doc.nodeRange(locate(node, message)),
+			Severity: severity,
+			Source:   diagnosticSource,
+			Message:  message,
+		})
+	}
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		report(SeverityError, err.Error())
+		return diagnostics
+	}
+	results, err := policy.KubectlValidateLoader(doc.uri, content)
+	if err != nil {
+		report(SeverityError, err.Error())
+		return diagnostics
+	}
+	for _, loaderErr := range results.NonFatalErrors {
+		report(SeverityError, loaderErr.Error.Error())
+	}
+	sa := config.KyvernoUserName(config.KyvernoServiceAccountName())
+	for _, pol := range results.Policies {
+		warnings, err := policyvalidation.Validate(pol, nil, nil, nil, true, sa, sa)
+		if err != nil {
+			report(SeverityError, err.Error())
+		}
+		for _, warning := range warnings {
+			report(SeverityWarning, warning)
+		}
+	}
+	return diagnostics
+}
+
+// locate returns the node an error message refers to, defaulting to the policy kind
+func locate(root *yaml.Node, message string) *yaml.Node {
+	if match := rulePointer.FindStringSubmatch(message); match != nil {
+		if rules := value(value(root, "spec"), "rules"); rules != nil && rules.Kind == yaml.SequenceNode {
+			for i, rule := range rules.Content {
+				if name := value(rule, "name"); name != nil && name.Value == match[1] {
+					path := fmt.Sprintf("spec.rules[%d]", i)
+					for _, token := range strings.Split(strings.Trim(match[2], "/:"), "/") {
+						if _, err := strconv.Atoi(token); err == nil {
+							path += "[" + token + "]"
+						} else {
+							path += "." + token
+						}
+					}
+					return lookup(root, path)
+				}
+			}
+		}
+	}
+	return lookup(root, fieldPath.FindString(message))
+}
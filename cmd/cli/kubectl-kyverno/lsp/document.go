@@ -0,0 +1,249 @@
+package lsp
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	yaml "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// document is a text document opened in the editor, it can contain multiple yaml documents
+type document struct {
+	uri   string
+	text  string
+	lines []string
+	// nodes are the parsed yaml documents
+	nodes []*yaml.Node
+	// parseErr is set when the text is not valid yaml, nodes contains the documents parsed before the error
+	parseErr error
+	// parseErrLine is the zero based line of the parse error, if known
+	parseErrLine int
+}
+
+func newDocument(uri, text string) *document {
+	doc := &document{
+		uri:   uri,
+		text:  text,
+		lines: strings.Split(text, "\n"),
+	}
+	decoder := yaml.NewDecoder(strings.NewReader(text))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if !errors.Is(err, io.EOF) {
+				doc.parseErr = err
+				if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+					line, _ := strconv.Atoi(match[1])
+					doc.parseErrLine = line - 1
+				}
+			}
+			break
+		}
+		if len(node.Content) != 0 {
+			doc.nodes = append(doc.nodes, node.Content[0])
+		}
+	}
+	return doc
+}
+
+// line returns the content of a zero based line, or an empty string if out of range
+func (d *document) line(line int) string {
+	if line < 0 || line >= len(d.lines) {
+		return ""
+	}
+	return strings.TrimRight(d.lines[line], "\r")
+}
+
+// lineRange returns the range covering the non blank content of a line
+func (d *document) lineRange(line int) Range {
+	content := d.line(line)
+	trimmed := strings.TrimLeft(content, " \t")
+	start := len(content) - len(trimmed)
+	return Range{
+		Start: Position{Line: line, Character: utf16Len(content[:start])},
+		End:   Position{Line: line, Character: utf16Len(content)},
+	}
+}
+
+// nodeRange returns the range of a scalar node, multi line scalars and collections only cover their first line
+func (d *document) nodeRange(node *yaml.Node) Range {
+	line := node.Line - 1
+	if node.Kind != yaml.ScalarNode {
+		return d.lineRange(line)
+	}
+	content := d.line(line)
+	start := byteOffset(content, node.Column-1)
+	end := start + len(node.Value)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		end += 2
+	}
+	if end > len(content) || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		end = len(content)
+	}
+	return Range{
+		Start: Position{Line: line, Character: utf16Len(content[:start])},
+		End:   Position{Line: line, Character: utf16Len(content[:end])},
+	}
+}
+
+// documentAt returns the yaml document containing the given zero based line
+func (d *document) documentAt(line int) *yaml.Node {
+	var found *yaml.Node
+	for _, node := range d.nodes {
+		if node.Line-1 > line {
+			break
+		}
+		found = node
+	}
+	return found
+}
+
+// scalarAt returns the scalar node at the given position and tells if it's a mapping key
+func (d *document) scalarAt(pos Position) (*yaml.Node, bool) {
+	root := d.documentAt(pos.Line)
+	if root == nil {
+		return nil, false
+	}
+	var found *yaml.Node
+	var isKey bool
+	var walk func(*yaml.Node, bool)
+	walk = func(node *yaml.Node, key bool) {
+		if found != nil {
+			return
+		}
+		switch node.Kind {
+		case yaml.ScalarNode:
+			if contains(d.nodeRange(node), pos) {
+				found, isKey = node, key
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i], true)
+				walk(node.Content[i+1], false)
+			}
+		default:
+			for _, child := range node.Content {
+				walk(child, false)
+			}
+		}
+	}
+	walk(root, false)
+	return found, isKey
+}
+
+// value returns the value of a key in a mapping node, or nil
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// keyNode returns the key node of a key in a mapping node, or nil
+func keyNode(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+var pathElement = regexp.MustCompile(`([^.\[\]]+)|\[(\d+)\]`)
+
+// lookup resolves a field path like spec.rules[0].name and returns the deepest node found.
+// For mapping entries the key node is returned so that diagnostics point at the field name.
+func lookup(root *yaml.Node, path string) *yaml.Node {
+	current := root
+	found := keyNode(root, "kind")
+	for _, match := range pathElement.FindAllStringSubmatch(path, -1) {
+		if match[1] != "" {
+			key := keyNode(current, match[1])
+			if key == nil {
+				break
+			}
+			found, current = key, value(current, match[1])
+		} else {
+			index, _ := strconv.Atoi(match[2])
+			if current == nil || current.Kind != yaml.SequenceNode || index >= len(current.Content) {
+				break
+			}
+			current = current.Content[index]
+			found = current
+		}
+	}
+	if found == nil {
+		return root
+	}
+	return found
+}
+
+// endLine returns the last zero based line spanned by a node
+func endLine(node *yaml.Node) int {
+	line := node.Line - 1
+	for _, child := range node.Content {
+		if end := endLine(child); end > line {
+			line = end
+		}
+	}
+	return line
+}
+
+func contains(r Range, pos Position) bool {
+	if pos.Line < r.Start.Line || pos.Line > r.End.Line {
+		return false
+	}
+	if pos.Line == r.Start.Line && pos.Character < r.Start.Character {
+		return false
+	}
+	if pos.Line == r.End.Line && pos.Character > r.End.Character {
+		return false
+	}
+	return true
+}
+
+// utf16Len returns the length of a string in UTF-16 code units
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += len(utf16.Encode([]rune{r}))
+	}
+	return n
+}
+
+// byteOffset converts a rune offset (as reported by the yaml parser) to a byte offset
+func byteOffset(s string, runes int) int {
+	offset := 0
+	for i := 0; i < runes && offset < len(s); i++ {
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// utf16ToByte converts an offset in UTF-16 code units to a byte offset
+func utf16ToByte(s string, units int) int {
+	n := 0
+	for i, r := range s {
+		if n >= units {
+			return i
+		}
+		n += len(utf16.Encode([]rune{r}))
+	}
+	return len(s)
+}
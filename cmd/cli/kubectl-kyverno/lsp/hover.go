@@ -0,0 +1,123 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+)
+
+var anchorDocs = map[anchor.AnchorType]string{
+	anchor.Condition:       "**Conditional anchor** `(key)`\n\nThe rest of the pattern is applied only if the tagged field matches.",
+	anchor.Negation:        "**Negation anchor** `X(key)`\n\nThe tagged field must not be present.",
+	anchor.AddIfNotPresent: "**Add if not present anchor** `+(key)`\n\nIn mutate overlays, the field is added only if it's not already present.",
+	anchor.Equality:        "**Equality anchor** `=(key)`\n\nIf the tagged field is present, its value must match the pattern.",
+	anchor.Existence:       "**Existence anchor** `^(key)`\n\nAt least one element of the tagged list must match the pattern.",
+	anchor.Global:          "**Global anchor** `<(key)`\n\nIf the tagged field doesn't match, the whole rule is skipped.",
+}
+
+var operatorDocs = map[string]string{
+	"Equal":                       "Deprecated alias of `Equals`.",
+	"Equals":                      "The key is equal to the value.",
+	"NotEqual":                    "Deprecated alias of `NotEquals`.",
+	"NotEquals":                   "The key is not equal to the value.",
+	"In":                          "Deprecated, use `AllIn` or `AnyIn`.",
+	"AnyIn":                       "At least one element of the key is in the value list.",
+	"AllIn":                       "All elements of the key are in the value list.",
+	"NotIn":                       "Deprecated, use `AllNotIn` or `AnyNotIn`.",
+	"AnyNotIn":                    "At least one element of the key is not in the value list.",
+	"AllNotIn":                    "None of the elements of the key are in the value list.",
+	"GreaterThanOrEquals":         "The key is greater than or equal to the value. Numbers, quantities and semver are supported.",
+	"GreaterThan":                 "The key is greater than the value. Numbers, quantities and semver are supported.",
+	"LessThanOrEquals":            "The key is less than or equal to the value. Numbers, quantities and semver are supported.",
+	"LessThan":                    "The key is less than the value. Numbers, quantities and semver are supported.",
+	"DurationGreaterThanOrEquals": "The key duration is greater than or equal to the value duration.",
+	"DurationGreaterThan":         "The key duration is greater than the value duration.",
+	"DurationLessThanOrEquals":    "The key duration is less than or equal to the value duration.",
+	"DurationLessThan":            "The key duration is less than the value duration.",
+}
+
+// hover returns the hover documentation at the given position, or nil
+func (s *Server) hover(doc *document, pos Position) *Hover {
+	line := doc.line(pos.Line)
+	if _, ok := expressionAt(line, pos.Character); ok {
+		return s.hoverExpression(doc, pos)
+	}
+	node, isKey := doc.scalarAt(pos)
+	if node == nil {
+		return nil
+	}
+	r := doc.nodeRange(node)
+	if isKey {
+		if a := anchor.Parse(node.Value); a != nil {
+			return markdown(anchorDocs[a.Type()], r)
+		}
+		return nil
+	}
+	if docs, ok := operatorDocs[node.Value]; ok && strings.HasPrefix(strings.TrimLeft(line, " \t-"), "operator:") {
+		return markdown(fmt.Sprintf("**%s** operator\n\n%s", node.Value, docs), r)
+	}
+	return nil
+}
+
+func (s *Server) hoverExpression(doc *document, pos Position) *Hover {
+	line := doc.line(pos.Line)
+	identifier, start, end := identifierAt(line, utf16ToByte(line, pos.Character))
+	if identifier == "" {
+		return nil
+	}
+	r := Range{
+		Start: Position{Line: pos.Line, Character: utf16Len(line[:start])},
+		End:   Position{Line: pos.Line, Character: utf16Len(line[:end])},
+	}
+	// functions are followed by a parenthesis
+	if end < len(line) && line[end] == '(' {
+		for _, function := range s.functions {
+			if function.Name == identifier {
+				signature := function
+				signature.Note = ""
+				return markdown(fmt.Sprintf("```\n%s\n```\n\n%s", signature.String(), function.Note), r)
+			}
+		}
+		return nil
+	}
+	path := strings.Split(identifier, ".")
+	if root := doc.documentAt(pos.Line); root != nil {
+		for _, entry := range contextEntries(root, pos.Line) {
+			if entry.name == path[0] {
+				return markdown(fmt.Sprintf("**%s** context entry (%s), declared at line %d", entry.name, entry.kind(), entry.node.Line), r)
+			}
+		}
+	}
+	if v := lookupVariable(builtinVariables, path); v != nil {
+		return markdown(fmt.Sprintf("**%s**\n\n%s", identifier, v.doc), r)
+	}
+	return nil
+}
+
+// definition returns the location of the context entry referenced at the given position, or nil
+func (s *Server) definition(doc *document, pos Position) *Location {
+	line := doc.line(pos.Line)
+	if _, ok := expressionAt(line, pos.Character); !ok {
+		return nil
+	}
+	identifier, _, _ := identifierAt(line, utf16ToByte(line, pos.Character))
+	name, _, _ := strings.Cut(identifier, ".")
+	root := doc.documentAt(pos.Line)
+	if name == "" || root == nil {
+		return nil
+	}
+	for _, entry := range contextEntries(root, pos.Line) {
+		if entry.name == name {
+			return &Location{URI: doc.uri, Range: doc.nodeRange(entry.node)}
+		}
+	}
+	return nil
+}
+
+func markdown(value string, r Range) *Hover {
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: value},
+		Range:    &r,
+	}
+}
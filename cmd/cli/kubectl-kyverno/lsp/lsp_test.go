@@ -0,0 +1,258 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-images
+spec:
+  background: false
+  rules:
+  - name: check-registry
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: allowed
+      configMap:
+        name: registries
+        namespace: kyverno
+    preconditions:
+      all:
+      - key: "{{ request.operation }}"
+        operator: AnyIn
+        value: ["CREATE", "UPDATE"]
+    validate:
+      message: "{{ allowed.data.registries }} {{ to_upper(request.object.metadata.name) }}"
+      pattern:
+        spec:
+          =(securityContext):
+            X(hostPID): "true"
+`
+
+// positionOf returns the position of the first occurrence of marker in the text, plus offset
+func positionOf(t *testing.T, text, marker string, offset int) Position {
+	for i, line := range strings.Split(text, "\n") {
+		if index := strings.Index(line, marker); index >= 0 {
+			return Position{Line: i, Character: index + offset}
+		}
+	}
+	t.Fatalf("marker %q not found", marker)
+	return Position{}
+}
+
+func labels(items []CompletionItem) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Label)
+	}
+	return out
+}
+
+func TestComplete(t *testing.T) {
+	s := NewServer(nil, nil)
+	tests := []struct {
+		name     string
+		text     string
+		marker   string
+		offset   int
+		contains []string
+		excludes []string
+	}{{
+		name:     "top level",
+		text:     testPolicy,
+		marker:   "{{ request.operation",
+		offset:   3,
+		contains: []string{"request", "allowed", "to_upper", "time_since", "images", "element"},
+	}, {
+		name:     "request fields",
+		text:     testPolicy,
+		marker:   "{{ request.operation",
+		offset:   11,
+		contains: []string{"object", "oldObject", "operation", "userInfo"},
+		excludes: []string{"request", "to_upper"},
+	}, {
+		name:     "object metadata fields",
+		text:     testPolicy,
+		marker:   "request.object.metadata.name",
+		offset:   24,
+		contains: []string{"name", "namespace", "labels"},
+	}, {
+		name:     "operators",
+		text:     testPolicy,
+		marker:   "operator: AnyIn",
+		offset:   10,
+		contains: []string{"AnyIn", "AllNotIn", "Equals", "DurationLessThan"},
+		excludes: []string{"In", "NotIn"},
+	}, {
+		name:   "outside expressions",
+		text:   testPolicy,
+		marker: "message:",
+		offset: 2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newDocument("file:///policy.yaml", tt.text)
+			items := labels(s.complete(doc, positionOf(t, tt.text, tt.marker, tt.offset)))
+			for _, label := range tt.contains {
+				assert.Contains(t, items, label)
+			}
+			for _, label := range tt.excludes {
+				assert.NotContains(t, items, label)
+			}
+			if len(tt.contains) == 0 {
+				assert.Empty(t, items)
+			}
+		})
+	}
+}
+
+func TestCompleteContextEntriesScope(t *testing.T) {
+	text := `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: scopes
+spec:
+  rules:
+  - name: first
+    context:
+    - name: one
+      variable:
+        value: 1
+    validate:
+      message: "{{ }}"
+  - name: second
+    context:
+    - name: two
+      variable:
+        value: 2
+`
+	s := NewServer(nil, nil)
+	doc := newDocument("file:///policy.yaml", text)
+	items := labels(s.complete(doc, positionOf(t, text, "{{ }}", 3)))
+	assert.Contains(t, items, "one")
+	assert.NotContains(t, items, "two")
+}
+
+func TestHover(t *testing.T) {
+	s := NewServer(nil, nil)
+	doc := newDocument("file:///policy.yaml", testPolicy)
+	tests := []struct {
+		name   string
+		marker string
+		offset int
+		want   string
+	}{{
+		name:   "equality anchor",
+		marker: "=(securityContext)",
+		offset: 2,
+		want:   "Equality anchor",
+	}, {
+		name:   "negation anchor",
+		marker: "X(hostPID)",
+		offset: 1,
+		want:   "Negation anchor",
+	}, {
+		name:   "operator",
+		marker: "AnyIn",
+		offset: 1,
+		want:   "At least one element of the key is in the value list.",
+	}, {
+		name:   "function",
+		marker: "to_upper(",
+		offset: 2,
+		want:   "to_upper(string) string",
+	}, {
+		name:   "context entry",
+		marker: "allowed.data",
+		offset: 1,
+		want:   "**allowed** context entry (configMap), declared at line 15",
+	}, {
+		name:   "builtin variable",
+		marker: "request.operation",
+		offset: 10,
+		want:   "The admission operation",
+	}, {
+		name:   "plain key",
+		marker: "background",
+		offset: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hover := s.hover(doc, positionOf(t, testPolicy, tt.marker, tt.offset))
+			if tt.want == "" {
+				assert.Nil(t, hover)
+			} else if assert.NotNil(t, hover) {
+				assert.Contains(t, hover.Contents.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	s := NewServer(nil, nil)
+	doc := newDocument("file:///policy.yaml", testPolicy)
+	location := s.definition(doc, positionOf(t, testPolicy, "allowed.data", 3))
+	if assert.NotNil(t, location) {
+		assert.Equal(t, "file:///policy.yaml", location.URI)
+		assert.Equal(t, Range{Start: Position{Line: 14, Character: 12}, End: Position{Line: 14, Character: 19}}, location.Range)
+	}
+	assert.Nil(t, s.definition(doc, positionOf(t, testPolicy, "request.operation", 1)))
+	assert.Nil(t, s.definition(doc, positionOf(t, testPolicy, "background", 1)))
+}
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     []string
+		wantLine []int
+	}{{
+		name: "valid policy",
+		text: testPolicy,
+	}, {
+		name: "not a policy",
+		text: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n",
+	}, {
+		name:     "invalid yaml",
+		text:     "kind: ClusterPolicy\nspec:\n  rules: [\n",
+		want:     []string{"yaml:"},
+		wantLine: []int{2},
+	}, {
+		name: "invalid policy",
+		text: strings.Replace(testPolicy, "name: check-registry", "name: check-registry\n    mutate:\n      patchStrategicMerge:\n        foo: bar", 1),
+		want: []string{"Multiple operations defined in the rule"},
+		// the error points at the rule
+		wantLine: []int{7},
+	}, {
+		name:     "invalid variable",
+		text:     strings.Replace(testPolicy, `value: ["CREATE", "UPDATE"]`, "value: [\"CREATE\", \"UPDATE\"]\n      - key: \"{{ foo( }}\"\n        operator: Equals\n        value: bar", 1),
+		want:     []string{"invalid JMESPath query foo("},
+		wantLine: []int{23},
+	}, {
+		name:     "unknown field",
+		text:     strings.Replace(testPolicy, "background: false", "background: false\n  foo: bar", 1),
+		want:     []string{"spec.foo: Invalid value: value provided for unknown field"},
+		wantLine: []int{6},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := diagnose(newDocument("file:///policy.yaml", tt.text))
+			assert.Len(t, diagnostics, len(tt.want))
+			for i := range diagnostics {
+				if i < len(tt.want) {
+					assert.Contains(t, diagnostics[i].Message, tt.want[i])
+					assert.Equal(t, tt.wantLine[i], diagnostics[i].Range.Start.Line, diagnostics[i].Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+// Server is a language server for kyverno policies.
+// It speaks the language server protocol over a reader/writer pair, usually stdin and stdout.
+type Server struct {
+	conn      *conn
+	functions []jmespath.FunctionEntry
+	lock      sync.Mutex
+	documents map[string]*document
+	shutdown  bool
+}
+
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		conn:      newConn(in, out),
+		functions: jmespath.GetFunctions(config.NewDefaultConfiguration(false)),
+		documents: map[string]*document{},
+	}
+}
+
+// Serve processes messages until the client sends the exit notification, the input is closed or the context is cancelled
+func (s *Server) Serve(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		msg, err := s.conn.read()
+		if err != nil {
+			var rpcErr *responseError
+			if errors.As(err, &rpcErr) {
+				if err := s.conn.write(message{ID: nullID(), Error: rpcErr}); err != nil {
+					return err
+				}
+				continue
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit received before shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	result, rpcErr := s.dispatch(msg)
+	// notifications don't have an id and don't expect a response
+	if msg.ID == nil {
+		return nil
+	}
+	response := message{ID: msg.ID, Error: rpcErr}
+	if rpcErr == nil {
+		content, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		response.Result = content
+	}
+	return s.conn.write(response)
+}
+
+func (s *Server) dispatch(msg *message) (any, *responseError) {
+	switch msg.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: 1,
+				CompletionProvider: completionOptions{
+					TriggerCharacters: []string{".", "{", "("},
+				},
+				HoverProvider:      true,
+				DefinitionProvider: true,
+			},
+			ServerInfo: serverInfo{
+				Name:    "kyverno",
+				Version: version.Version(),
+			},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return nil, s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// full synchronization, the last change contains the whole document
+		return nil, s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.lock.Lock()
+		delete(s.documents, params.TextDocument.URI)
+		s.lock.Unlock()
+		return nil, s.publish(params.TextDocument.URI, []Diagnostic{})
+	case "textDocument/completion":
+		doc, pos, err := s.position(msg)
+		if err != nil {
+			return nil, err
+		}
+		return s.complete(doc, pos), nil
+	case "textDocument/hover":
+		doc, pos, err := s.position(msg)
+		if err != nil {
+			return nil, err
+		}
+		if hover := s.hover(doc, pos); hover != nil {
+			return hover, nil
+		}
+		return nil, nil
+	case "textDocument/definition":
+		doc, pos, err := s.position(msg)
+		if err != nil {
+			return nil, err
+		}
+		if location := s.definition(doc, pos); location != nil {
+			return location, nil
+		}
+		return nil, nil
+	default:
+		if msg.ID == nil {
+			// unknown notifications are ignored
+			return nil, nil
+		}
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+	}
+}
+
+func (s *Server) update(uri, text string) *responseError {
+	doc := newDocument(uri, text)
+	s.lock.Lock()
+	s.documents[uri] = doc
+	s.lock.Unlock()
+	return s.publish(uri, diagnose(doc))
+}
+
+func (s *Server) publish(uri string, diagnostics []Diagnostic) *responseError {
+	params, err := json.Marshal(publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+	if err != nil {
+		return &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	if err := s.conn.write(message{Method: "textDocument/publishDiagnostics", Params: params}); err != nil {
+		return &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) position(msg *message) (*document, Position, *responseError) {
+	var params textDocumentPositionParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return nil, Position{}, invalidParams(err)
+	}
+	s.lock.Lock()
+	doc, ok := s.documents[params.TextDocument.URI]
+	s.lock.Unlock()
+	if !ok {
+		return nil, Position{}, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("document not opened: %s", params.TextDocument.URI)}
+	}
+	return doc, params.Position, nil
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+func nullID() *json.RawMessage {
+	id := json.RawMessage("null")
+	return &id
+}
@@ -0,0 +1,106 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func frame(t *testing.T, messages ...map[string]any) *bytes.Buffer {
+	var in bytes.Buffer
+	for _, msg := range messages {
+		msg["jsonrpc"] = "2.0"
+		content, err := json.Marshal(msg)
+		require.NoError(t, err)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+	return &in
+}
+
+func unframe(t *testing.T, out *bytes.Buffer) []message {
+	c := newConn(bufio.NewReader(out), nil)
+	var messages []message
+	for out.Len() != 0 || c.reader.Buffered() != 0 {
+		msg, err := c.read()
+		require.NoError(t, err)
+		messages = append(messages, *msg)
+	}
+	return messages
+}
+
+func TestServe(t *testing.T) {
+	uri := "file:///policy.yaml"
+	invalid := strings.Replace(testPolicy, "background: false", "background: false\n  foo: bar", 1)
+	in := frame(t,
+		map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}},
+		map[string]any{"method": "initialized", "params": map[string]any{}},
+		map[string]any{"method": "textDocument/didOpen", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri, "languageId": "yaml", "version": 1, "text": invalid},
+		}},
+		map[string]any{"method": "textDocument/didChange", "params": map[string]any{
+			"textDocument":   map[string]any{"uri": uri, "version": 2},
+			"contentChanges": []map[string]any{{"text": testPolicy}},
+		}},
+		map[string]any{"id": 2, "method": "textDocument/hover", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     positionOf(t, testPolicy, "X(hostPID)", 1),
+		}},
+		map[string]any{"id": "three", "method": "textDocument/definition", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     positionOf(t, testPolicy, "allowed.data", 1),
+		}},
+		map[string]any{"id": 4, "method": "textDocument/completion", "params": map[string]any{
+			"textDocument": map[string]any{"uri": "file:///unknown.yaml"},
+			"position":     Position{},
+		}},
+		map[string]any{"id": 5, "method": "workspace/symbol", "params": map[string]any{}},
+		map[string]any{"method": "$/cancelRequest", "params": map[string]any{"id": 1}},
+		map[string]any{"id": 6, "method": "shutdown"},
+		map[string]any{"method": "exit"},
+	)
+	var out bytes.Buffer
+	require.NoError(t, NewServer(in, &out).Serve(context.Background()))
+	messages := unframe(t, &out)
+	require.Len(t, messages, 8)
+	// initialize
+	assert.Equal(t, "1", string(*messages[0].ID))
+	assert.Contains(t, string(messages[0].Result), `"hoverProvider":true`)
+	// diagnostics after open and change
+	assert.Equal(t, "textDocument/publishDiagnostics", messages[1].Method)
+	assert.Contains(t, string(messages[1].Params), "value provided for unknown field")
+	assert.Equal(t, "textDocument/publishDiagnostics", messages[2].Method)
+	assert.JSONEq(t, `{"uri":"file:///policy.yaml","diagnostics":[]}`, string(messages[2].Params))
+	// hover
+	assert.Equal(t, "2", string(*messages[3].ID))
+	assert.Contains(t, string(messages[3].Result), "Negation anchor")
+	// definition
+	assert.Equal(t, `"three"`, string(*messages[4].ID))
+	assert.JSONEq(t, `{"uri":"file:///policy.yaml","range":{"start":{"line":14,"character":12},"end":{"line":14,"character":19}}}`, string(messages[4].Result))
+	// errors
+	assert.Equal(t, codeInvalidParams, messages[5].Error.Code)
+	assert.Equal(t, codeMethodNotFound, messages[6].Error.Code)
+	// shutdown
+	assert.Equal(t, "6", string(*messages[7].ID))
+	assert.Equal(t, "null", string(messages[7].Result))
+}
+
+func TestServeExitWithoutShutdown(t *testing.T) {
+	in := frame(t, map[string]any{"method": "exit"})
+	assert.Error(t, NewServer(in, &bytes.Buffer{}).Serve(context.Background()))
+}
+
+func TestServeInvalidMessage(t *testing.T) {
+	in := bytes.NewBufferString("Content-Length: 3\r\n\r\n{{{")
+	var out bytes.Buffer
+	require.NoError(t, NewServer(in, &out).Serve(context.Background()))
+	messages := unframe(t, &out)
+	require.Len(t, messages, 1)
+	assert.Equal(t, codeParseError, messages[0].Error.Code)
+}
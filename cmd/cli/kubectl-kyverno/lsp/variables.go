@@ -0,0 +1,188 @@
+package lsp
+
+import (
+	"strings"
+
+	yaml "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+// variable is a well known variable available in policy expressions
+type variable struct {
+	name   string
+	doc    string
+	fields []variable
+}
+
+var objectFields = []variable{
+	{name: "apiVersion", doc: "API version of the resource"},
+	{name: "kind", doc: "Kind of the resource"},
+	{name: "metadata", doc: "Metadata of the resource", fields: []variable{
+		{name: "name", doc: "Name of the resource"},
+		{name: "namespace", doc: "Namespace of the resource"},
+		{name: "labels", doc: "Labels of the resource"},
+		{name: "annotations", doc: "Annotations of the resource"},
+		{name: "uid", doc: "UID of the resource"},
+		{name: "creationTimestamp", doc: "Creation timestamp of the resource"},
+		{name: "deletionTimestamp", doc: "Deletion timestamp of the resource"},
+		{name: "ownerReferences", doc: "Owner references of the resource"},
+		{name: "generateName", doc: "Generate name prefix of the resource"},
+	}},
+	{name: "spec", doc: "Spec of the resource"},
+	{name: "status", doc: "Status of the resource"},
+	{name: "data", doc: "Data of the resource (ConfigMap, Secret)"},
+}
+
+var groupVersionKindFields = []variable{
+	{name: "group", doc: "API group"},
+	{name: "version", doc: "API version"},
+	{name: "kind", doc: "Kind"},
+}
+
+var groupVersionResourceFields = []variable{
+	{name: "group", doc: "API group"},
+	{name: "version", doc: "API version"},
+	{name: "resource", doc: "Resource"},
+}
+
+// builtinVariables are the variables added to the context by the engine
+var builtinVariables = []variable{
+	{name: "request", doc: "The admission request", fields: []variable{
+		{name: "object", doc: "The resource being admitted", fields: objectFields},
+		{name: "oldObject", doc: "The existing resource for UPDATE and DELETE operations", fields: objectFields},
+		{name: "operation", doc: "The admission operation: CREATE, UPDATE, DELETE or CONNECT"},
+		{name: "userInfo", doc: "The user performing the request", fields: []variable{
+			{name: "username", doc: "Name of the user"},
+			{name: "uid", doc: "UID of the user"},
+			{name: "groups", doc: "Groups of the user"},
+			{name: "extra", doc: "Extra information about the user"},
+		}},
+		{name: "roles", doc: "Roles bound to the user in the request namespace"},
+		{name: "clusterRoles", doc: "Cluster roles bound to the user"},
+		{name: "namespace", doc: "Namespace of the resource"},
+		{name: "name", doc: "Name of the resource"},
+		{name: "uid", doc: "UID of the admission request"},
+		{name: "kind", doc: "Kind of the resource", fields: groupVersionKindFields},
+		{name: "resource", doc: "Resource being requested", fields: groupVersionResourceFields},
+		{name: "subResource", doc: "Subresource being requested"},
+		{name: "requestKind", doc: "Kind of the original request", fields: groupVersionKindFields},
+		{name: "requestResource", doc: "Resource of the original request", fields: groupVersionResourceFields},
+		{name: "requestSubResource", doc: "Subresource of the original request"},
+		{name: "dryRun", doc: "Indicates the request is a dry run"},
+		{name: "options", doc: "Operation options"},
+	}},
+	{name: "serviceAccountName", doc: "Name of the service account performing the request"},
+	{name: "serviceAccountNamespace", doc: "Namespace of the service account performing the request"},
+	{name: "images", doc: "Images of the resource containers, keyed by container type and name", fields: []variable{
+		{name: "containers", doc: "Images of the containers"},
+		{name: "initContainers", doc: "Images of the init containers"},
+		{name: "ephemeralContainers", doc: "Images of the ephemeral containers"},
+	}},
+	{name: "element", doc: "The current element in a foreach declaration"},
+	{name: "elementIndex", doc: "The index of the current element in a foreach declaration"},
+	{name: "target", doc: "The target resource of mutate existing rules and cleanup policies", fields: objectFields},
+}
+
+// lookupVariable returns the variable for a dotted path, or nil
+func lookupVariable(variables []variable, path []string) *variable {
+	for i := range variables {
+		if variables[i].name == path[0] {
+			if len(path) == 1 {
+				return &variables[i]
+			}
+			return lookupVariable(variables[i].fields, path[1:])
+		}
+	}
+	return nil
+}
+
+// contextEntry is a context entry declared in a policy
+type contextEntry struct {
+	name string
+	// node is the node holding the entry name
+	node *yaml.Node
+	// entry is the context entry mapping
+	entry *yaml.Node
+}
+
+// kind returns the type of the context entry (apiCall, configMap, variable, ...)
+func (e contextEntry) kind() string {
+	for i := 0; i+1 < len(e.entry.Content); i += 2 {
+		if key := e.entry.Content[i].Value; key != "name" {
+			return key
+		}
+	}
+	return ""
+}
+
+// contextEntries returns the context entries visible at the given line.
+// Entries of the rule containing the line are returned, or all entries if the line is not in a rule.
+func contextEntries(root *yaml.Node, line int) []contextEntry {
+	scope := root
+	if rules := value(value(root, "spec"), "rules"); rules != nil && rules.Kind == yaml.SequenceNode {
+		for _, rule := range rules.Content {
+			if rule.Line-1 <= line && line <= endLine(rule) {
+				scope = rule
+				break
+			}
+		}
+	}
+	var entries []contextEntry
+	var walk func(*yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == "context" && node.Content[i+1].Kind == yaml.SequenceNode {
+					for _, entry := range node.Content[i+1].Content {
+						if name := value(entry, "name"); name != nil && name.Kind == yaml.ScalarNode && name.Value != "" {
+							entries = append(entries, contextEntry{name: name.Value, node: name, entry: entry})
+						}
+					}
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(scope)
+	return entries
+}
+
+// expressionAt returns the JMESPath expression text before the cursor if the cursor is in an expression.
+// Expressions are enclosed in {{ }} or are the value of a jmesPath field.
+func expressionAt(line string, character int) (string, bool) {
+	prefix := line[:utf16ToByte(line, character)]
+	if open := strings.LastIndex(prefix, "{{"); open >= 0 && strings.LastIndex(prefix, "}}") < open {
+		return prefix[open+2:], true
+	}
+	trimmed := strings.TrimLeft(prefix, " \t-")
+	if strings.HasPrefix(trimmed, "jmesPath:") {
+		return strings.TrimPrefix(trimmed, "jmesPath:"), true
+	}
+	return "", false
+}
+
+// identifierBefore returns the dotted identifier ending the expression, like request.object.meta
+func identifierBefore(expression string) string {
+	i := len(expression)
+	for i > 0 && isIdentifierChar(expression[i-1]) {
+		i--
+	}
+	return expression[i:]
+}
+
+// identifierAt returns the dotted identifier around a byte offset of a line and its byte bounds
+func identifierAt(line string, offset int) (string, int, int) {
+	start, end := offset, offset
+	for start > 0 && isIdentifierChar(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isIdentifierChar(line[end]) {
+		end++
+	}
+	return line[start:end], start, end
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
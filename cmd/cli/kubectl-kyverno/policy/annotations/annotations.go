@@ -1,6 +1,8 @@
 package annotations
 
 import (
+	"strings"
+
 	"github.com/kyverno/kyverno/api/kyverno"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
@@ -20,3 +22,27 @@ func Severity(annotations map[string]string) policyreportv1alpha2.PolicySeverity
 func Category(annotations map[string]string) string {
 	return annotations[kyverno.AnnotationPolicyCategory]
 }
+
+func Title(annotations map[string]string) string {
+	return annotations[kyverno.AnnotationPolicyTitle]
+}
+
+func Subject(annotations map[string]string) string {
+	return annotations[kyverno.AnnotationPolicySubject]
+}
+
+func Description(annotations map[string]string) string {
+	return strings.TrimSpace(annotations[kyverno.AnnotationPolicyDescription])
+}
+
+func MinVersion(annotations map[string]string) string {
+	return annotations[kyverno.AnnotationPolicyMinVersion]
+}
+
+func KyvernoVersion(annotations map[string]string) string {
+	return annotations[kyverno.AnnotationKyvernoVersion]
+}
+
+func KubernetesVersion(annotations map[string]string) string {
+	return annotations[kyverno.AnnotationKubernetesVersion]
+}
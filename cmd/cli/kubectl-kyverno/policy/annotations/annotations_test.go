@@ -160,3 +160,53 @@ func TestCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentation(t *testing.T) {
+	annotations := map[string]string{
+		kyverno.AnnotationPolicyTitle:       "Disallow Latest Tag",
+		kyverno.AnnotationPolicySubject:     "Pod",
+		kyverno.AnnotationPolicyDescription: "  The ':latest' tag is mutable.\n",
+		kyverno.AnnotationPolicyMinVersion:  "1.6.0",
+		kyverno.AnnotationKyvernoVersion:    "1.12.0",
+		kyverno.AnnotationKubernetesVersion: "1.28",
+	}
+	tests := []struct {
+		name string
+		get  func(map[string]string) string
+		want string
+	}{{
+		name: "title",
+		get:  Title,
+		want: "Disallow Latest Tag",
+	}, {
+		name: "subject",
+		get:  Subject,
+		want: "Pod",
+	}, {
+		name: "description",
+		get:  Description,
+		want: "The ':latest' tag is mutable.",
+	}, {
+		name: "min version",
+		get:  MinVersion,
+		want: "1.6.0",
+	}, {
+		name: "kyverno version",
+		get:  KyvernoVersion,
+		want: "1.12.0",
+	}, {
+		name: "kubernetes version",
+		get:  KubernetesVersion,
+		want: "1.28",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.get(annotations); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+			if got := tt.get(nil); got != "" {
+				t.Errorf("%s(nil) = %v, want empty", tt.name, got)
+			}
+		})
+	}
+}
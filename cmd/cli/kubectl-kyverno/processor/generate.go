@@ -16,6 +16,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -113,14 +116,18 @@ func initializeMockController(out io.Writer, s *store.Store, gvrToListKind map[s
 	}
 	client.SetDiscovery(dclient.NewFakeDiscoveryClient(gvrs.UnsortedList()))
 	cfg := config.NewDefaultConfiguration(false)
+	paramsStore := loaders.NewClientParamsStore(adapters.Client(client))
 	c := generate.NewGenerateControllerWithOnlyClient(client, engine.NewEngine(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jmespath.New(cfg),
+		enginecel.NewCache(),
+		nil,
 		adapters.Client(client),
 		nil,
+		nil,
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(s, nil),
+		store.ContextLoaderFactory(s, nil, factories.WithParamsStore(paramsStore)),
 		nil,
 		nil,
 	))
@@ -8,7 +8,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	json_patch "github.com/evanphx/json-patch/v5"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
@@ -21,15 +20,15 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
-	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/exceptions"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
-	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
-	"gomodules.xyz/jsonpatch/v2"
 	yamlv2 "gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -55,19 +54,40 @@ type PolicyProcessor struct {
 	AuditWarn                 bool
 	Subresources              []v1alpha1.Subresource
 	Out                       io.Writer
+	Explain                   bool
+	// Configuration overrides the default configuration (optional)
+	Configuration config.Configuration
+	// JMESPath overrides the default JMESPath implementation (optional)
+	JMESPath jmespath.Interface
+	// ContextLoaderFactory overrides the default context loader factory (optional)
+	ContextLoaderFactory engineapi.ContextLoaderFactory
+	// Pipeline applies mutation, image verification and validation policies in the same order as the admission webhooks
+	Pipeline bool
 }
 
 func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse, error) {
-	cfg := config.NewDefaultConfiguration(false)
-	jp := jmespath.New(cfg)
-	resource := p.Resource
-	namespaceLabels := p.NamespaceSelectorMap[p.Resource.GetNamespace()]
-	policyExceptionLister := &policyExceptionLister{
-		exceptions: p.PolicyExceptions,
+	cfg := p.Configuration
+	if cfg == nil {
+		cfg = config.NewDefaultConfiguration(false)
+	}
+	jp := p.JMESPath
+	if jp == nil {
+		jp = jmespath.New(cfg)
 	}
 	var client engineapi.Client
+	var contextLoaderOptions []factories.ContextLoaderFactoryOptions
 	if p.Client != nil {
 		client = adapters.Client(p.Client)
+		contextLoaderOptions = append(contextLoaderOptions, factories.WithParamsStore(loaders.NewClientParamsStore(client)))
+	}
+	contextLoaderFactory := p.ContextLoaderFactory
+	if contextLoaderFactory == nil {
+		contextLoaderFactory = store.ContextLoaderFactory(p.Store, nil, contextLoaderOptions...)
+	}
+	resource := p.Resource
+	namespaceLabels := p.NamespaceSelectorMap[p.Resource.GetNamespace()]
+	policyExceptionLister := &policyExceptionLister{
+		exceptions: p.PolicyExceptions,
 	}
 	rclient := p.Store.GetRegistryClient()
 	if rclient == nil {
@@ -77,11 +97,14 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	eng := engine.NewEngine(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
-		jmespath.New(cfg),
+		jp,
+		enginecel.NewCache(),
+		nil,
 		client,
+		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(p.Store, nil),
+		contextLoaderFactory,
 		exceptions.New(policyExceptionLister),
 		&isCluster,
 	)
@@ -117,80 +140,33 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			namespaceLabels = ns.GetLabels()
 		}
 	}
+	ctx := context.TODO()
+	if p.Explain {
+		ctx = explain.Enable(ctx)
+	}
 	resPath := fmt.Sprintf("%s/%s/%s", resourceNamespace, resourceKind, resourceName)
 	responses := make([]engineapi.EngineResponse, 0, len(p.Policies))
-	// mutate
-	for _, policy := range p.Policies {
-		if !policy.GetSpec().HasMutate() {
-			continue
-		}
-		policyContext, err := p.makePolicyContext(jp, cfg, resource, policy, namespaceLabels, gvk, subresource)
-		if err != nil {
-			return responses, err
-		}
-		mutateResponse := eng.Mutate(context.Background(), policyContext)
-		err = p.processMutateEngineResponse(mutateResponse, resPath)
-		if err != nil {
-			return responses, fmt.Errorf("failed to print mutated result (%w)", err)
-		}
-		responses = append(responses, mutateResponse)
-		resource = mutateResponse.PatchedResource
+	// the pipeline mode only selects the policies applied at admission, mutate existing rules are applied otherwise
+	var pipelineOptions []engine.PipelineOption
+	if !p.Pipeline {
+		pipelineOptions = append(pipelineOptions, engine.WithMutateExisting())
 	}
-	// verify images
-	for _, policy := range p.Policies {
-		if !policy.GetSpec().HasVerifyImages() {
-			continue
-		}
-		policyContext, err := p.makePolicyContext(jp, cfg, resource, policy, namespaceLabels, gvk, subresource)
-		if err != nil {
-			return responses, err
-		}
-		verifyImageResponse, verifiedImageData := eng.VerifyAndPatchImages(context.TODO(), policyContext)
-		// update annotation to reflect verified images
-		var patches []jsonpatch.JsonPatchOperation
-		if !verifiedImageData.IsEmpty() {
-			annotationPatches, err := verifiedImageData.Patches(len(verifyImageResponse.PatchedResource.GetAnnotations()) != 0, log.Log)
-			if err != nil {
-				return responses, err
-			}
-			// add annotation patches first
-			patches = append(annotationPatches, patches...)
-		}
-		if len(patches) != 0 {
-			patch := jsonutils.JoinPatches(patch.ConvertPatches(patches...)...)
-			decoded, err := json_patch.DecodePatch(patch)
-			if err != nil {
-				return responses, err
-			}
-			options := &json_patch.ApplyOptions{SupportNegativeIndices: true, AllowMissingPathOnRemove: true, EnsurePathExistsOnAdd: true}
-			resourceBytes, err := verifyImageResponse.PatchedResource.MarshalJSON()
-			if err != nil {
-				return responses, err
-			}
-			patchedResourceBytes, err := decoded.ApplyWithOptions(resourceBytes, options)
-			if err != nil {
-				return responses, err
-			}
-			if err := verifyImageResponse.PatchedResource.UnmarshalJSON(patchedResourceBytes); err != nil {
-				return responses, err
-			}
-		}
-		responses = append(responses, verifyImageResponse)
-		resource = verifyImageResponse.PatchedResource
+	pipelineResponse, err := engine.RunPipeline(ctx, eng, resource, p.Policies, func(policy kyvernov1.PolicyInterface, resource unstructured.Unstructured) (engineapi.PolicyContext, error) {
+		return p.makePolicyContext(jp, cfg, resource, policy, namespaceLabels, gvk, subresource)
+	}, pipelineOptions...)
+	if err != nil {
+		return responses, err
 	}
-	// validate
-	for _, policy := range p.Policies {
-		if !policyHasValidateOrVerifyImageChecks(policy) {
-			continue
-		}
-		policyContext, err := p.makePolicyContext(jp, cfg, resource, policy, namespaceLabels, gvk, subresource)
-		if err != nil {
-			return responses, err
+	for _, mutateResponse := range pipelineResponse.Mutation {
+		if err := p.processMutateEngineResponse(mutateResponse, resPath); err != nil {
+			return responses, fmt.Errorf("failed to print mutated result (%w)", err)
 		}
-		validateResponse := eng.Validate(context.TODO(), policyContext)
-		responses = append(responses, validateResponse)
-		resource = validateResponse.PatchedResource
 	}
+	if p.Pipeline {
+		p.printPipelineResponse(ctx, pipelineResponse, resPath)
+	}
+	responses = append(responses, pipelineResponse.Responses()...)
+	resource = pipelineResponse.PatchedResource
 	// generate
 	for _, policy := range p.Policies {
 		if policy.GetSpec().HasGenerate() {
@@ -198,7 +174,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			if err != nil {
 				return responses, err
 			}
-			generateResponse := eng.ApplyBackgroundChecks(context.TODO(), policyContext)
+			generateResponse := eng.ApplyBackgroundChecks(ctx, policyContext)
 			if !generateResponse.IsEmpty() {
 				newRuleResponse, err := handleGeneratePolicy(p.Out, p.Store, &generateResponse, *policyContext, p.RuleToCloneSourceResource)
 				if err != nil {
@@ -364,6 +340,31 @@ func (p *PolicyProcessor) processGenerateResponse(response engineapi.EngineRespo
 	return nil
 }
 
+func (p *PolicyProcessor) printPipelineResponse(ctx context.Context, response engineapi.PipelineResponse, resourcePath string) {
+	if p.Stdin {
+		return
+	}
+	fmt.Fprintf(p.Out, "\n\nPipeline:\n")
+	stages := []struct {
+		name      string
+		responses []engineapi.EngineResponse
+	}{
+		{"mutate", response.Mutation},
+		{"verifyImages", response.ImageVerification},
+		{"validate", response.Validation},
+	}
+	for _, stage := range stages {
+		for _, r := range stage.responses {
+			fmt.Fprintf(p.Out, "%s %s: %s\n", stage.name, r.Policy().GetName(), pipelineStatus(r))
+		}
+	}
+	if response.IsBlocked(ctx) {
+		fmt.Fprintf(p.Out, "resource %s would be denied at admission\n", resourcePath)
+	} else {
+		fmt.Fprintf(p.Out, "resource %s would be admitted\n", resourcePath)
+	}
+}
+
 func (p *PolicyProcessor) processMutateEngineResponse(response engineapi.EngineResponse, resourcePath string) error {
 	p.Rc.addMutateResponse(response)
 	err := p.printOutput(response.PatchedResource.Object, response, resourcePath, false)
@@ -1,7 +1,9 @@
 package processor
 
 import (
+	"bytes"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
@@ -118,3 +120,108 @@ func Test_NamespaceSelector(t *testing.T) {
 		assert.Equal(t, int64(rc.Error), int64(tc.result.Error))
 	}
 }
+
+var policiesPipeline = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-team
+spec:
+  rules:
+  - name: add-team-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            +(team): payments
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-team
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: require-team-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: the team label is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: restrict-team
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: restrict-team-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: the team must be checkout
+      pattern:
+        metadata:
+          labels:
+            team: checkout
+`)
+
+func Test_Pipeline(t *testing.T) {
+	testcases := []struct {
+		name     string
+		policies int
+		resource []byte
+		result   ResultCounts
+		output   string
+	}{{
+		name:     "mutated resource is validated",
+		policies: 2,
+		resource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"default"},"spec":{"containers":[{"image":"nginx:latest","name":"nginx"}]}}`),
+		result:   ResultCounts{Pass: 2},
+		output:   "resource default/Pod/nginx would be admitted",
+	}, {
+		name:     "mutated resource is denied",
+		policies: 3,
+		resource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"default"},"spec":{"containers":[{"image":"nginx:latest","name":"nginx"}]}}`),
+		result:   ResultCounts{Pass: 2, Fail: 1},
+		output:   "resource default/Pod/nginx would be denied at admission",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			policyArray, _, _, err := yamlutils.GetPolicy(policiesPipeline)
+			assert.NilError(t, err)
+			resourceArray, err := resource.GetUnstructuredResources(tc.resource)
+			assert.NilError(t, err)
+			var out bytes.Buffer
+			rc := &ResultCounts{}
+			processor := PolicyProcessor{
+				Store:    &store.Store{},
+				Policies: policyArray[:tc.policies],
+				Resource: *resourceArray[0],
+				Rc:       rc,
+				Out:      &out,
+				Pipeline: true,
+			}
+			responses, err := processor.ApplyPoliciesOnResource()
+			assert.NilError(t, err)
+			assert.Equal(t, len(responses), tc.policies)
+			assert.Equal(t, *rc, tc.result)
+			assert.Assert(t, strings.Contains(out.String(), tc.output), out.String())
+		})
+	}
+}
@@ -1,15 +1,20 @@
 package processor
 
 import (
-	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 )
 
-func policyHasValidateOrVerifyImageChecks(policy kyvernov1.PolicyInterface) bool {
-	for _, rule := range policy.GetSpec().Rules {
-		//  engine.validate handles both validate and verifyImageChecks atm
-		if rule.HasValidate() || rule.HasVerifyImageChecks() {
-			return true
-		}
+func pipelineStatus(response engineapi.EngineResponse) engineapi.RuleStatus {
+	switch {
+	case response.IsError():
+		return engineapi.RuleStatusError
+	case response.IsFailed():
+		return engineapi.RuleStatusFail
+	case response.IsOneOf(engineapi.RuleStatusWarn):
+		return engineapi.RuleStatusWarn
+	case response.IsOneOf(engineapi.RuleStatusPass):
+		return engineapi.RuleStatusPass
+	default:
+		return engineapi.RuleStatusSkip
 	}
-	return false
 }
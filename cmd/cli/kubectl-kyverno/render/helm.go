@@ -0,0 +1,380 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/xeipuuv/gojsonschema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultReleaseName = "release"
+	defaultNamespace   = "default"
+	// kubeVersion is the kubernetes version exposed to templates through .Capabilities
+	kubeVersion = "v1.31.0"
+	// helmHookAnnotation marks resources managed as hooks by helm
+	helmHookAnnotation = "helm.sh/hook"
+)
+
+type chartMetadata struct {
+	APIVersion   string `json:"apiVersion"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	AppVersion   string `json:"appVersion,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Type         string `json:"type,omitempty"`
+	KubeVersion  string `json:"kubeVersion,omitempty"`
+	Dependencies []any  `json:"dependencies,omitempty"`
+}
+
+// unsupported returns the error reported when a chart uses a Helm feature that can't be rendered faithfully
+func unsupported(feature string) error {
+	return fmt.Errorf("%s is not supported, charts are rendered without the Helm SDK", feature)
+}
+
+type files map[string][]byte
+
+// Get returns the content of a chart file, or an empty string if it does not exist
+func (f files) Get(name string) string {
+	return string(f[name])
+}
+
+// GetBytes returns the content of a chart file, or nil if it does not exist
+func (f files) GetBytes(name string) []byte {
+	return f[name]
+}
+
+// Lines returns the lines of a chart file
+func (f files) Lines(name string) []string {
+	if f[name] == nil {
+		return []string{}
+	}
+	return strings.Split(string(f[name]), "\n")
+}
+
+// Glob fails, helm glob patterns are not supported
+func (f files) Glob(string) (files, error) {
+	return nil, unsupported(".Files.Glob")
+}
+
+// AsConfig fails, it is only useful together with Glob
+func (f files) AsConfig() (string, error) {
+	return "", unsupported(".Files.AsConfig")
+}
+
+// AsSecrets fails, it is only useful together with Glob
+func (f files) AsSecrets() (string, error) {
+	return "", unsupported(".Files.AsSecrets")
+}
+
+type apiVersions []string
+
+// Has fails, rendering is done without a cluster and a silent false would change the rendered resources
+func (apiVersions) Has(string) (bool, error) {
+	return false, unsupported(".Capabilities.APIVersions.Has")
+}
+
+// Helm renders the local chart in path with the values files of opts.
+// Rendered resources are annotated with helm://<path>/<template>.
+//
+// Charts are rendered with text/template and the sprig functions, not with the Helm SDK,
+// only self contained application charts are supported. Charts using a feature that would render
+// differently from helm template fail with an explicit error:
+//   - charts with dependencies (Chart.yaml dependencies, requirements.yaml or a charts directory)
+//     and library charts are rejected
+//   - .Capabilities reports Kubernetes v1.31.0, .Capabilities.APIVersions.Has and lookup fail
+//   - templates producing hooks are rejected
+//   - .Files.Glob, .Files.AsConfig and .Files.AsSecrets fail
+//
+// Values are validated against values.schema.json like helm does.
+func Helm(path string, opts Options) ([]*unstructured.Unstructured, error) {
+	content, err := os.ReadFile(filepath.Join(path, "Chart.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var chart chartMetadata
+	if err := yaml.Unmarshal(content, &chart); err != nil {
+		return nil, fmt.Errorf("failed to parse Chart.yaml (%w)", err)
+	}
+	if chart.Name == "" {
+		return nil, errors.New("chart name is required in Chart.yaml")
+	}
+	if len(chart.Dependencies) != 0 || isFile(filepath.Join(path, "requirements.yaml")) {
+		return nil, errors.New("charts with dependencies are not supported")
+	}
+	if chart.Type == "library" {
+		return nil, errors.New("library charts can't be rendered")
+	}
+	values, err := loadValues(filepath.Join(path, "values.yaml"), true)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range opts.HelmValues {
+		overrides, err := loadValues(file, false)
+		if err != nil {
+			return nil, err
+		}
+		values = mergeValues(values, overrides)
+	}
+	if err := validateValues(filepath.Join(path, "values.schema.json"), chart.Name, values); err != nil {
+		return nil, err
+	}
+	templates, chartFiles, err := loadChart(path, chart.Name)
+	if err != nil {
+		return nil, err
+	}
+	release := map[string]any{
+		"Name":      opts.ReleaseName,
+		"Namespace": opts.Namespace,
+		"Service":   "Helm",
+		"IsInstall": true,
+		"IsUpgrade": false,
+		"Revision":  1,
+	}
+	if opts.ReleaseName == "" {
+		release["Name"] = defaultReleaseName
+	}
+	if opts.Namespace == "" {
+		release["Namespace"] = defaultNamespace
+	}
+	data := map[string]any{
+		"Values":  values,
+		"Release": release,
+		"Chart": map[string]any{
+			"APIVersion":  chart.APIVersion,
+			"Name":        chart.Name,
+			"Version":     chart.Version,
+			"AppVersion":  chart.AppVersion,
+			"Description": chart.Description,
+			"Type":        chart.Type,
+			"KubeVersion": chart.KubeVersion,
+		},
+		"Capabilities": map[string]any{
+			"KubeVersion": map[string]any{
+				"Version":    kubeVersion,
+				"GitVersion": kubeVersion,
+				"Major":      "1",
+				"Minor":      "31",
+			},
+			"APIVersions": apiVersions{},
+		},
+		"Files": chartFiles,
+	}
+	tpl := template.New(chart.Name).Option("missingkey=zero")
+	tpl.Funcs(funcMap(tpl))
+	var names []string
+	for name, content := range templates {
+		if _, err := tpl.New(name).Parse(content); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s (%w)", name, err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var resources []*unstructured.Unstructured
+	for _, name := range names {
+		base := filepath.Base(name)
+		// partials and notes do not produce resources
+		if strings.HasPrefix(base, "_") || strings.EqualFold(base, "NOTES.txt") {
+			continue
+		}
+		templateData := map[string]any{
+			"Template": map[string]any{
+				"Name":     name,
+				"BasePath": chart.Name + "/templates",
+			},
+		}
+		for k, v := range data {
+			templateData[k] = v
+		}
+		var buf bytes.Buffer
+		if err := tpl.ExecuteTemplate(&buf, name, templateData); err != nil {
+			return nil, fmt.Errorf("failed to render template %s (%w)", name, err)
+		}
+		rendered := strings.ReplaceAll(buf.String(), "<no value>", "")
+		if strings.TrimSpace(rendered) == "" {
+			continue
+		}
+		objects, err := resource.GetUnstructuredResources([]byte(rendered))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rendered template %s (%w)", name, err)
+		}
+		origin := "helm://" + filepath.Join(path, strings.TrimPrefix(name, chart.Name+"/"))
+		for _, object := range objects {
+			if _, ok := object.GetAnnotations()[helmHookAnnotation]; ok {
+				return nil, fmt.Errorf("template %s renders the hook %s/%s, hooks are not supported as charts are rendered without the Helm SDK", name, object.GetKind(), object.GetName())
+			}
+			setOrigin(object, origin)
+			resources = append(resources, object)
+		}
+	}
+	return resources, nil
+}
+
+// loadChart returns the chart templates keyed by <chart name>/templates/<path> and the other chart files
+func loadChart(path, chartName string) (map[string]string, files, error) {
+	templates := map[string]string{}
+	chartFiles := files{}
+	err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(path, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == "charts" {
+				return errors.New("charts with subcharts are not supported")
+			}
+			return nil
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(rel, "templates/") {
+			templates[chartName+"/"+rel] = string(content)
+		} else if rel != "Chart.yaml" && rel != "values.yaml" && rel != "values.schema.json" {
+			chartFiles[rel] = content
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return templates, chartFiles, nil
+}
+
+// validateValues validates the values against the chart JSON schema, if the chart has one
+func validateValues(path, chartName string, values map[string]any) error {
+	schema, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewGoLoader(values))
+	if err != nil {
+		return fmt.Errorf("failed to validate values against values.schema.json (%w)", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	var violations []string
+	for _, violation := range result.Errors() {
+		violations = append(violations, "- "+violation.String())
+	}
+	return fmt.Errorf("values don't meet the specifications of the schema of chart %s:\n%s", chartName, strings.Join(violations, "\n"))
+}
+
+func loadValues(path string, optional bool) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return map[string]any{}, nil
+		}
+		return nil, err
+	}
+	values := map[string]any{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse values file %s (%w)", path, err)
+	}
+	return values, nil
+}
+
+// mergeValues deeply merges src into dst, values in src take precedence and null values remove keys
+func mergeValues(dst, src map[string]any) map[string]any {
+	for key, value := range src {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+		if srcMap, ok := value.(map[string]any); ok {
+			if dstMap, ok := dst[key].(map[string]any); ok {
+				dst[key] = mergeValues(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
+func funcMap(tpl *template.Template) template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	// helm removes functions giving access to the environment
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	funcs["toYaml"] = func(v any) string {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+	funcs["fromYaml"] = func(s string) map[string]any {
+		m := map[string]any{}
+		if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["toJson"] = func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+	funcs["fromJson"] = funcs["fromYaml"]
+	funcs["required"] = func(message string, v any) (any, error) {
+		if v == nil {
+			return nil, errors.New(message)
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return nil, errors.New(message)
+		}
+		return v, nil
+	}
+	funcs["include"] = func(name string, data any) (string, error) {
+		var buf bytes.Buffer
+		if err := tpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	funcs["tpl"] = func(text string, data any) (string, error) {
+		clone, err := tpl.Clone()
+		if err != nil {
+			return "", err
+		}
+		t, err := clone.New("tpl").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+	}
+	// lookup requires a cluster, rendering is done offline
+	funcs["lookup"] = func(string, string, string, string) (map[string]any, error) {
+		return nil, unsupported("lookup")
+	}
+	return funcs
+}
@@ -0,0 +1,228 @@
+package render
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	chartHelpers = `{{- define "app.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end -}}
+{{- define "app.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+{{- end -}}
+`
+	chartDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "app.fullname" . }}
+  labels:
+    {{- include "app.labels" . | nindent 4 }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: app
+        image: {{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}
+        {{- with .Values.resources }}
+        resources:
+          {{- toYaml . | nindent 10 }}
+        {{- end }}
+`
+	chartService = `{{- if .Values.service.enabled }}
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "app.fullname" . }}
+  namespace: {{ .Release.Namespace }}
+spec:
+  ports:
+  - port: {{ .Values.service.port }}
+{{- end }}
+`
+)
+
+func newChart(t *testing.T) string {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Chart.yaml": "apiVersion: v2\nname: app\nversion: 0.1.0\nappVersion: \"1.2.3\"\n",
+		"values.yaml": `replicas: 1
+image:
+  repository: registry.example.com/app
+  tag: ""
+service:
+  enabled: false
+  port: 80
+resources: {}
+`,
+		"templates/_helpers.tpl":    chartHelpers,
+		"templates/deployment.yaml": chartDeployment,
+		"templates/service.yaml":    chartService,
+		"templates/NOTES.txt":       "{{ .Release.Name }} installed\n",
+		"production.yaml": `replicas: 3
+service:
+  enabled: true
+resources:
+  limits:
+    memory: 128Mi
+`,
+		"latest.yaml": "image:\n  tag: latest\n",
+	})
+	return dir
+}
+
+func TestHelm(t *testing.T) {
+	chart := newChart(t)
+
+	resources, err := Helm(chart, Options{})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	deployment := resources[0]
+	assert.Equal(t, "release-app", deployment.GetName())
+	assert.Equal(t, "1.2.3", deployment.GetLabels()["app.kubernetes.io/version"])
+	assert.Equal(t, "helm://"+filepath.Join(chart, "templates", "deployment.yaml"), deployment.GetAnnotations()[AnnotationOrigin])
+	replicas, _, _ := unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+	assert.Equal(t, int64(1), replicas)
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, "registry.example.com/app:1.2.3", containers[0].(map[string]any)["image"])
+	assert.NotContains(t, containers[0], "resources")
+
+	resources, err = Helm(chart, Options{
+		HelmValues:  []string{filepath.Join(chart, "production.yaml"), filepath.Join(chart, "latest.yaml")},
+		ReleaseName: "web",
+		Namespace:   "production",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	deployment, service := resources[0], resources[1]
+	assert.Equal(t, "web-app", deployment.GetName())
+	replicas, _, _ = unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+	assert.Equal(t, int64(3), replicas)
+	containers, _, _ = unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, "registry.example.com/app:latest", containers[0].(map[string]any)["image"])
+	memory, _, _ := unstructured.NestedString(containers[0].(map[string]any), "resources", "limits", "memory")
+	assert.Equal(t, "128Mi", memory)
+	assert.Equal(t, "Service", service.GetKind())
+	assert.Equal(t, "production", service.GetNamespace())
+	assert.Equal(t, "helm://"+filepath.Join(chart, "templates", "service.yaml"), service.GetAnnotations()[AnnotationOrigin])
+}
+
+func TestHelmErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"dependencies/Chart.yaml":           "apiVersion: v2\nname: app\nversion: 0.1.0\ndependencies:\n- name: redis\n",
+		"requirements/Chart.yaml":           "apiVersion: v1\nname: app\nversion: 0.1.0\n",
+		"requirements/requirements.yaml":    "dependencies:\n- name: redis\n",
+		"library/Chart.yaml":                "apiVersion: v2\nname: app\nversion: 0.1.0\ntype: library\n",
+		"subcharts/Chart.yaml":              "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"subcharts/charts/redis/Chart.yaml": "apiVersion: v2\nname: redis\nversion: 0.1.0\n",
+		"required/Chart.yaml":               "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"required/templates/cm.yaml":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ required \"name is required\" .Values.name }}\n",
+		"invalid/Chart.yaml":                "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"invalid/templates/cm.yaml":         "{{ .Values.name \n",
+		"lookup/Chart.yaml":                 "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"lookup/templates/cm.yaml":          "{{- $cm := lookup \"v1\" \"ConfigMap\" \"default\" \"app\" }}\n",
+		"capabilities/Chart.yaml":           "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"capabilities/templates/pdb.yaml":   "{{- if .Capabilities.APIVersions.Has \"policy/v1\" }}\napiVersion: policy/v1\n{{- end }}\n",
+		"hook/Chart.yaml":                   "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"hook/templates/job.yaml":           "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrate\n  annotations:\n    helm.sh/hook: pre-install\n",
+		"glob/Chart.yaml":                   "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"glob/templates/cm.yaml":            "data:\n  {{- (.Files.Glob \"config/*\").AsConfig | nindent 2 }}\n",
+		"schema/Chart.yaml":                 "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"schema/values.yaml":                "replicas: zero\n",
+		"schema/values.schema.json":         `{"type": "object", "properties": {"replicas": {"type": "integer"}}}`,
+	})
+	tests := []struct {
+		name    string
+		chart   string
+		wantErr string
+	}{{
+		name:    "dependencies",
+		chart:   "dependencies",
+		wantErr: "charts with dependencies are not supported",
+	}, {
+		name:    "requirements",
+		chart:   "requirements",
+		wantErr: "charts with dependencies are not supported",
+	}, {
+		name:    "library",
+		chart:   "library",
+		wantErr: "library charts can't be rendered",
+	}, {
+		name:    "subcharts",
+		chart:   "subcharts",
+		wantErr: "charts with subcharts are not supported",
+	}, {
+		name:    "required value",
+		chart:   "required",
+		wantErr: "name is required",
+	}, {
+		name:    "invalid template",
+		chart:   "invalid",
+		wantErr: "failed to parse template app/templates/cm.yaml",
+	}, {
+		name:    "lookup",
+		chart:   "lookup",
+		wantErr: "lookup is not supported, charts are rendered without the Helm SDK",
+	}, {
+		name:    "capabilities",
+		chart:   "capabilities",
+		wantErr: ".Capabilities.APIVersions.Has is not supported, charts are rendered without the Helm SDK",
+	}, {
+		name:    "hook",
+		chart:   "hook",
+		wantErr: "template app/templates/job.yaml renders the hook Job/migrate, hooks are not supported as charts are rendered without the Helm SDK",
+	}, {
+		name:    "files glob",
+		chart:   "glob",
+		wantErr: ".Files.Glob is not supported, charts are rendered without the Helm SDK",
+	}, {
+		name:    "values schema",
+		chart:   "schema",
+		wantErr: "values don't meet the specifications of the schema of chart app:\n- replicas: Invalid type. Expected: integer, given: string",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Helm(filepath.Join(dir, tt.chart), Options{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHelmValuesSchemaAndFiles(t *testing.T) {
+	chart := t.TempDir()
+	writeFiles(t, chart, map[string]string{
+		"Chart.yaml":         "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"values.yaml":        "replicas: 2\n",
+		"values.schema.json": `{"type": "object", "required": ["replicas"], "properties": {"replicas": {"type": "integer"}}}`,
+		"config/hosts":       "a.example.com\nb.example.com",
+		"templates/cm.yaml":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: hosts\ndata:\n  replicas: {{ .Values.replicas | quote }}\n  {{- range $i, $host := .Files.Lines \"config/hosts\" }}\n  host{{ $i }}: {{ $host }}\n  {{- end }}\n",
+	})
+	resources, err := Helm(chart, Options{})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	data, _, _ := unstructured.NestedStringMap(resources[0].Object, "data")
+	assert.Equal(t, map[string]string{"replicas": "2", "host0": "a.example.com", "host1": "b.example.com"}, data)
+}
+
+func TestMergeValues(t *testing.T) {
+	values := mergeValues(map[string]any{
+		"image":    map[string]any{"repository": "nginx", "tag": "1.27"},
+		"replicas": 1,
+		"debug":    true,
+	}, map[string]any{
+		"image":    map[string]any{"tag": "latest"},
+		"replicas": 3,
+		"debug":    nil,
+	})
+	assert.Equal(t, map[string]any{
+		"image":    map[string]any{"repository": "nginx", "tag": "latest"},
+		"replicas": 3,
+	}, values)
+}
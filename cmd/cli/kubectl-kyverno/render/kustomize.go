@@ -0,0 +1,30 @@
+package render
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Kustomize builds the kustomization in path.
+// Rendered resources are annotated with kustomize://<path>.
+func Kustomize(path string) ([]*unstructured.Unstructured, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := resMap.AsYaml()
+	if err != nil {
+		return nil, err
+	}
+	resources, err := resource.GetUnstructuredResources(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		setOrigin(resource, "kustomize://"+path)
+	}
+	return resources, nil
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKustomize(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/kustomization.yaml": "resources:\n- deployment.yaml\n",
+		"base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.27
+`,
+		"overlays/production/kustomization.yaml": `namespace: production
+namePrefix: prod-
+resources:
+- ../../base
+images:
+- name: nginx
+  newTag: latest
+`,
+	})
+	resources, err := Kustomize(filepath.Join(dir, "overlays", "production"))
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, "prod-nginx", resources[0].GetName())
+	assert.Equal(t, "production", resources[0].GetNamespace())
+	assert.Equal(t, "kustomize://"+filepath.Join(dir, "overlays", "production"), resources[0].GetAnnotations()[AnnotationOrigin])
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, "nginx:latest", containers[0].(map[string]any)["image"])
+
+	_, err = Kustomize(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AnnotationOrigin is set on rendered resources and records the kustomization or chart template they come from
+const AnnotationOrigin = "cli.kyverno.io/origin"
+
+type Options struct {
+	// HelmValues are values files merged on top of the chart values, in order
+	HelmValues []string
+	// ReleaseName is the release name used to render charts
+	ReleaseName string
+	// Namespace is the release namespace used to render charts
+	Namespace string
+}
+
+var kustomizationFiles = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// IsKustomization returns true if the path is a directory containing a kustomization file
+func IsKustomization(path string) bool {
+	for _, file := range kustomizationFiles {
+		if isFile(filepath.Join(path, file)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsChart returns true if the path is a directory containing a Helm chart
+func IsChart(path string) bool {
+	return isFile(filepath.Join(path, "Chart.yaml"))
+}
+
+// Render renders the kustomizations and charts found in paths.
+// It returns the rendered resources and the paths that are neither a kustomization nor a chart.
+func Render(paths []string, opts Options) ([]*unstructured.Unstructured, []string, error) {
+	var resources []*unstructured.Unstructured
+	var remaining []string
+	for _, path := range paths {
+		var rendered []*unstructured.Unstructured
+		var err error
+		if IsKustomization(path) {
+			rendered, err = Kustomize(path)
+		} else if IsChart(path) {
+			rendered, err = Helm(path, opts)
+		} else {
+			remaining = append(remaining, path)
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render %s (%w)", path, err)
+		}
+		resources = append(resources, rendered...)
+	}
+	return resources, remaining, nil
+}
+
+func setOrigin(resource *unstructured.Unstructured, origin string) {
+	annotations := resource.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationOrigin] = origin
+	resource.SetAnnotations(annotations)
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestIsKustomizationAndIsChart(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"overlay/kustomization.yaml": "resources: []\n",
+		"chart/Chart.yaml":           "name: app\n",
+		"plain/pod.yaml":             "kind: Pod\n",
+	})
+	tests := []struct {
+		name          string
+		path          string
+		kustomization bool
+		chart         bool
+	}{{
+		name:          "kustomization",
+		path:          filepath.Join(dir, "overlay"),
+		kustomization: true,
+	}, {
+		name:  "chart",
+		path:  filepath.Join(dir, "chart"),
+		chart: true,
+	}, {
+		name: "directory",
+		path: filepath.Join(dir, "plain"),
+	}, {
+		name: "file",
+		path: filepath.Join(dir, "plain", "pod.yaml"),
+	}, {
+		name: "stdin",
+		path: "-",
+	}, {
+		name: "not found",
+		path: filepath.Join(dir, "missing"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.kustomization, IsKustomization(tt.path))
+			assert.Equal(t, tt.chart, IsChart(tt.path))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"overlay/kustomization.yaml": "resources:\n- pod.yaml\n",
+		"overlay/pod.yaml":           "apiVersion: v1\nkind: Pod\nmetadata:\n  name: kustomized\n",
+		"chart/Chart.yaml":           "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		"chart/templates/pod.yaml":   "apiVersion: v1\nkind: Pod\nmetadata:\n  name: {{ .Release.Name }}\n",
+	})
+	plain := filepath.Join(dir, "pod.yaml")
+	resources, remaining, err := Render([]string{filepath.Join(dir, "overlay"), plain, filepath.Join(dir, "chart")}, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{plain}, remaining)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "kustomized", resources[0].GetName())
+	assert.Equal(t, "kustomize://"+filepath.Join(dir, "overlay"), resources[0].GetAnnotations()[AnnotationOrigin])
+	assert.Equal(t, "release", resources[1].GetName())
+	assert.Equal(t, "helm://"+filepath.Join(dir, "chart", "templates", "pod.yaml"), resources[1].GetAnnotations()[AnnotationOrigin])
+
+	_, _, err = Render([]string{filepath.Join(dir, "overlay"), filepath.Join(dir, "chart")}, Options{HelmValues: []string{filepath.Join(dir, "missing.yaml")}})
+	assert.ErrorContains(t, err, "failed to render "+filepath.Join(dir, "chart"))
+}
@@ -10,9 +10,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 )
 
-func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory {
+func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver, opts ...factories.ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
 	if !s.IsLocal() {
-		return factories.DefaultContextLoaderFactory(cmResolver)
+		return factories.DefaultContextLoaderFactory(cmResolver, opts...)
 	}
 	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		init := func(jsonContext enginecontext.Interface) error {
@@ -34,7 +34,7 @@ func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engi
 			}
 			return nil
 		}
-		factory := factories.DefaultContextLoaderFactory(cmResolver, factories.WithInitializer(init))
+		factory := factories.DefaultContextLoaderFactory(cmResolver, append([]factories.ContextLoaderFactoryOptions{factories.WithInitializer(init)}, opts...)...)
 		return wrapper{
 			store: s,
 			inner: factory(policy, rule),
@@ -51,6 +51,11 @@ func (s *Store) SetRegistryAccess(access bool) {
 	}
 }
 
+// SetRegistryClient sets the registry client used to access images, it also grants registry access
+func (s *Store) SetRegistryClient(client registryclient.Client) {
+	s.registryClient = client
+}
+
 func (s *Store) GetRegistryAccess() bool {
 	return s.registryClient != nil
 }
@@ -17,3 +17,32 @@ type TestCase struct {
 func (tc TestCase) Dir() string {
 	return filepath.Clean(filepath.Dir(tc.Path))
 }
+
+// References returns the files referenced by the test, as written in the test file.
+func (tc TestCase) References() []string {
+	if tc.Test == nil {
+		return nil
+	}
+	var refs []string
+	add := func(files ...string) {
+		for _, file := range files {
+			if file != "" {
+				refs = append(refs, file)
+			}
+		}
+	}
+	add(tc.Test.Policies...)
+	add(tc.Test.Resources...)
+	add(tc.Test.TargetResources...)
+	add(tc.Test.HelmValues...)
+	add(tc.Test.PolicyExceptions...)
+	add(tc.Test.ImageLayouts...)
+	add(tc.Test.Variables, tc.Test.UserInfo)
+	if tc.Test.Cleanup != nil {
+		add(tc.Test.Cleanup.Policies...)
+	}
+	for _, result := range tc.Test.Results {
+		add(result.PatchedResources, result.PatchedResource, result.GeneratedResource, result.CloneSourceResource)
+	}
+	return refs
+}
@@ -0,0 +1,54 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Results maps a result, usually identified by policy, rule and resource, to its status.
+type Results map[string]string
+
+// Diff returns the results that were added, removed or changed between two runs, sorted by result.
+func Diff(previous, current Results) []string {
+	keys := map[string]struct{}{}
+	for key := range previous {
+		keys[key] = struct{}{}
+	}
+	for key := range current {
+		keys[key] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	var changes []string
+	for _, key := range sorted {
+		before, wasThere := previous[key]
+		after, isThere := current[key]
+		switch {
+		case !wasThere:
+			changes = append(changes, fmt.Sprintf("+ %s: %s", key, after))
+		case !isThere:
+			changes = append(changes, fmt.Sprintf("- %s: %s", key, before))
+		case before != after:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", key, before, after))
+		}
+	}
+	return changes
+}
+
+// PrintDiff prints the changes between two runs.
+func PrintDiff(out io.Writer, previous, current Results) {
+	changes := Diff(previous, current)
+	fmt.Fprintln(out)
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No result changed since previous run")
+		return
+	}
+	fmt.Fprintln(out, "Changes since previous run:")
+	for _, change := range changes {
+		fmt.Fprintln(out, " ", change)
+	}
+}
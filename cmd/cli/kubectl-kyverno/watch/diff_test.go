@@ -0,0 +1,36 @@
+package watch
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	previous := Results{
+		"policy/rule Pod/a": "pass",
+		"policy/rule Pod/b": "pass",
+		"policy/rule Pod/c": "fail",
+	}
+	current := Results{
+		"policy/rule Pod/a": "pass",
+		"policy/rule Pod/b": "fail",
+		"policy/rule Pod/d": "skip",
+	}
+	assert.Equal(t, []string{
+		"~ policy/rule Pod/b: pass -> fail",
+		"- policy/rule Pod/c: fail",
+		"+ policy/rule Pod/d: skip",
+	}, Diff(previous, current))
+	assert.Empty(t, Diff(current, current))
+}
+
+func TestPrintDiff(t *testing.T) {
+	var out bytes.Buffer
+	PrintDiff(&out, Results{"a": "pass"}, Results{"a": "pass"})
+	assert.Equal(t, "\nNo result changed since previous run\n", out.String())
+	out.Reset()
+	PrintDiff(&out, Results{"a": "pass"}, Results{"a": "fail"})
+	assert.Equal(t, "\nChanges since previous run:\n  ~ a: pass -> fail\n", out.String())
+}
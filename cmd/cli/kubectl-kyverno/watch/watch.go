@@ -0,0 +1,120 @@
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounce is the time to wait for more events after a change, editors usually write files in several steps.
+const debounce = 200 * time.Millisecond
+
+// Run waits for changes to the given paths and invokes run with the changed files, until the context is cancelled.
+// Paths can be files or directories, directories are watched recursively.
+// Paths are resolved again after every run so that files referenced by changed files are watched too.
+func Run(ctx context.Context, paths func() []string, run func(changed []string)) error {
+	for {
+		changed, err := wait(ctx, paths())
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		run(changed)
+	}
+}
+
+// Affects returns true if one of the changed files is one of the given paths, or lives in one of them.
+func Affects(paths []string, changed []string) bool {
+	for _, path := range paths {
+		path = abs(path)
+		for _, file := range changed {
+			file = abs(file)
+			if file == path || strings.HasPrefix(file, path+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func wait(ctx context.Context, paths []string) ([]string, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	defer watcher.Close()
+	var watched []string
+	for _, path := range paths {
+		path = abs(path)
+		watched = append(watched, path)
+		if err := add(watcher, path); err != nil {
+			return nil, err
+		}
+	}
+	changed := map[string]struct{}{}
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case err := <-watcher.Errors:
+			return nil, err
+		case event := <-watcher.Events:
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if !Affects(watched, []string{event.Name}) {
+				continue
+			}
+			changed[abs(event.Name)] = struct{}{}
+			timer = time.After(debounce)
+		case <-timer:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			return files, nil
+		}
+	}
+}
+
+// add registers the directories to watch for a path, the parent directory for files
+// (editors often replace files instead of writing them) and all sub directories for directories.
+func add(watcher *fsnotify.Watcher, path string) error {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		// a missing file can be created later, watch its parent if it exists
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			return nil
+		}
+		return watcher.Add(filepath.Dir(path))
+	}
+	return filepath.WalkDir(path, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if dir != path && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return watcher.Add(dir)
+	})
+}
+
+func abs(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,41 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffects(t *testing.T) {
+	assert.True(t, Affects([]string{"/tmp/a.yaml"}, []string{"/tmp/a.yaml"}))
+	assert.True(t, Affects([]string{"/tmp/dir"}, []string{"/tmp/dir/sub/a.yaml"}))
+	assert.False(t, Affects([]string{"/tmp/dir"}, []string{"/tmp/directory/a.yaml"}))
+	assert.False(t, Affects([]string{"/tmp/a.yaml"}, []string{"/tmp/b.yaml"}))
+	assert.False(t, Affects([]string{"/tmp/a.yaml"}, nil))
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	watched := filepath.Join(dir, "watched.yaml")
+	ignored := filepath.Join(dir, "ignored.yaml")
+	assert.NoError(t, os.WriteFile(watched, []byte("a"), 0o600))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var calls [][]string
+	go func() {
+		// give the watcher some time to start
+		time.Sleep(500 * time.Millisecond)
+		assert.NoError(t, os.WriteFile(ignored, []byte("b"), 0o600))
+		assert.NoError(t, os.WriteFile(watched, []byte("b"), 0o600))
+	}()
+	err := Run(ctx, func() []string { return []string{watched} }, func(changed []string) {
+		calls = append(calls, changed)
+		cancel()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{watched}}, calls)
+}
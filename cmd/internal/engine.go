@@ -15,10 +15,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"k8s.io/client-go/kubernetes"
@@ -31,6 +33,8 @@ func NewEngine(
 	configuration config.Configuration,
 	metricsConfiguration config.MetricsConfiguration,
 	jp jmespath.Interface,
+	celCache enginecel.Cache,
+	schemaCache jsonschema.Cache,
 	client dclient.Interface,
 	rclient registryclient.Client,
 	ivCache imageverifycache.Client,
@@ -40,6 +44,8 @@ func NewEngine(
 	apiCallConfig apicall.APICallConfiguration,
 	exceptionsSelector engineapi.PolicyExceptionSelector,
 	gctxStore loaders.Store,
+	collectionStore loaders.CollectionStore,
+	paramsStore loaders.ParamsStore,
 ) engineapi.Engine {
 	configMapResolver := NewConfigMapResolver(ctx, logger, kubeClient, resyncPeriod)
 	logger = logger.WithName("engine")
@@ -48,10 +54,19 @@ func NewEngine(
 		configuration,
 		metricsConfiguration,
 		jp,
+		celCache,
+		schemaCache,
 		adapters.Client(client),
+		configMapResolver,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), secretLister),
 		ivCache,
-		factories.DefaultContextLoaderFactory(configMapResolver, factories.WithAPICallConfig(apiCallConfig), factories.WithGlobalContextStore(gctxStore)),
+		factories.DefaultContextLoaderFactory(
+			configMapResolver,
+			factories.WithAPICallConfig(apiCallConfig),
+			factories.WithGlobalContextStore(gctxStore),
+			factories.WithCollectionStore(collectionStore),
+			factories.WithParamsStore(paramsStore),
+		),
 		exceptionsSelector,
 		nil,
 	)
@@ -12,7 +12,9 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
 	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -38,6 +40,8 @@ type SetupResult struct {
 	MetricsConfiguration   config.MetricsConfiguration
 	MetricsManager         metrics.MetricsConfigManager
 	Jp                     jmespath.Interface
+	CELCache               enginecel.Cache
+	SchemaCache            jsonschema.Cache
 	KubeClient             kubeclient.UpstreamInterface
 	LeaderElectionClient   kubeclient.UpstreamInterface
 	RegistryClient         registryclient.Client
@@ -119,6 +123,8 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 			MetricsConfiguration:   metricsConfiguration,
 			MetricsManager:         metricsManager,
 			Jp:                     jmespath.New(configuration),
+			CELCache:               enginecel.NewCache(),
+			SchemaCache:            jsonschema.NewCache(),
 			KubeClient:             client,
 			LeaderElectionClient:   leaderElectionClient,
 			RegistryClient:         registryClient,
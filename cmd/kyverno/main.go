@@ -17,22 +17,29 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/collection"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	genericloggingcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/logging"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
+	policybundlecontroller "github.com/kyverno/kyverno/pkg/controllers/policybundle"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
 	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy-generate"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/utils/generator"
@@ -86,12 +93,20 @@ func createNonLeaderControllers(
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	dynamicClient dclient.Interface,
 	policyCache policycache.Cache,
+	jp jmespath.Interface,
+	celCache enginecel.Cache,
+	schemaCache jsonschema.Cache,
+	paramsStore loaders.ParamsStore,
 ) ([]internal.Controller, func(context.Context) error) {
 	policyCacheController := policycachecontroller.NewController(
 		dynamicClient,
 		policyCache,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
+		jp,
+		celCache,
+		schemaCache,
+		paramsStore,
 	)
 	return []internal.Controller{
 			internal.NewController(policycachecontroller.ControllerName, policyCacheController, policycachecontroller.Workers),
@@ -106,6 +121,7 @@ func createNonLeaderControllers(
 
 func createrLeaderControllers(
 	generateVAPs bool,
+	policyBundles bool,
 	admissionReports bool,
 	serverIP string,
 	webhookTimeout int,
@@ -126,6 +142,7 @@ func createrLeaderControllers(
 	webhookServerPort int32,
 	configuration config.Configuration,
 	eventGenerator event.Interface,
+	rclient registryclient.Client,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	var leaderControllers []internal.Controller
 	certManager := certmanager.NewController(
@@ -248,6 +265,15 @@ func createrLeaderControllers(
 		)
 		leaderControllers = append(leaderControllers, internal.NewController(vapcontroller.ControllerName, vapController, vapcontroller.Workers))
 	}
+	if policyBundles {
+		policyBundleController := policybundlecontroller.NewController(
+			kyvernoInformer.Kyverno().V2alpha1().PolicyBundles(),
+			dynamicClient,
+			kyvernoClient,
+			rclient,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(policybundlecontroller.ControllerName, policyBundleController, policybundlecontroller.Workers))
+	}
 	return leaderControllers, nil, nil
 }
 
@@ -263,6 +289,7 @@ func main() {
 		autoDeleteWebhooks           bool
 		webhookRegistrationTimeout   time.Duration
 		admissionReports             bool
+		policyBundles                bool
 		dumpPayload                  bool
 		servicePort                  int
 		webhookServerPort            int
@@ -287,7 +314,9 @@ func main() {
 	flagset.Func(toggle.ForceFailurePolicyIgnoreFlagName, toggle.ForceFailurePolicyIgnoreDescription, toggle.ForceFailurePolicyIgnore.Parse)
 	flagset.Func(toggle.GenerateValidatingAdmissionPolicyFlagName, toggle.GenerateValidatingAdmissionPolicyDescription, toggle.GenerateValidatingAdmissionPolicy.Parse)
 	flagset.Func(toggle.DumpMutatePatchesFlagName, toggle.DumpMutatePatchesDescription, toggle.DumpMutatePatches.Parse)
+	flagset.Func(toggle.ExplainAdmissionFlagName, toggle.ExplainAdmissionDescription, toggle.ExplainAdmission.Parse)
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
+	flagset.BoolVar(&policyBundles, "enablePolicyBundles", false, "Enable synchronization of policies from signed OCI policy bundles.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
 	flagset.IntVar(&webhookServerPort, "webhookServerPort", 9443, "Port used by the webhook server.")
 	flagset.StringVar(&backgroundServiceAccountName, "backgroundServiceAccountName", "", "Background controller service account name.")
@@ -433,6 +462,13 @@ func main() {
 			kubeKyvernoInformer.Apps().V1().Deployments(),
 			certRenewer,
 		)
+		// params store
+		paramsStore := collection.NewStore(
+			signalCtx,
+			setup.Logger.WithName("params-store"),
+			setup.KyvernoDynamicClient.GetDynamicInterface(),
+			setup.KyvernoDynamicClient.Discovery(),
+		)
 		// engine
 		engine := internal.NewEngine(
 			signalCtx,
@@ -440,6 +476,8 @@ func main() {
 			setup.Configuration,
 			setup.MetricsConfiguration,
 			setup.Jp,
+			setup.CELCache,
+			setup.SchemaCache,
 			setup.KyvernoDynamicClient,
 			setup.RegistryClient,
 			setup.ImageVerifyCacheClient,
@@ -449,12 +487,18 @@ func main() {
 			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
 			polexCache,
 			gcstore,
+			nil,
+			paramsStore,
 		)
 		// create non leader controllers
 		nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
 			kyvernoInformer,
 			setup.KyvernoDynamicClient,
 			policyCache,
+			setup.Jp,
+			setup.CELCache,
+			setup.SchemaCache,
+			paramsStore,
 		)
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
@@ -484,6 +528,7 @@ func main() {
 				// create leader controllers
 				leaderControllers, warmup, err := createrLeaderControllers(
 					generateValidatingAdmissionPolicy,
+					policyBundles,
 					admissionReports,
 					serverIP,
 					webhookTimeout,
@@ -504,6 +549,7 @@ func main() {
 					int32(webhookServerPort), //nolint:gosec
 					setup.Configuration,
 					eventGenerator,
+					setup.RegistryClient,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
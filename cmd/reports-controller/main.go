@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/collection"
 	"github.com/kyverno/kyverno/pkg/config"
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
@@ -65,6 +66,7 @@ func createReportControllers(
 	eventGenerator event.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	collectionStore collection.Store,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -119,6 +121,7 @@ func createReportControllers(
 				vapBindingInformer,
 				kubeInformer.Core().V1().Namespaces(),
 				resourceReportController,
+				collectionStore,
 				backgroundScanInterval,
 				configuration,
 				jp,
@@ -164,6 +167,7 @@ func createrLeaderControllers(
 	eventGenerator event.Interface,
 	backgroundScanInterval time.Duration,
 	reportsBreaker breaker.Breaker,
+	collectionStore collection.Store,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
@@ -185,6 +189,7 @@ func createrLeaderControllers(
 		eventGenerator,
 		reportsConfig,
 		reportsBreaker,
+		collectionStore,
 	)
 	return reportControllers, warmup, nil
 }
@@ -296,6 +301,13 @@ func main() {
 			),
 			globalcontextcontroller.Workers,
 		)
+		// collection store
+		collectionStore := collection.NewStore(
+			ctx,
+			setup.Logger.WithName("collection-store"),
+			setup.KyvernoDynamicClient.GetDynamicInterface(),
+			setup.KyvernoDynamicClient.Discovery(),
+		)
 		// engine
 		engine := internal.NewEngine(
 			ctx,
@@ -303,6 +315,8 @@ func main() {
 			setup.Configuration,
 			setup.MetricsConfiguration,
 			setup.Jp,
+			setup.CELCache,
+			setup.SchemaCache,
 			setup.KyvernoDynamicClient,
 			setup.RegistryClient,
 			setup.ImageVerifyCacheClient,
@@ -312,6 +326,8 @@ func main() {
 			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
 			polexCache,
 			gcstore,
+			collectionStore,
+			collectionStore,
 		)
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer) {
@@ -368,6 +384,7 @@ func main() {
 					eventGenerator,
 					backgroundScanInterval,
 					reportsBreaker,
+					collectionStore,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
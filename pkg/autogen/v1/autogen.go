@@ -72,6 +72,10 @@ func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers sets.Set[s
 		if rule.HasGenerate() {
 			return false, sets.New("none")
 		}
+		// schemas describe the selected document and don't apply to the pod template of controllers
+		if rule.HasValidateJSONSchema() {
+			return false, sets.New("none")
+		}
 		if rule.Mutation != nil {
 			if rule.Mutation.PatchesJSON6902 != "" {
 				return false, sets.New("none")
@@ -81,6 +85,13 @@ func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers sets.Set[s
 					return false, sets.New("none")
 				}
 			}
+			if rule.Mutation.CEL != nil {
+				for _, mutation := range rule.Mutation.CEL.Mutations {
+					if mutation.PatchType == kyvernov1.PatchTypeJSONPatch {
+						return false, sets.New("none")
+					}
+				}
+			}
 		}
 		match := rule.MatchResources
 		if !checkAutogenSupport(&needed, match.ResourceDescription) {
@@ -207,7 +218,7 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	} else {
 		// CEL variables are object, oldObject, request, params and authorizer.
 		// Therefore CEL expressions can be either written as object.spec or request.object.spec
-		bytes = updateFields(bytes, kind, rule.Validation != nil && rule.Validation.CEL != nil)
+		bytes = updateFields(bytes, kind, (rule.Validation != nil && rule.Validation.CEL != nil) || (rule.Mutation != nil && rule.Mutation.CEL != nil))
 		if err := json.Unmarshal(bytes, &rule); err != nil {
 			return nil, err
 		}
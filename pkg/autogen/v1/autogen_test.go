@@ -66,6 +66,11 @@ func Test_CanAutoGen(t *testing.T) {
 			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"test","match":{"resources":{"kinds":["Namespace"],"name":"*"}}}]}}`),
 			expectedControllers: sets.New("none"),
 		},
+		{
+			name:                "rule-with-validate-jsonschema",
+			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"test","match":{"resources":{"kinds":["Pod"]}},"validate":{"jsonSchema":{"schema":{"type":"object"}}}}]}}`),
+			expectedControllers: sets.New("none"),
+		},
 		{
 			name:                "rule-with-match-selector",
 			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test-getcontrollers"},"spec":{"background":false,"rules":[{"name":"test-getcontrollers","match":{"resources":{"kinds":["Pod"],"selector":{"matchLabels":{"foo":"bar"}}}}}]}}`),
@@ -91,6 +96,16 @@ func Test_CanAutoGen(t *testing.T) {
 			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"require-network-policy","match":{"resources":{"kinds":["Pod"]}},"validate":{"message":"testpolicy","deny":{"conditions":[{"key":"{{request.object.metadata.labels.foo}}","operator":"Equals","value":"bar"}]}}}]}}`),
 			expectedControllers: PodControllers,
 		},
+		{
+			name:                "rule-with-mutate-cel-json-patch",
+			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"add-label","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"cel":{"mutations":[{"patchType":"JSONPatch","jsonPatch":{"expression":"[{\"op\": \"add\", \"path\": \"/metadata/labels/env\", \"value\": \"prod\"}]"}}]}}}]}}`),
+			expectedControllers: sets.New("none"),
+		},
+		{
+			name:                "rule-with-mutate-cel-apply-configuration",
+			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"add-label","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"cel":{"mutations":[{"patchType":"ApplyConfiguration","applyConfiguration":{"expression":"{\"metadata\": {\"labels\": {\"env\": \"prod\"}}}"}}]}}}]}}`),
+			expectedControllers: PodControllers,
+		},
 		{
 			name:                "rule-with-match-mixed-kinds-pod-podcontrollers",
 			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"set-service-labels-env"},"spec":{"background":false,"rules":[{"name":"set-service-label","match":{"resources":{"kinds":["Pod","Deployment"]}},"preconditions":{"any":[{"key":"{{request.operation}}","operator":"Equals","value":"CREATE"}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"+(service)":"{{request.object.spec.template.metadata.labels.app}}"}}}}}]}}`),
@@ -642,3 +657,61 @@ func Test_ValidateWithAssertion(t *testing.T) {
 	rules := computeRules(policies[0], "")
 	assert.Equal(t, 3, len(rules))
 }
+
+func Test_MutateWithCELExpressions(t *testing.T) {
+	policy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		  "name": "add-team-label"
+		},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "team-label",
+			  "match": {
+				"any": [
+				  {
+					"resources": {
+					  "kinds": [
+						"Pod"
+					  ]
+					}
+				  }
+				]
+			  },
+			  "mutate": {
+				"cel": {
+				  "mutations": [
+					{
+					  "patchType": "ApplyConfiguration",
+					  "applyConfiguration": {
+						"expression": "{\"metadata\": {\"labels\": {\"team\": object.metadata.labels.app}}}"
+					  }
+					}
+				  ]
+				}
+			  }
+			}
+		  ]
+		}
+	  }
+`)
+	policies, _, _, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(policies))
+
+	rules := computeRules(policies[0], "")
+	assert.Equal(t, 3, len(rules))
+	assert.Equal(t, rules[1].Name, "autogen-team-label")
+	assert.Equal(t,
+		rules[1].Mutation.CEL.Mutations[0].ApplyConfiguration.Expression,
+		`{"spec": {"template": ({"metadata": {"labels": {"team": object.spec.template.metadata.labels.app}}})}}`,
+	)
+	assert.Equal(t, rules[2].Name, "autogen-cronjob-team-label")
+	assert.Equal(t,
+		rules[2].Mutation.CEL.Mutations[0].ApplyConfiguration.Expression,
+		`{"spec": {"jobTemplate": ({"spec": {"template": ({"metadata": {"labels": {"team": object.spec.jobTemplate.spec.template.metadata.labels.app}}})}})}}`,
+	)
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -103,6 +104,19 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		}
 	}
 	if rule.Mutation != nil {
+		if rule.Mutation.CEL != nil {
+			cel := rule.Mutation.CEL.DeepCopy()
+			// apply configurations are nested under the pod template, JSON patches are not supported by autogen
+			for i, mutation := range cel.Mutations {
+				if mutation.ApplyConfiguration != nil {
+					cel.Mutations[i].ApplyConfiguration.Expression = fmt.Sprintf(`{"spec": {"%s": (%s)}}`, tplKey, mutation.ApplyConfiguration.Expression)
+				}
+			}
+			rule.Mutation = &kyvernov1.Mutation{
+				CEL: cel,
+			}
+			return rule
+		}
 		if target := rule.Mutation.GetPatchStrategicMerge(); target != nil {
 			newMutation := &kyvernov1.Mutation{}
 			newMutation.SetPatchStrategicMerge(
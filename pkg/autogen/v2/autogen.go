@@ -72,6 +72,13 @@ func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers sets.Set[s
 					return false, sets.New("none")
 				}
 			}
+			if rule.Mutation.CEL != nil {
+				for _, mutation := range rule.Mutation.CEL.Mutations {
+					if mutation.PatchType == kyvernov1.PatchTypeJSONPatch {
+						return false, sets.New("none")
+					}
+				}
+			}
 		}
 		match := rule.MatchResources
 		if !checkAutogenSupport(&needed, match.ResourceDescription) {
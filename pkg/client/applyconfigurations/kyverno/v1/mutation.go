@@ -30,6 +30,7 @@ type MutationApplyConfiguration struct {
 	RawPatchStrategicMerge       *apiextensionsv1.JSON                  `json:"patchStrategicMerge,omitempty"`
 	PatchesJSON6902              *string                                `json:"patchesJson6902,omitempty"`
 	ForEachMutation              []ForEachMutationApplyConfiguration    `json:"foreach,omitempty"`
+	CEL                          *MutationCELApplyConfiguration         `json:"cel,omitempty"`
 }
 
 // MutationApplyConfiguration constructs an declarative configuration of the Mutation type for use with
@@ -87,3 +88,11 @@ func (b *MutationApplyConfiguration) WithForEachMutation(values ...*ForEachMutat
 	}
 	return b
 }
+
+// WithCEL sets the CEL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CEL field is set to the value of the last call.
+func (b *MutationApplyConfiguration) WithCEL(value *MutationCELApplyConfiguration) *MutationApplyConfiguration {
+	b.CEL = value
+	return b
+}
@@ -30,6 +30,7 @@ type PolicyStatusApplyConfiguration struct {
 	Autogen                   *AutogenStatusApplyConfiguration                   `json:"autogen,omitempty"`
 	RuleCount                 *RuleCountStatusApplyConfiguration                 `json:"rulecount,omitempty"`
 	ValidatingAdmissionPolicy *ValidatingAdmissionPolicyStatusApplyConfiguration `json:"validatingadmissionpolicy,omitempty"`
+	Params                    *PolicyParamsApplyConfiguration                    `json:"params,omitempty"`
 }
 
 // PolicyStatusApplyConfiguration constructs an declarative configuration of the PolicyStatus type for use with
@@ -79,3 +80,11 @@ func (b *PolicyStatusApplyConfiguration) WithValidatingAdmissionPolicy(value *Va
 	b.ValidatingAdmissionPolicy = value
 	return b
 }
+
+// WithParams sets the Params field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Params field is set to the value of the last call.
+func (b *PolicyStatusApplyConfiguration) WithParams(value *PolicyParamsApplyConfiguration) *PolicyStatusApplyConfiguration {
+	b.Params = value
+	return b
+}
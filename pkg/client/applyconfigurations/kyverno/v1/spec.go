@@ -40,6 +40,7 @@ type SpecApplyConfiguration struct {
 	GenerateExisting                 *bool                                               `json:"generateExisting,omitempty"`
 	UseServerSideApply               *bool                                               `json:"useServerSideApply,omitempty"`
 	WebhookConfiguration             *WebhookConfigurationApplyConfiguration             `json:"webhookConfiguration,omitempty"`
+	Params                           *PolicyParamsApplyConfiguration                     `json:"params,omitempty"`
 }
 
 // SpecApplyConfiguration constructs an declarative configuration of the Spec type for use with
@@ -177,3 +178,11 @@ func (b *SpecApplyConfiguration) WithWebhookConfiguration(value *WebhookConfigur
 	b.WebhookConfiguration = value
 	return b
 }
+
+// WithParams sets the Params field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Params field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithParams(value *PolicyParamsApplyConfiguration) *SpecApplyConfiguration {
+	b.Params = value
+	return b
+}
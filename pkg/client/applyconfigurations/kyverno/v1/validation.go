@@ -36,8 +36,10 @@ type ValidationApplyConfiguration struct {
 	RawPattern              *apiextensionsv1.JSON                               `json:"pattern,omitempty"`
 	RawAnyPattern           *apiextensionsv1.JSON                               `json:"anyPattern,omitempty"`
 	Deny                    *DenyApplyConfiguration                             `json:"deny,omitempty"`
+	Collection              *CollectionApplyConfiguration                       `json:"collection,omitempty"`
 	PodSecurity             *PodSecurityApplyConfiguration                      `json:"podSecurity,omitempty"`
 	CEL                     *CELApplyConfiguration                              `json:"cel,omitempty"`
+	JSONSchema              *JSONSchemaApplyConfiguration                       `json:"jsonSchema,omitempty"`
 	Assert                  *v1alpha1.Any                                       `json:"assert,omitempty"`
 }
 
@@ -129,6 +131,14 @@ func (b *ValidationApplyConfiguration) WithDeny(value *DenyApplyConfiguration) *
 	return b
 }
 
+// WithCollection sets the Collection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Collection field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithCollection(value *CollectionApplyConfiguration) *ValidationApplyConfiguration {
+	b.Collection = value
+	return b
+}
+
 // WithPodSecurity sets the PodSecurity field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PodSecurity field is set to the value of the last call.
@@ -145,6 +155,14 @@ func (b *ValidationApplyConfiguration) WithCEL(value *CELApplyConfiguration) *Va
 	return b
 }
 
+// WithJSONSchema sets the JSONSchema field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JSONSchema field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithJSONSchema(value *JSONSchemaApplyConfiguration) *ValidationApplyConfiguration {
+	b.JSONSchema = value
+	return b
+}
+
 // WithAssert sets the Assert field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Assert field is set to the value of the last call.
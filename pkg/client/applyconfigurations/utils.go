@@ -45,6 +45,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.AnyAllConditionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("APICall"):
 		return &kyvernov1.APICallApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ApplyConfiguration"):
+		return &kyvernov1.ApplyConfigurationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Attestation"):
 		return &kyvernov1.AttestationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Attestor"):
@@ -55,6 +57,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.AutogenStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CEL"):
 		return &kyvernov1.CELApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CELMutation"):
+		return &kyvernov1.CELMutationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CertificateAttestor"):
 		return &kyvernov1.CertificateAttestorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CloneFrom"):
@@ -63,6 +67,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.CloneListApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterPolicy"):
 		return &kyvernov1.ClusterPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Collection"):
+		return &kyvernov1.CollectionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Condition"):
 		return &kyvernov1.ConditionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ConfigMapReference"):
@@ -99,6 +105,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ImageRegistryCredentialsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageVerification"):
 		return &kyvernov1.ImageVerificationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("JSONPatch"):
+		return &kyvernov1.JSONPatchApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("JSONSchema"):
+		return &kyvernov1.JSONSchemaApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("JSONSchemaConfigMapReference"):
+		return &kyvernov1.JSONSchemaConfigMapReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("JSONSchemaOCIReference"):
+		return &kyvernov1.JSONSchemaOCIReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KeylessAttestor"):
 		return &kyvernov1.KeylessAttestorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Manifests"):
@@ -107,6 +121,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.MatchResourcesApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Mutation"):
 		return &kyvernov1.MutationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MutationCEL"):
+		return &kyvernov1.MutationCELApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectFieldBinding"):
 		return &kyvernov1.ObjectFieldBindingApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurity"):
@@ -115,6 +131,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.PodSecurityStandardApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Policy"):
 		return &kyvernov1.PolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PolicyParams"):
+		return &kyvernov1.PolicyParamsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PolicyStatus"):
 		return &kyvernov1.PolicyStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Rekor"):
@@ -217,6 +235,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2alpha1.GlobalContextEntryStatusApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KubernetesResource"):
 		return &kyvernov2alpha1.KubernetesResourceApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicyBundle"):
+		return &kyvernov2alpha1.PolicyBundleApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicyBundleResource"):
+		return &kyvernov2alpha1.PolicyBundleResourceApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicyBundleSpec"):
+		return &kyvernov2alpha1.PolicyBundleSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicyBundleStatus"):
+		return &kyvernov2alpha1.PolicyBundleStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicyBundleVerification"):
+		return &kyvernov2alpha1.PolicyBundleVerificationApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithKind("AnyAllConditions"):
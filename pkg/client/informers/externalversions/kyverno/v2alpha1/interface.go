@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// GlobalContextEntries returns a GlobalContextEntryInformer.
 	GlobalContextEntries() GlobalContextEntryInformer
+	// PolicyBundles returns a PolicyBundleInformer.
+	PolicyBundles() PolicyBundleInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) GlobalContextEntries() GlobalContextEntryInformer {
 	return &globalContextEntryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// PolicyBundles returns a PolicyBundleInformer.
+func (v *version) PolicyBundles() PolicyBundleInformer {
+	return &policyBundleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,364 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// syncTimeout is the maximum time to wait for an informer cache to sync
+const syncTimeout = 30 * time.Second
+
+// EventHandler is called when an object watched by the store changes
+type EventHandler func(schema.GroupVersionResource)
+
+// Store serves the objects of collection rules and policy parameters from informer caches.
+// Informers are started the first time a kind is requested and run until the store context is done,
+// watching a kind requires list and watch permissions on it in all namespaces.
+// Policy parameters are served by informers watching only the bound object.
+type Store interface {
+	loaders.CollectionStore
+	loaders.ParamsStore
+	// List returns the objects of the given kinds, sorted by api version, kind, namespace and name
+	List(ctx context.Context, kinds ...string) ([]unstructured.Unstructured, error)
+	// Resources returns the resources served for the given kinds
+	Resources(kinds ...string) ([]schema.GroupVersionResource, error)
+	// Revision returns the revision of the objects of the given kinds, it increases every time one of them changes
+	Revision(kinds ...string) (int64, error)
+	AddEventHandler(EventHandler)
+}
+
+// collectionData is the decoded list of objects of a set of kinds at a given revision
+type collectionData struct {
+	revision int64
+	items    []any
+}
+
+// paramsKey identifies the object bound to policies as parameters
+type paramsKey struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+type store struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	client    dynamic.Interface
+	discovery dclient.IDiscovery
+	lock      sync.Mutex
+	informers map[schema.GroupVersionResource]informers.GenericInformer
+	params    map[paramsKey]informers.GenericInformer
+	revisions map[schema.GroupVersionResource]int64
+	data      map[string]collectionData
+	handlers  []EventHandler
+}
+
+func NewStore(ctx context.Context, logger logr.Logger, client dynamic.Interface, discovery dclient.IDiscovery) Store {
+	return &store{
+		ctx:       ctx,
+		logger:    logger,
+		client:    client,
+		discovery: discovery,
+		informers: map[schema.GroupVersionResource]informers.GenericInformer{},
+		params:    map[paramsKey]informers.GenericInformer{},
+		revisions: map[schema.GroupVersionResource]int64{},
+		data:      map[string]collectionData{},
+	}
+}
+
+func (s *store) AddEventHandler(handler EventHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+func (s *store) Load(ctx context.Context, kinds ...string) ([]any, error) {
+	gvrs, err := s.Resources(kinds...)
+	if err != nil {
+		return nil, err
+	}
+	// the revision is read before listing, a change happening while listing is picked up by the next call
+	revision := s.revision(gvrs...)
+	key := strings.Join(kinds, ",")
+	s.lock.Lock()
+	cached, ok := s.data[key]
+	s.lock.Unlock()
+	if ok && cached.revision == revision {
+		return cached.items, nil
+	}
+	objs, err := s.List(ctx, kinds...)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]any, 0, len(objs))
+	for _, obj := range objs {
+		item := maps.Clone(obj.Object)
+		if metadata, ok := item["metadata"].(map[string]any); ok {
+			metadata = maps.Clone(metadata)
+			delete(metadata, "managedFields")
+			item["metadata"] = metadata
+		}
+		items = append(items, item)
+	}
+	// objects are round tripped through JSON once per revision so that their values have the types
+	// of JSON decoded context entries, they are then shared by all the rules evaluating the collection
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal collection objects: %w", err)
+	}
+	decoded := []any{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal collection objects: %w", err)
+	}
+	s.lock.Lock()
+	s.data[key] = collectionData{revision: revision, items: decoded}
+	s.lock.Unlock()
+	return decoded, nil
+}
+
+func (s *store) Resources(kinds ...string) ([]schema.GroupVersionResource, error) {
+	var gvrs []schema.GroupVersionResource
+	for _, kind := range kinds {
+		resolved, err := s.resolve(kind)
+		if err != nil {
+			return nil, err
+		}
+		gvrs = append(gvrs, resolved...)
+	}
+	return gvrs, nil
+}
+
+func (s *store) Revision(kinds ...string) (int64, error) {
+	gvrs, err := s.Resources(kinds...)
+	if err != nil {
+		return 0, err
+	}
+	return s.revision(gvrs...), nil
+}
+
+func (s *store) revision(gvrs ...schema.GroupVersionResource) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var revision int64
+	for _, gvr := range gvrs {
+		revision += s.revisions[gvr]
+	}
+	return revision
+}
+
+func (s *store) List(ctx context.Context, kinds ...string) ([]unstructured.Unstructured, error) {
+	seen := map[types.UID]struct{}{}
+	var objs []unstructured.Unstructured
+	for _, kind := range kinds {
+		gvrs, err := s.resolve(kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, gvr := range gvrs {
+			informer, err := s.informer(ctx, gvr)
+			if err != nil {
+				return nil, err
+			}
+			items, err := informer.Lister().List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				obj, ok := item.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				// the same objects can be served by multiple versions
+				if _, ok := seen[obj.GetUID()]; ok {
+					continue
+				}
+				seen[obj.GetUID()] = struct{}{}
+				objs = append(objs, *obj)
+			}
+		}
+	}
+	slices.SortFunc(objs, func(a, b unstructured.Unstructured) int {
+		return strings.Compare(objectKey(a), objectKey(b))
+	})
+	return objs, nil
+}
+
+func (s *store) Get(ctx context.Context, binding kyvernov1.PolicyParams) (*unstructured.Unstructured, error) {
+	key, err := s.paramsKey(binding)
+	if err != nil {
+		return nil, err
+	}
+	informer, err := waitForSync(ctx, key.gvr, s.getOrStartParams(key))
+	if err != nil {
+		return nil, err
+	}
+	var obj runtime.Object
+	if key.namespace != "" {
+		obj, err = informer.Lister().ByNamespace(key.namespace).Get(key.name)
+	} else {
+		obj, err = informer.Lister().Get(key.name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	return u, nil
+}
+
+func (s *store) Warm(binding kyvernov1.PolicyParams) error {
+	key, err := s.paramsKey(binding)
+	if err != nil {
+		return err
+	}
+	s.getOrStartParams(key)
+	return nil
+}
+
+func (s *store) paramsKey(binding kyvernov1.PolicyParams) (paramsKey, error) {
+	gv, err := schema.ParseGroupVersion(binding.APIVersion)
+	if err != nil {
+		return paramsKey{}, err
+	}
+	// an empty group matches all groups in discovery, the core group has to be checked explicitly
+	gvrs, err := s.find(gv.Group, gv.Version, binding.Kind, func(gvr schema.GroupVersionResource) bool {
+		return gvr.Group == gv.Group
+	})
+	if err != nil {
+		return paramsKey{}, err
+	}
+	return paramsKey{gvr: gvrs[0], namespace: binding.Namespace, name: binding.Name}, nil
+}
+
+func (s *store) resolve(kind string) ([]schema.GroupVersionResource, error) {
+	group, version, kind, _ := kubeutils.ParseKindSelector(kind)
+	return s.find(group, version, kind, nil)
+}
+
+func (s *store) find(group, version, kind string, filter func(schema.GroupVersionResource) bool) ([]schema.GroupVersionResource, error) {
+	apis, err := s.discovery.FindResources(group, version, kind, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resources for kind %s: %w", kind, err)
+	}
+	var gvrs []schema.GroupVersionResource
+	for api, resource := range apis {
+		if api.SubResource != "" {
+			continue
+		}
+		if filter != nil && !filter(api.GroupVersionResource()) {
+			continue
+		}
+		if !slices.Contains(resource.Verbs, "list") || !slices.Contains(resource.Verbs, "watch") {
+			s.logger.Info("list/watch not supported for kind", "kind", kind, "gvr", api.GroupVersionResource())
+			continue
+		}
+		gvrs = append(gvrs, api.GroupVersionResource())
+	}
+	if len(gvrs) == 0 {
+		return nil, fmt.Errorf("no resource supporting list/watch found for kind %s", kind)
+	}
+	slices.SortFunc(gvrs, func(a, b schema.GroupVersionResource) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return gvrs, nil
+}
+
+func (s *store) informer(ctx context.Context, gvr schema.GroupVersionResource) (informers.GenericInformer, error) {
+	return waitForSync(ctx, gvr, s.getOrStart(gvr))
+}
+
+func waitForSync(ctx context.Context, gvr schema.GroupVersionResource, informer informers.GenericInformer) (informers.GenericInformer, error) {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+		return nil, fmt.Errorf("failed to sync cache for %s", gvr)
+	}
+	return informer, nil
+}
+
+func (s *store) getOrStart(gvr schema.GroupVersionResource) informers.GenericInformer {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if informer, ok := s.informers[gvr]; ok {
+		return informer
+	}
+	logger := s.logger.WithValues("gvr", gvr)
+	informer := dynamicinformer.NewFilteredDynamicInformer(s.client, gvr, metav1.NamespaceAll, 0, cache.Indexers{}, nil)
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ any, isInInitialList bool) {
+			if !isInInitialList {
+				s.notify(gvr)
+			}
+		},
+		UpdateFunc: func(old, obj any) {
+			if old.(metav1.Object).GetResourceVersion() != obj.(metav1.Object).GetResourceVersion() {
+				s.notify(gvr)
+			}
+		},
+		DeleteFunc: func(_ any) {
+			s.notify(gvr)
+		},
+	}); err != nil {
+		logger.Error(err, "failed to register event handler")
+	}
+	logger.Info("starting informer")
+	go informer.Informer().Run(s.ctx.Done())
+	s.informers[gvr] = informer
+	return informer
+}
+
+// getOrStartParams returns the informer watching a parameters object, starting it if needed.
+// Parameters informers don't notify the event handlers, changes are picked up when policies are applied.
+func (s *store) getOrStartParams(key paramsKey) informers.GenericInformer {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if informer, ok := s.params[key]; ok {
+		return informer
+	}
+	informer := dynamicinformer.NewFilteredDynamicInformer(s.client, key.gvr, key.namespace, 0, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", key.name).String()
+	})
+	s.logger.Info("starting parameters informer", "gvr", key.gvr, "namespace", key.namespace, "name", key.name)
+	go informer.Informer().Run(s.ctx.Done())
+	s.params[key] = informer
+	return informer
+}
+
+func (s *store) notify(gvr schema.GroupVersionResource) {
+	s.lock.Lock()
+	s.revisions[gvr]++
+	handlers := slices.Clone(s.handlers)
+	s.lock.Unlock()
+	for _, handler := range handlers {
+		handler(gvr)
+	}
+}
+
+func objectKey(obj unstructured.Unstructured) string {
+	return strings.Join([]string{obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+}
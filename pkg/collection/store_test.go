@@ -0,0 +1,176 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var servicesGVR = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+
+type fakeDiscovery struct {
+	dclient.IDiscovery
+}
+
+func (fakeDiscovery) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	return map[dclient.TopLevelApiDescription]metav1.APIResource{
+		{GroupVersion: servicesGVR.GroupVersion(), Kind: "Service", Resource: servicesGVR.Resource}:                        {Verbs: []string{"get", "list", "watch"}},
+		{GroupVersion: servicesGVR.GroupVersion(), Kind: "Service", Resource: servicesGVR.Resource, SubResource: "status"}: {Verbs: []string{"get"}},
+	}, nil
+}
+
+func newService(namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Service")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(types.UID(namespace + "-" + name))
+	return obj
+}
+
+func TestStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{servicesGVR: "ServiceList"},
+		newService("b", "svc"),
+		newService("a", "svc"),
+	)
+	s := NewStore(ctx, logr.Discard(), client, fakeDiscovery{})
+	events := make(chan schema.GroupVersionResource, 10)
+	s.AddEventHandler(func(gvr schema.GroupVersionResource) {
+		events <- gvr
+	})
+
+	objs, err := s.List(ctx, "Service")
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	require.Equal(t, "a", objs[0].GetNamespace())
+	require.Equal(t, "b", objs[1].GetNamespace())
+	// objects from the initial list don't trigger events
+	require.Empty(t, events)
+
+	_, err = client.Resource(servicesGVR).Namespace("c").Create(ctx, newService("c", "svc"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	select {
+	case gvr := <-events:
+		require.Equal(t, servicesGVR, gvr)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for collection event")
+	}
+	require.Eventually(t, func() bool {
+		objs, err := s.List(ctx, "v1/Service")
+		return err == nil && len(objs) == 3
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestStoreGet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{servicesGVR: "ServiceList"},
+		newService("a", "params"),
+	)
+	s := NewStore(ctx, logr.Discard(), client, fakeDiscovery{})
+	binding := kyvernov1.PolicyParams{Kind: "Service", Name: "params", Namespace: "a"}.Binding("")
+
+	for i := 0; i < 3; i++ {
+		obj, err := s.Get(ctx, binding)
+		require.NoError(t, err)
+		require.Equal(t, "params", obj.GetName())
+	}
+	// objects are served from the informer cache, they are listed once and never fetched
+	var lists int
+	for _, action := range client.Actions() {
+		require.NotEqual(t, "get", action.GetVerb())
+		if action.Matches("list", servicesGVR.Resource) {
+			lists++
+		}
+	}
+	require.Equal(t, 1, lists)
+
+	_, err := s.Get(ctx, kyvernov1.PolicyParams{Kind: "Service", Name: "missing", Namespace: "a"}.Binding(""))
+	require.Error(t, err)
+	// the core group is not matched by resources of other groups
+	_, err = s.Get(ctx, kyvernov1.PolicyParams{APIVersion: "example.io/v1", Kind: "Service", Name: "params", Namespace: "a"})
+	require.Error(t, err)
+}
+
+func TestStoreLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{servicesGVR: "ServiceList"},
+		newService("a", "svc"),
+	)
+	s := NewStore(ctx, logr.Discard(), client, fakeDiscovery{})
+
+	data, err := s.Load(ctx, "Service")
+	require.NoError(t, err)
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"apiVersion":"v1","kind":"Service","metadata":{"namespace":"a","name":"svc","uid":"a-svc"}}]`, string(encoded))
+	revision, err := s.Revision("Service")
+	require.NoError(t, err)
+	require.Zero(t, revision)
+	// the objects are decoded once per revision
+	again, err := s.Load(ctx, "Service")
+	require.NoError(t, err)
+	require.Same(t, &data[0], &again[0])
+
+	_, err = client.Resource(servicesGVR).Namespace("b").Create(ctx, newService("b", "svc"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		revision, err := s.Revision("Service")
+		return err == nil && revision == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	data, err = s.Load(ctx, "Service")
+	require.NoError(t, err)
+	require.NotSame(t, &data[0], &again[0])
+	require.Len(t, data, 2)
+	require.Equal(t, "b", data[1].(map[string]any)["metadata"].(map[string]any)["namespace"])
+}
+
+func TestStoreWarm(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{servicesGVR: "ServiceList"},
+		newService("a", "params"),
+		newService("b", "params"),
+	)
+	s := NewStore(ctx, logr.Discard(), client, fakeDiscovery{})
+	binding := kyvernov1.PolicyParams{Kind: "Service", Name: "params", Namespace: "a"}.Binding("")
+
+	require.NoError(t, s.Warm(binding))
+	// the informer only watches the bound object
+	require.Eventually(t, func() bool {
+		for _, action := range client.Actions() {
+			if list, ok := action.(clienttesting.ListAction); ok && list.Matches("list", servicesGVR.Resource) {
+				return list.GetNamespace() == "a" && list.GetListRestrictions().Fields.String() == "metadata.name=params"
+			}
+		}
+		return false
+	}, 10*time.Second, 10*time.Millisecond)
+	obj, err := s.Get(ctx, binding)
+	require.NoError(t, err)
+	require.Equal(t, "a", obj.GetNamespace())
+}
@@ -97,6 +97,7 @@ const (
 	webhookLabels                 = "webhookLabels"
 	matchConditions               = "matchConditions"
 	updateRequestThreshold        = "updateRequestThreshold"
+	jmespathFunctions             = "jmespathFunctions"
 )
 
 const UpdateRequestThreshold = 1000
@@ -188,6 +189,8 @@ type Configuration interface {
 	OnChanged(func())
 	// GetUpdateRequestThreshold gets the threshold limit for the total number of updaterequests
 	GetUpdateRequestThreshold() int64
+	// GetJMESPathFunctions returns the user-defined JMESPath functions
+	GetJMESPathFunctions() []JMESPathFunction
 }
 
 // configuration stores the configuration
@@ -206,6 +209,7 @@ type configuration struct {
 	mux                           sync.RWMutex
 	callbacks                     []func()
 	updateRequestThreshold        int64
+	jmespathFunctions             []JMESPathFunction
 }
 
 type match struct {
@@ -340,6 +344,12 @@ func (cd *configuration) GetUpdateRequestThreshold() int64 {
 	return cd.updateRequestThreshold
 }
 
+func (cd *configuration) GetJMESPathFunctions() []JMESPathFunction {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.jmespathFunctions
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
 	if cm != nil {
 		cd.load(cm)
@@ -368,6 +378,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
 	cd.matchConditions = nil
+	cd.jmespathFunctions = nil
 	// load filters
 	cd.filters = parseKinds(data[resourceFilters])
 	cd.updateRequestThreshold = UpdateRequestThreshold
@@ -514,6 +525,20 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			logger.Info("enableDefaultRegistryMutation configured")
 		}
 	}
+	// load jmespath functions
+	functions, ok := data[jmespathFunctions]
+	if !ok {
+		logger.Info("jmespathFunctions not set")
+	} else {
+		logger := logger.WithValues("jmespathFunctions", functions)
+		functions, err := parseJMESPathFunctions(functions)
+		if err != nil {
+			logger.Error(err, "failed to parse jmespath functions")
+		} else {
+			cd.jmespathFunctions = functions
+			logger.Info("jmespathFunctions configured")
+		}
+	}
 }
 
 func (cd *configuration) unload() {
@@ -529,6 +554,7 @@ func (cd *configuration) unload() {
 	cd.webhook = WebhookConfig{}
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
+	cd.jmespathFunctions = nil
 	logger.Info("configuration unloaded")
 }
 
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	gojmespath "github.com/kyverno/go-jmespath"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,6 +72,114 @@ func parseMatchConditions(in string) ([]admissionregistrationv1.MatchCondition,
 	return out, nil
 }
 
+// JMESPathFunction declares a user-defined JMESPath function.
+// The function arguments are available by name in the expression, exactly one of JMESPath or CEL must be set.
+type JMESPathFunction struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Arguments   []string `json:"arguments,omitempty"`
+	JMESPath    string   `json:"jmesPath,omitempty"`
+	CEL         string   `json:"cel,omitempty"`
+}
+
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// parseJMESPathFunctions parses and validates user-defined functions.
+// Functions are returned in dependency order, a function comes after the functions it calls,
+// recursive definitions are rejected.
+func parseJMESPathFunctions(in string) ([]JMESPathFunction, error) {
+	var out []JMESPathFunction
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	names := map[string]struct{}{}
+	for _, function := range out {
+		if !identifierRegex.MatchString(function.Name) {
+			return nil, fmt.Errorf("invalid function name %q", function.Name)
+		}
+		if _, ok := names[function.Name]; ok {
+			return nil, fmt.Errorf("function %q is declared more than once", function.Name)
+		}
+		names[function.Name] = struct{}{}
+		arguments := map[string]struct{}{}
+		for _, argument := range function.Arguments {
+			if !identifierRegex.MatchString(argument) {
+				return nil, fmt.Errorf("invalid argument name %q in function %q", argument, function.Name)
+			}
+			if _, ok := arguments[argument]; ok {
+				return nil, fmt.Errorf("argument %q is declared more than once in function %q", argument, function.Name)
+			}
+			arguments[argument] = struct{}{}
+		}
+		if (function.JMESPath == "") == (function.CEL == "") {
+			return nil, fmt.Errorf("function %q must declare exactly one of jmesPath or cel", function.Name)
+		}
+	}
+	return sortJMESPathFunctions(out)
+}
+
+// sortJMESPathFunctions orders functions so that each function comes after the user-defined functions it calls,
+// the declaration order is kept otherwise. It returns an error if a function calls itself, directly or not.
+func sortJMESPathFunctions(functions []JMESPathFunction) ([]JMESPathFunction, error) {
+	byName := make(map[string]JMESPathFunction, len(functions))
+	for _, function := range functions {
+		byName[function.Name] = function
+	}
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	var sorted []JMESPathFunction
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		path = append(path, name)
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("function %q is recursive (%s)", name, strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		function := byName[name]
+		if function.JMESPath != "" {
+			// expressions that don't parse are reported when the function is registered
+			if ast, err := gojmespath.NewParser().Parse(function.JMESPath); err == nil {
+				for _, call := range functionCalls(ast) {
+					if _, ok := byName[call]; ok {
+						if err := visit(call, path); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+		state[name] = visited
+		sorted = append(sorted, function)
+		return nil
+	}
+	for _, function := range functions {
+		if err := visit(function.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// functionCalls returns the names of the functions called in a JMESPath expression
+func functionCalls(node gojmespath.ASTNode) []string {
+	var calls []string
+	if node.NodeType == gojmespath.ASTFunctionExpression {
+		if name, ok := node.Value.(string); ok {
+			calls = append(calls, name)
+		}
+	}
+	for _, child := range node.Children {
+		calls = append(calls, functionCalls(child)...)
+	}
+	return calls
+}
+
 type namespacesConfig struct {
 	IncludeNamespaces []string `json:"include,omitempty"`
 	ExcludeNamespaces []string `json:"exclude,omitempty"`
@@ -406,3 +406,78 @@ func Test_parseMetricExposureConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseJMESPathFunctions(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []JMESPathFunction
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name: "null",
+		in:   "null",
+	}, {
+		name: "valid",
+		in:   `[{"name": "normalize_team", "arguments": ["team"], "jmesPath": "to_lower(team)"}, {"name": "double", "arguments": ["x"], "cel": "x * 2.0"}]`,
+		want: []JMESPathFunction{
+			{Name: "normalize_team", Arguments: []string{"team"}, JMESPath: "to_lower(team)"},
+			{Name: "double", Arguments: []string{"x"}, CEL: "x * 2.0"},
+		},
+	}, {
+		name:    "invalid name",
+		in:      `[{"name": "normalize-team", "jmesPath": "@"}]`,
+		wantErr: true,
+	}, {
+		name:    "duplicated name",
+		in:      `[{"name": "f", "jmesPath": "@"}, {"name": "f", "jmesPath": "@"}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid argument",
+		in:      `[{"name": "f", "arguments": ["a.b"], "jmesPath": "@"}]`,
+		wantErr: true,
+	}, {
+		name:    "duplicated argument",
+		in:      `[{"name": "f", "arguments": ["a", "a"], "jmesPath": "a"}]`,
+		wantErr: true,
+	}, {
+		name:    "no expression",
+		in:      `[{"name": "f"}]`,
+		wantErr: true,
+	}, {
+		name:    "both expressions",
+		in:      `[{"name": "f", "jmesPath": "@", "cel": "true"}]`,
+		wantErr: true,
+	}, {
+		name: "dependency order",
+		in:   `[{"name": "outer", "arguments": ["x"], "jmesPath": "middle(x)"}, {"name": "middle", "arguments": ["x"], "jmesPath": "map(&inner(@), x)"}, {"name": "inner", "arguments": ["x"], "jmesPath": "to_upper(x)"}]`,
+		want: []JMESPathFunction{
+			{Name: "inner", Arguments: []string{"x"}, JMESPath: "to_upper(x)"},
+			{Name: "middle", Arguments: []string{"x"}, JMESPath: "map(&inner(@), x)"},
+			{Name: "outer", Arguments: []string{"x"}, JMESPath: "middle(x)"},
+		},
+	}, {
+		name:    "self recursion",
+		in:      `[{"name": "loop", "arguments": ["x"], "jmesPath": "loop(x)"}]`,
+		wantErr: true,
+	}, {
+		name:    "mutual recursion",
+		in:      `[{"name": "ping", "arguments": ["x"], "jmesPath": "pong(x)"}, {"name": "pong", "arguments": ["x"], "jmesPath": "[ping(x)]"}]`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJMESPathFunctions(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJMESPathFunctions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJMESPathFunctions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,9 +22,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/toggle"
-	"github.com/kyverno/kyverno/pkg/utils/conditions"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
-	"github.com/kyverno/kyverno/pkg/utils/match"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -236,71 +234,13 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 					}
 					nsLabels = ns.GetLabels()
 				}
-				// match namespaces
-				if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
-					debug.Info("resource namespace didn't match policy namespace", "result", err)
-				}
-				// match resource with match/exclude clause
-				matched := match.CheckMatchesResources(
-					resource,
-					spec.MatchResources,
-					nsLabels,
-					// TODO(eddycharly): we don't have user info here, we should check that
-					// we don't have user conditions in the policy rule
-					kyvernov2.RequestInfo{},
-					resource.GroupVersionKind(),
-					"",
-				)
-				if matched != nil {
-					debug.Info("resource/match didn't match", "result", matched)
+				matched, err := Matches(debug, enginectx, c.configuration, policy, resource, nsLabels)
+				if err != nil {
+					errs = append(errs, err)
 					continue
 				}
-				if spec.ExcludeResources != nil {
-					excluded := match.CheckMatchesResources(
-						resource,
-						*spec.ExcludeResources,
-						nsLabels,
-						// TODO(eddycharly): we don't have user info here, we should check that
-						// we don't have user conditions in the policy rule
-						kyvernov2.RequestInfo{},
-						resource.GroupVersionKind(),
-						"",
-					)
-					if excluded == nil {
-						debug.Info("resource/exclude matched")
-						continue
-					} else {
-						debug.Info("resource/exclude didn't match", "result", excluded)
-					}
-				}
-				// check conditions
-				if spec.Conditions != nil {
-					enginectx.Reset()
-					if err := enginectx.SetTargetResource(resource.Object); err != nil {
-						debug.Error(err, "failed to add resource in context")
-						errs = append(errs, err)
-						continue
-					}
-					if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
-						debug.Error(err, "failed to add namespace in context")
-						errs = append(errs, err)
-						continue
-					}
-					if err := enginectx.AddImageInfos(&resource, c.configuration); err != nil {
-						debug.Error(err, "failed to add image infos in context")
-						errs = append(errs, err)
-						continue
-					}
-					passed, err := conditions.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
-					if err != nil {
-						debug.Error(err, "failed to check condition")
-						errs = append(errs, err)
-						continue
-					}
-					if !passed {
-						debug.Info("conditions did not pass")
-						continue
-					}
+				if !matched {
+					continue
 				}
 				var labels []attribute.KeyValue
 				labels = append(labels, commonLabels...)
@@ -0,0 +1,87 @@
+package cleanup
+
+import (
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/utils/conditions"
+	"github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Matches returns true if the resource is selected for deletion by the cleanup policy.
+// The policy context entries are expected to be loaded in enginectx already.
+func Matches(
+	logger logr.Logger,
+	enginectx enginecontext.Interface,
+	configuration config.Configuration,
+	policy kyvernov2.CleanupPolicyInterface,
+	resource unstructured.Unstructured,
+	nsLabels map[string]string,
+) (bool, error) {
+	spec := policy.GetSpec()
+	// match namespaces
+	if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
+		logger.Info("resource namespace didn't match policy namespace", "result", err)
+	}
+	// match resource with match/exclude clause
+	matched := match.CheckMatchesResources(
+		resource,
+		spec.MatchResources,
+		nsLabels,
+		// TODO(eddycharly): we don't have user info here, we should check that
+		// we don't have user conditions in the policy rule
+		kyvernov2.RequestInfo{},
+		resource.GroupVersionKind(),
+		"",
+	)
+	if matched != nil {
+		logger.Info("resource/match didn't match", "result", matched)
+		return false, nil
+	}
+	if spec.ExcludeResources != nil {
+		excluded := match.CheckMatchesResources(
+			resource,
+			*spec.ExcludeResources,
+			nsLabels,
+			// TODO(eddycharly): we don't have user info here, we should check that
+			// we don't have user conditions in the policy rule
+			kyvernov2.RequestInfo{},
+			resource.GroupVersionKind(),
+			"",
+		)
+		if excluded == nil {
+			logger.Info("resource/exclude matched")
+			return false, nil
+		} else {
+			logger.Info("resource/exclude didn't match", "result", excluded)
+		}
+	}
+	// check conditions
+	if spec.Conditions != nil {
+		enginectx.Reset()
+		if err := enginectx.SetTargetResource(resource.Object); err != nil {
+			logger.Error(err, "failed to add resource in context")
+			return false, err
+		}
+		if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
+			logger.Error(err, "failed to add namespace in context")
+			return false, err
+		}
+		if err := enginectx.AddImageInfos(&resource, configuration); err != nil {
+			logger.Error(err, "failed to add image infos in context")
+			return false, err
+		}
+		passed, err := conditions.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
+		if err != nil {
+			logger.Error(err, "failed to check condition")
+			return false, err
+		}
+		if !passed {
+			logger.Info("conditions did not pass")
+			return false, nil
+		}
+	}
+	return true, nil
+}
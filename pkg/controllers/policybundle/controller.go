@@ -0,0 +1,275 @@
+package policybundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/policybundle"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "policy-bundle-controller"
+	maxRetries     = 10
+	// defaultNamespace is used for namespaced resources stored without namespace in a bundle
+	defaultNamespace = "default"
+)
+
+// fetchFunc verifies and downloads the content of a policy bundle
+type fetchFunc func(context.Context, *kyvernov2alpha1.PolicyBundle) (*policybundle.Bundle, error)
+
+type controller struct {
+	// listers
+	pbLister kyvernov2alpha1listers.PolicyBundleLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// state
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	fetch         fetchFunc
+}
+
+func NewController(
+	pbInformer kyvernov2alpha1informers.PolicyBundleInformer,
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	rclient registryclient.Client,
+) controllers.Controller {
+	return newController(pbInformer, client, kyvernoClient, func(ctx context.Context, bundle *kyvernov2alpha1.PolicyBundle) (*policybundle.Bundle, error) {
+		return policybundle.Fetch(
+			ctx,
+			rclient,
+			cosign.NewVerifier(),
+			bundle.Spec.Image,
+			policybundle.VerificationOptions(bundle.Spec.Verification),
+		)
+	})
+}
+
+func newController(
+	pbInformer kyvernov2alpha1informers.PolicyBundleInformer,
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	fetch fetchFunc,
+) *controller {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[any](),
+		workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+	)
+	c := &controller{
+		pbLister:      pbInformer.Lister(),
+		queue:         queue,
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		fetch:         fetch,
+	}
+	if _, err := controllerutils.AddEventHandlersT(pbInformer.Informer(), c.addBundle, c.updateBundle, nil); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) addBundle(obj *kyvernov2alpha1.PolicyBundle) {
+	c.enqueueBundle(obj)
+}
+
+func (c *controller) updateBundle(old, obj *kyvernov2alpha1.PolicyBundle) {
+	// status updates must not trigger a synchronization
+	if datautils.DeepEqual(old.Spec, obj.Spec) {
+		return
+	}
+	c.enqueueBundle(obj)
+}
+
+func (c *controller) enqueueBundle(bundle *kyvernov2alpha1.PolicyBundle) {
+	key, err := cache.MetaNamespaceKeyFunc(bundle)
+	if err != nil {
+		logger.Error(err, "failed to enqueue policy bundle")
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	bundle, err := c.pbLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// synchronized resources are garbage collected through their owner reference
+			return nil
+		}
+		return err
+	}
+	if errs := bundle.Validate(); len(errs) != 0 {
+		// invalid bundles are not retried until their spec changes
+		return c.updateStatus(ctx, bundle, errs.ToAggregate(), "", bundle.Status.Resources)
+	}
+	// poll the image for changes
+	c.queue.AddAfter(key, bundle.Spec.GetRefreshInterval())
+	fetched, err := c.fetch(ctx, bundle)
+	if err != nil {
+		logger.Error(err, "failed to fetch policy bundle", "image", bundle.Spec.Image)
+		return errors.Join(err, c.updateStatus(ctx, bundle, err, "", bundle.Status.Resources))
+	}
+	resources, err := c.sync(ctx, bundle, fetched)
+	if err != nil {
+		logger.Error(err, "failed to synchronize policy bundle", "image", bundle.Spec.Image, "digest", fetched.Digest)
+		return errors.Join(err, c.updateStatus(ctx, bundle, err, "", resources))
+	}
+	logger.V(2).Info("policy bundle synchronized", "image", bundle.Spec.Image, "digest", fetched.Digest, "resources", len(resources))
+	return c.updateStatus(ctx, bundle, nil, fetched.Digest, resources)
+}
+
+// sync applies the resources of the bundle and prunes the ones that were removed from it.
+// It returns the resources managed by the bundle after synchronization.
+func (c *controller) sync(ctx context.Context, bundle *kyvernov2alpha1.PolicyBundle, fetched *policybundle.Bundle) ([]kyvernov2alpha1.PolicyBundleResource, error) {
+	var errs []error
+	expected := sets.New[kyvernov2alpha1.PolicyBundleResource]()
+	previous := sets.New(bundle.Status.Resources...)
+	var managed []kyvernov2alpha1.PolicyBundleResource
+	for _, resource := range fetched.Resources {
+		resource := resource.DeepCopy()
+		if err := c.apply(ctx, bundle, fetched.Digest, resource); err != nil {
+			errs = append(errs, err)
+			// keep tracking resources that could have been applied by a previous synchronization
+			if ref := toResource(resource); previous.Has(ref) && !expected.Has(ref) {
+				expected.Insert(ref)
+				managed = append(managed, ref)
+			}
+			continue
+		}
+		ref := toResource(resource)
+		if !expected.Has(ref) {
+			expected.Insert(ref)
+			managed = append(managed, ref)
+		}
+	}
+	for _, ref := range bundle.Status.Resources {
+		if expected.Has(ref) {
+			continue
+		}
+		if !bundle.Spec.IsPruneEnabled() {
+			continue
+		}
+		if err := c.prune(ctx, bundle, ref); err != nil {
+			errs = append(errs, err)
+			managed = append(managed, ref)
+		}
+	}
+	return managed, errors.Join(errs...)
+}
+
+func (c *controller) apply(ctx context.Context, bundle *kyvernov2alpha1.PolicyBundle, digest string, resource *unstructured.Unstructured) error {
+	switch resource.GetKind() {
+	case "ClusterPolicy", "GlobalContextEntry":
+		resource.SetNamespace("")
+	default:
+		if resource.GetNamespace() == "" {
+			resource.SetNamespace(defaultNamespace)
+		}
+	}
+	controllerutils.SetLabel(resource, kyverno.LabelPolicyBundle, bundle.GetName())
+	controllerutils.SetAnnotation(resource, kyverno.AnnotationPolicyBundleDigest, digest)
+	controllerutils.SetOwner(resource, kyvernov2alpha1.SchemeGroupVersion.String(), "PolicyBundle", bundle.GetName(), bundle.GetUID())
+	resource.SetResourceVersion("")
+	resource.SetUID("")
+	apiVersion, kind, namespace, name := resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName()
+	existing, err := c.client.GetResource(ctx, apiVersion, kind, namespace, name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get %s %s: %w", kind, resourceKey(namespace, name), err)
+		}
+		if _, err := c.client.CreateResource(ctx, apiVersion, kind, namespace, resource, false); err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", kind, resourceKey(namespace, name), err)
+		}
+		return nil
+	}
+	if !controllerutils.CheckLabel(existing, kyverno.LabelPolicyBundle, bundle.GetName()) {
+		return fmt.Errorf("%s %s already exists and is not managed by policy bundle %s", kind, resourceKey(namespace, name), bundle.GetName())
+	}
+	resource.SetResourceVersion(existing.GetResourceVersion())
+	if _, err := c.client.UpdateResource(ctx, apiVersion, kind, namespace, resource, false); err != nil {
+		return fmt.Errorf("failed to update %s %s: %w", kind, resourceKey(namespace, name), err)
+	}
+	return nil
+}
+
+func (c *controller) prune(ctx context.Context, bundle *kyvernov2alpha1.PolicyBundle, ref kyvernov2alpha1.PolicyBundleResource) error {
+	existing, err := c.client.GetResource(ctx, ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get %s %s: %w", ref.Kind, resourceKey(ref.Namespace, ref.Name), err)
+	}
+	// the resource has been taken over, leave it alone
+	if !controllerutils.CheckLabel(existing, kyverno.LabelPolicyBundle, bundle.GetName()) {
+		return nil
+	}
+	if err := c.client.DeleteResource(ctx, ref.APIVersion, ref.Kind, ref.Namespace, ref.Name, false, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s: %w", ref.Kind, resourceKey(ref.Namespace, ref.Name), err)
+	}
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, bundle *kyvernov2alpha1.PolicyBundle, syncErr error, digest string, resources []kyvernov2alpha1.PolicyBundleResource) error {
+	return controllerutils.UpdateStatus(
+		ctx,
+		bundle,
+		c.kyvernoClient.KyvernoV2alpha1().PolicyBundles(),
+		func(bundle *kyvernov2alpha1.PolicyBundle) error {
+			bundle.Status.Resources = resources
+			if syncErr != nil {
+				bundle.Status.SetReady(false, syncErr.Error())
+				return nil
+			}
+			bundle.Status.SetReady(true, "Policy bundle synchronized")
+			bundle.Status.Digest = digest
+			bundle.Status.UpdateSyncTime()
+			return nil
+		},
+		nil,
+	)
+}
+
+func toResource(resource *unstructured.Unstructured) kyvernov2alpha1.PolicyBundleResource {
+	return kyvernov2alpha1.PolicyBundleResource{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+	}
+}
+
+func resourceKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,184 @@
+package policybundle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/policybundle"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newResource(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	var resource unstructured.Unstructured
+	resource.SetAPIVersion(apiVersion)
+	resource.SetKind(kind)
+	resource.SetNamespace(namespace)
+	resource.SetName(name)
+	return &resource
+}
+
+type fixture struct {
+	controller    *controller
+	client        dclient.Interface
+	kyvernoClient *versionedfake.Clientset
+	informer      kyvernoinformer.SharedInformerFactory
+	bundle        *kyvernov2alpha1.PolicyBundle
+	fetched       *policybundle.Bundle
+	fetchErr      error
+}
+
+func newFixture(t *testing.T) *fixture {
+	bundle := &kyvernov2alpha1.PolicyBundle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "library",
+			UID:  "1234",
+		},
+		Spec: kyvernov2alpha1.PolicyBundleSpec{
+			Image: "registry.example.com/policies:v1",
+			Verification: kyvernov2alpha1.PolicyBundleVerification{
+				Keys: &kyvernov1.StaticKeyAttestor{PublicKeys: "key"},
+			},
+		},
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{})
+	assert.NoError(t, err)
+	// the fake discovery client pluralizes kinds by appending a trailing s
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{
+		{Group: "kyverno.io", Version: "v1", Resource: "clusterpolicys"},
+		{Group: "kyverno.io", Version: "v2", Resource: "policyexceptions"},
+	}))
+	kyvernoClient := versionedfake.NewSimpleClientset(bundle)
+	informer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, 0)
+	f := &fixture{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		informer:      informer,
+	}
+	f.controller = newController(informer.Kyverno().V2alpha1().PolicyBundles(), client, kyvernoClient, func(context.Context, *kyvernov2alpha1.PolicyBundle) (*policybundle.Bundle, error) {
+		return f.fetched, f.fetchErr
+	})
+	f.refresh(t)
+	return f
+}
+
+// refresh loads the latest bundle in the lister
+func (f *fixture) refresh(t *testing.T) {
+	bundle, err := f.kyvernoClient.KyvernoV2alpha1().PolicyBundles().Get(context.TODO(), "library", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, f.informer.Kyverno().V2alpha1().PolicyBundles().Informer().GetIndexer().Add(bundle))
+	f.bundle = bundle
+}
+
+func (f *fixture) reconcile(t *testing.T) error {
+	err := f.controller.reconcile(context.TODO(), logr.Discard(), "library", "", "library")
+	f.refresh(t)
+	return err
+}
+
+func TestReconcile(t *testing.T) {
+	f := newFixture(t)
+	defer f.controller.queue.ShutDown()
+
+	// initial synchronization creates the resources
+	f.fetched = &policybundle.Bundle{
+		Digest: "sha256:1",
+		Resources: []*unstructured.Unstructured{
+			newResource("kyverno.io/v1", "ClusterPolicy", "", "require-labels"),
+			newResource("kyverno.io/v2", "PolicyException", "kube-system", "system-pods"),
+		},
+	}
+	assert.NoError(t, f.reconcile(t))
+	assert.True(t, f.bundle.Status.IsReady())
+	assert.Equal(t, "sha256:1", f.bundle.Status.Digest)
+	assert.Equal(t, []kyvernov2alpha1.PolicyBundleResource{
+		{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy", Name: "require-labels"},
+		{APIVersion: "kyverno.io/v2", Kind: "PolicyException", Namespace: "kube-system", Name: "system-pods"},
+	}, f.bundle.Status.Resources)
+	policy, err := f.client.GetResource(context.TODO(), "kyverno.io/v1", "ClusterPolicy", "", "require-labels")
+	assert.NoError(t, err)
+	assert.Equal(t, "library", policy.GetLabels()[kyverno.LabelPolicyBundle])
+	assert.Equal(t, "sha256:1", policy.GetAnnotations()[kyverno.AnnotationPolicyBundleDigest])
+	assert.Len(t, policy.GetOwnerReferences(), 1)
+	assert.Equal(t, "PolicyBundle", policy.GetOwnerReferences()[0].Kind)
+
+	// resources removed from the bundle are pruned
+	f.fetched = &policybundle.Bundle{
+		Digest: "sha256:2",
+		Resources: []*unstructured.Unstructured{
+			newResource("kyverno.io/v1", "ClusterPolicy", "", "require-labels"),
+		},
+	}
+	assert.NoError(t, f.reconcile(t))
+	assert.True(t, f.bundle.Status.IsReady())
+	assert.Equal(t, "sha256:2", f.bundle.Status.Digest)
+	assert.Len(t, f.bundle.Status.Resources, 1)
+	_, err = f.client.GetResource(context.TODO(), "kyverno.io/v2", "PolicyException", "kube-system", "system-pods")
+	assert.True(t, apierrors.IsNotFound(err))
+	policy, err = f.client.GetResource(context.TODO(), "kyverno.io/v1", "ClusterPolicy", "", "require-labels")
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:2", policy.GetAnnotations()[kyverno.AnnotationPolicyBundleDigest])
+
+	// fetch errors are reported and keep the synchronized resources
+	f.fetchErr = errors.New("signature mismatch")
+	assert.Error(t, f.reconcile(t))
+	assert.False(t, f.bundle.Status.IsReady())
+	assert.Equal(t, "sha256:2", f.bundle.Status.Digest)
+	assert.Len(t, f.bundle.Status.Resources, 1)
+}
+
+func TestReconcileConflict(t *testing.T) {
+	f := newFixture(t)
+	defer f.controller.queue.ShutDown()
+
+	existing := newResource("kyverno.io/v1", "ClusterPolicy", "", "disallow-latest-tag")
+	_, err := f.client.CreateResource(context.TODO(), "kyverno.io/v1", "ClusterPolicy", "", existing, false)
+	assert.NoError(t, err)
+	f.fetched = &policybundle.Bundle{
+		Digest: "sha256:1",
+		Resources: []*unstructured.Unstructured{
+			newResource("kyverno.io/v1", "ClusterPolicy", "", "disallow-latest-tag"),
+			newResource("kyverno.io/v1", "ClusterPolicy", "", "require-labels"),
+		},
+	}
+	err = f.reconcile(t)
+	assert.ErrorContains(t, err, "is not managed by policy bundle library")
+	assert.False(t, f.bundle.Status.IsReady())
+	assert.Equal(t, []kyvernov2alpha1.PolicyBundleResource{
+		{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy", Name: "require-labels"},
+	}, f.bundle.Status.Resources)
+	policy, err := f.client.GetResource(context.TODO(), "kyverno.io/v1", "ClusterPolicy", "", "disallow-latest-tag")
+	assert.NoError(t, err)
+	assert.Empty(t, policy.GetLabels())
+}
+
+func TestReconcileInvalid(t *testing.T) {
+	f := newFixture(t)
+	defer f.controller.queue.ShutDown()
+
+	bundle := f.bundle.DeepCopy()
+	bundle.Spec.Verification = kyvernov2alpha1.PolicyBundleVerification{}
+	bundle.Spec.RefreshInterval = &metav1.Duration{Duration: time.Millisecond}
+	_, err := f.kyvernoClient.KyvernoV2alpha1().PolicyBundles().Update(context.TODO(), bundle, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	f.refresh(t)
+	f.fetchErr = errors.New("must not be called")
+	assert.NoError(t, f.reconcile(t))
+	assert.False(t, f.bundle.Status.IsReady())
+	// invalid bundles are not polled
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, f.controller.queue.Len())
+}
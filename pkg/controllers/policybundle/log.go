@@ -0,0 +1,5 @@
+package policybundle
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.ControllerLogger(ControllerName)
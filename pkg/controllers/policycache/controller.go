@@ -10,11 +10,17 @@ import (
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	pcache "github.com/kyverno/kyverno/pkg/policycache"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -43,9 +49,21 @@ type controller struct {
 
 	// client
 	client dclient.Interface
+
+	// jp is used to pre-compile the JMESPath queries of cached policies
+	jp jmespath.Interface
+
+	// celCache holds the CEL programs compiled by the engine, they are dropped when a policy changes
+	celCache enginecel.Cache
+
+	// schemaCache holds the JSON schemas compiled by the engine, they are dropped when a policy changes
+	schemaCache jsonschema.Cache
+
+	// paramsStore serves the parameters of cached policies, their informers are started when policies are cached
+	paramsStore loaders.ParamsStore
 }
 
-func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer) Controller {
+func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer, jp jmespath.Interface, celCache enginecel.Cache, schemaCache jsonschema.Cache, paramsStore loaders.ParamsStore) Controller {
 	c := controller{
 		cache:      pcache,
 		cpolLister: cpolInformer.Lister(),
@@ -54,7 +72,11 @@ func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer k
 			workqueue.DefaultTypedControllerRateLimiter[any](),
 			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
 		),
-		client: client,
+		client:      client,
+		jp:          jp,
+		celCache:    celCache,
+		schemaCache: schemaCache,
+		paramsStore: paramsStore,
 	}
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -78,6 +100,7 @@ func (c *controller) WarmUp() error {
 			return err
 		} else {
 			if policy.IsReady() {
+				c.precompile(policy)
 				return c.cache.Set(key, policy, c.client.Discovery())
 			} else {
 				c.cache.Unset(key)
@@ -94,6 +117,7 @@ func (c *controller) WarmUp() error {
 			return err
 		} else {
 			if policy.IsReady() {
+				c.precompile(policy)
 				return c.cache.Set(key, policy, c.client.Discovery())
 			} else {
 				c.cache.Unset(key)
@@ -109,6 +133,13 @@ func (c *controller) Run(ctx context.Context, workers int) {
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	// the policy changed or was deleted, compiled CEL programs and JSON schemas are not valid anymore
+	if c.celCache != nil {
+		c.celCache.Invalidate(key)
+	}
+	if c.schemaCache != nil {
+		c.schemaCache.Invalidate(key)
+	}
 	policy, err := c.loadPolicy(namespace, name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -118,6 +149,8 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	}
 	if policy.AdmissionProcessingEnabled() && !policy.GetSpec().CustomWebhookMatchConditions() {
 		if policy.IsReady() {
+			c.precompile(policy)
+			c.warmParams(logger, policy)
 			return c.cache.Set(key, policy, c.client.Discovery())
 		} else {
 			c.cache.Unset(key)
@@ -129,6 +162,35 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	}
 }
 
+// precompile compiles the JMESPath queries found in a policy so that they don't need to be parsed
+// when the policy is applied, queries that don't compile will fail when evaluated
+func (c *controller) precompile(policy kyvernov1.PolicyInterface) {
+	if c.jp == nil {
+		return
+	}
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy.GetSpec())
+	if err != nil {
+		logger.Error(err, "failed to convert policy spec", "policy", policy.GetName())
+		return
+	}
+	if err := c.jp.Precompile(variables.Queries(spec)...); err != nil {
+		logger.V(4).Info("failed to pre-compile JMESPath queries", "policy", policy.GetName(), "error", err.Error())
+	}
+}
+
+// warmParams starts watching the parameters of a policy so that they are served from the params store informers
+// when the policy is applied, it doesn't wait for the informers to sync and a missing parameters object
+// only fails the policy rules at evaluation time
+func (c *controller) warmParams(logger logr.Logger, policy kyvernov1.PolicyInterface) {
+	if c.paramsStore == nil || policy.GetSpec().Params == nil {
+		return
+	}
+	binding := policy.GetSpec().Params.Binding(policy.GetNamespace())
+	if err := c.paramsStore.Warm(binding); err != nil {
+		logger.Info("failed to watch policy parameters", "kind", binding.Kind, "namespace", binding.Namespace, "name", binding.Name, "error", err.Error())
+	}
+}
+
 func (c *controller) loadPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
 	if namespace == "" {
 		return c.cpolLister.Get(name)
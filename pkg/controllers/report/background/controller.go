@@ -2,7 +2,9 @@ package background
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,6 +19,7 @@ import (
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/collection"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
@@ -73,6 +76,13 @@ type controller struct {
 	metadataCache resource.MetadataCache
 	forceDelay    time.Duration
 
+	// collections
+	collections collection.Store
+	// collectionScans maps resource keys to the collection revision they were last scanned with
+	collectionScans sync.Map
+	// collectionEvents holds the resources of the collections changed since they were last enqueued
+	collectionEvents sync.Map
+
 	// config
 	config        config.Configuration
 	jp            jmespath.Interface
@@ -94,6 +104,7 @@ func NewController(
 	vapBindingInformer admissionregistrationv1beta1informers.ValidatingAdmissionPolicyBindingInformer,
 	nsInformer corev1informers.NamespaceInformer,
 	metadataCache resource.MetadataCache,
+	collections collection.Store,
 	forceDelay time.Duration,
 	config config.Configuration,
 	jp jmespath.Interface,
@@ -120,6 +131,7 @@ func NewController(
 		nsLister:       nsInformer.Lister(),
 		queue:          queue,
 		metadataCache:  metadataCache,
+		collections:    collections,
 		forceDelay:     forceDelay,
 		config:         config,
 		jp:             jp,
@@ -160,6 +172,18 @@ func NewController(
 			c.queue.AddAfter(res.Namespace+"/"+string(uid), enqueueDelay)
 		}
 	})
+	if c.collections != nil {
+		c.collections.AddEventHandler(func(gvr schema.GroupVersionResource) {
+			// events are debounced per resource, a burst of changes only enqueues the matching resources once
+			if _, pending := c.collectionEvents.LoadOrStore(gvr, struct{}{}); pending {
+				return
+			}
+			time.AfterFunc(enqueueDelay, func() {
+				c.collectionEvents.Delete(gvr)
+				c.enqueueCollectionResources(gvr)
+			})
+		})
+	}
 	return &c
 }
 
@@ -230,6 +254,42 @@ func (c *controller) enqueueResources() {
 	}
 }
 
+// enqueueCollectionResources enqueues the resources matched by the collection rules watching the given resource
+func (c *controller) enqueueCollectionResources(gvr schema.GroupVersionResource) {
+	policies, err := utils.FetchClusterPolicies(c.cpolLister)
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+		return
+	}
+	pols, err := utils.FetchPolicies(c.polLister, metav1.NamespaceAll)
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+		return
+	}
+	var kinds []string
+	for _, policy := range utils.RemoveNonBackgroundPolicies(append(policies, pols...)...) {
+		for _, rule := range policy.GetSpec().Rules {
+			if !rule.HasValidateCollection() {
+				continue
+			}
+			gvrs, err := c.collections.Resources(rule.Validation.Collection.Kinds...)
+			if err != nil {
+				logger.Error(err, "failed to resolve collection kinds", "policy", policy.GetName(), "rule", rule.Name)
+				continue
+			}
+			if slices.Contains(gvrs, gvr) {
+				kinds = append(kinds, rule.MatchResources.GetKinds()...)
+			}
+		}
+	}
+	if len(kinds) == 0 {
+		return
+	}
+	for _, key := range c.metadataCache.GetResourceKeys(kinds...) {
+		c.queue.Add(key)
+	}
+}
+
 func (c *controller) getReport(ctx context.Context, namespace, name string) (reportsv1.ReportInterface, error) {
 	if namespace == "" {
 		return c.kyvernoClient.ReportsV1().ClusterEphemeralReports().Get(ctx, name, metav1.GetOptions{})
@@ -502,6 +562,7 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 	// if the resource is not present it means we shouldn't have a report for it
 	// we can delete the report, we will recreate one if the resource comes back
 	if !exists {
+		c.collectionScans.Delete(key)
 		report, err := c.getMeta(namespace, name)
 		if err != nil {
 			if !apierrors.IsNotFound(err) {
@@ -558,15 +619,52 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 		return err
 	}
 	// we have the resource, check if we need to reconcile
+	revision, hasCollections := c.collectionRevision(kyvernoPolicies...)
 	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, exceptions, vapBindings, policies...); err != nil {
 		return err
 	} else {
 		defer func() {
 			c.queue.AddAfter(key, c.forceDelay)
 		}()
+		// if a collection changed since the last scan, we need a full reconcile
+		if hasCollections && !c.collectionScanned(key, revision) {
+			needsReconcile, full = true, true
+		}
 		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, exceptions, vapBindings, policies...)
+			if err := c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, exceptions, vapBindings, policies...); err != nil {
+				return err
+			}
+			if hasCollections {
+				c.collectionScans.Store(key, revision)
+			}
 		}
 	}
 	return nil
 }
+
+func (c *controller) collectionScanned(key string, revision int64) bool {
+	scanned, ok := c.collectionScans.Load(key)
+	return ok && scanned.(int64) == revision
+}
+
+// collectionRevision returns the revision of the collections referenced by the policies and whether there is any,
+// the revisions of the collections only increase so their sum changes every time one of them changes
+func (c *controller) collectionRevision(policies ...kyvernov1.PolicyInterface) (int64, bool) {
+	var revision int64
+	var hasCollections bool
+	for _, policy := range policies {
+		for _, rule := range policy.GetSpec().Rules {
+			if !rule.HasValidateCollection() {
+				continue
+			}
+			hasCollections = true
+			if c.collections == nil {
+				continue
+			}
+			if r, err := c.collections.Revision(rule.Validation.Collection.Kinds...); err == nil {
+				revision += r
+			}
+		}
+	}
+	return revision, hasCollections
+}
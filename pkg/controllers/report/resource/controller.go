@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/kyverno/kyverno/pkg/utils/match"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +58,7 @@ type EventHandler func(EventType, types.UID, schema.GroupVersionKind, Resource)
 type MetadataCache interface {
 	GetResourceHash(uid types.UID) (Resource, schema.GroupVersionKind, bool)
 	GetAllResourceKeys() []string
+	GetResourceKeys(kinds ...string) []string
 	AddEventHandler(EventHandler)
 	Warmup(ctx context.Context) error
 }
@@ -141,10 +143,24 @@ func (c *controller) GetResourceHash(uid types.UID) (Resource, schema.GroupVersi
 }
 
 func (c *controller) GetAllResourceKeys() []string {
+	return c.getResourceKeys(nil)
+}
+
+// GetResourceKeys returns the keys of the resources matching one of the kind selectors
+func (c *controller) GetResourceKeys(kinds ...string) []string {
+	return c.getResourceKeys(func(gvk schema.GroupVersionKind) bool {
+		return match.CheckKind(kinds, gvk, "", false)
+	})
+}
+
+func (c *controller) getResourceKeys(filter func(schema.GroupVersionKind) bool) []string {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 	var keys []string
 	for _, watcher := range c.dynamicWatchers {
+		if filter != nil && !filter(watcher.gvk) {
+			continue
+		}
 		for uid, resource := range watcher.hashes {
 			key := string(uid)
 			if resource.Namespace != "" {
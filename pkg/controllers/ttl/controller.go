@@ -173,19 +173,16 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 	if metaObj.GetDeletionTimestamp() != nil {
 		return nil
 	}
-	labels := metaObj.GetLabels()
-	ttlValue, ok := labels[kyverno.LabelCleanupTtl]
-	if !ok {
-		// No 'ttl' label present, no further action needed
+	deletionTime, err := DeletionTime(metaObj)
+	if err != nil {
+		logger.Error(err, "failed to parse label", "value", metaObj.GetLabels()[kyverno.LabelCleanupTtl])
 		return nil
 	}
-	var deletionTime time.Time
-	// Try parsing ttlValue as duration
-	if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
-		logger.Error(err, "failed to parse label", "value", ttlValue)
+	if deletionTime == nil {
+		// No 'ttl' label present, no further action needed
 		return nil
 	}
-	if time.Now().After(deletionTime) {
+	if time.Now().After(*deletionTime) {
 		deleteOptions := metav1.DeleteOptions{
 			PropagationPolicy: determinePropagationPolicy(metaObj, logger),
 		}
@@ -203,7 +200,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 			c.metrics.deletedObjectsTotal.Add(context.Background(), 1, metric.WithAttributes(commonLabels...))
 		}
 		// Calculate the remaining time until deletion
-		timeRemaining := time.Until(deletionTime)
+		timeRemaining := time.Until(*deletionTime)
 		// Add the item back to the queue after the remaining time
 		c.queue.AddAfter(itemKey, timeRemaining)
 	}
@@ -68,3 +68,17 @@ func parseDeletionTime(metaObj metav1.Object, deletionTime *time.Time, ttlValue
 	}
 	return nil
 }
+
+// DeletionTime returns the time at which the resource expires according to its ttl label.
+// It returns nil if the resource doesn't have the label.
+func DeletionTime(metaObj metav1.Object) (*time.Time, error) {
+	ttlValue, ok := metaObj.GetLabels()[kyverno.LabelCleanupTtl]
+	if !ok {
+		return nil, nil
+	}
+	var deletionTime time.Time
+	if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
+		return nil, err
+	}
+	return &deletionTime, nil
+}
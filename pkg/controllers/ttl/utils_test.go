@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -71,3 +72,27 @@ func TestParseDeletionTime(t *testing.T) {
 		}
 	}
 }
+
+func TestDeletionTime(t *testing.T) {
+	creationTime := time.Date(2023, 7, 18, 12, 0, 0, 0, time.UTC)
+	metaObj := &mockMetaObj{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(creationTime),
+		},
+	}
+	deletionTime, err := DeletionTime(metaObj)
+	if err != nil || deletionTime != nil {
+		t.Errorf("Expected no deletion time for a resource without ttl label, got: %v, %v", deletionTime, err)
+	}
+	metaObj.Labels = map[string]string{kyverno.LabelCleanupTtl: "1h"}
+	deletionTime, err = DeletionTime(metaObj)
+	if err != nil {
+		t.Errorf("Expected no error but got: %v", err)
+	} else if !deletionTime.Equal(creationTime.Add(time.Hour)) {
+		t.Errorf("Expected deletion time: %v but got: %v", creationTime.Add(time.Hour), deletionTime)
+	}
+	metaObj.Labels = map[string]string{kyverno.LabelCleanupTtl: "invalid-value"}
+	if _, err := DeletionTime(metaObj); err == nil {
+		t.Errorf("Expected an error but got nil")
+	}
+}
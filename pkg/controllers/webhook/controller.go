@@ -570,6 +570,11 @@ func (c *controller) updatePolicyStatuses(ctx context.Context, webhookType strin
 		}
 		status := policy.GetStatus()
 		status.SetReady(ready, message)
+		status.Params = nil
+		if params := policy.GetSpec().Params; params != nil {
+			binding := params.Binding(policy.GetNamespace())
+			status.Params = &binding
+		}
 		status.Autogen.Rules = nil
 		rules := autogen.Default.ComputeRules(policy, "")
 		setRuleCount(rules, status)
@@ -0,0 +1,91 @@
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	imagespecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	cosignempty "github.com/sigstore/cosign/v2/pkg/oci/empty"
+	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"gotest.tools/assert"
+)
+
+// writeSignedLayout writes an OCI image layout containing a signed and an unsigned image, it returns the public key
+func writeSignedLayout(t *testing.T, dir string, repository string) string {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	signer, err := signature.LoadECDSASignerVerifier(priv, crypto.SHA256)
+	assert.NilError(t, err)
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	assert.NilError(t, err)
+
+	p, err := layout.Write(dir, empty.Index)
+	assert.NilError(t, err)
+	signed, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	unsigned, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	digest, err := signed.Digest()
+	assert.NilError(t, err)
+	assert.NilError(t, p.AppendImage(signed, layout.WithAnnotations(map[string]string{imagespecv1.AnnotationRefName: repository + ":signed"})))
+	assert.NilError(t, p.AppendImage(unsigned, layout.WithAnnotations(map[string]string{imagespecv1.AnnotationRefName: repository + ":unsigned"})))
+
+	ref, err := name.ParseReference(repository + "@" + digest.String())
+	assert.NilError(t, err)
+	body, err := payload.Cosign{Image: ref.(name.Digest)}.MarshalJSON()
+	assert.NilError(t, err)
+	sig, err := signer.SignMessage(bytes.NewReader(body))
+	assert.NilError(t, err)
+	ociSig, err := static.NewSignature(body, base64.StdEncoding.EncodeToString(sig))
+	assert.NilError(t, err)
+	sigs, err := mutate.AppendSignatures(cosignempty.Signatures(), false, ociSig)
+	assert.NilError(t, err)
+	sigTag := repository + ":" + strings.ReplaceAll(digest.String(), ":", "-") + ".sig"
+	assert.NilError(t, p.AppendImage(sigs, layout.WithAnnotations(map[string]string{imagespecv1.AnnotationRefName: sigTag})))
+	return string(pub)
+}
+
+func TestCosignVerifyFromLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "layout")
+	pub := writeSignedLayout(t, dir, "ghcr.io/kyverno/test-verify-image")
+	rc, err := registryclient.NewFromLayout(dir)
+	assert.NilError(t, err)
+	verifier := &cosignVerifier{}
+	opts := images.Options{
+		ImageRef:   "ghcr.io/kyverno/test-verify-image:signed",
+		Client:     rc,
+		Key:        pub,
+		IgnoreTlog: true,
+		IgnoreSCT:  true,
+	}
+	resp, err := verifier.VerifySignature(context.TODO(), opts)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(resp.Digest, "sha256:"))
+
+	opts.ImageRef = "ghcr.io/kyverno/test-verify-image:unsigned"
+	_, err = verifier.VerifySignature(context.TODO(), opts)
+	assert.ErrorContains(t, err, "no signatures found")
+
+	// the same repository on another registry is not served
+	opts.ImageRef = "docker.io/kyverno/test-verify-image:signed"
+	_, err = verifier.VerifySignature(context.TODO(), opts)
+	assert.Assert(t, err != nil)
+}
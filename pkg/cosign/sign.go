@@ -0,0 +1,114 @@
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	cbundle "github.com/sigstore/cosign/v2/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	"github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+)
+
+type SignOptions struct {
+	ImageRef string
+	Client   images.Client
+	// Key is a PEM encoded cosign private key
+	Key []byte
+	// Password decrypts the private key
+	Password []byte
+	// Annotations are added to the signed payload
+	Annotations map[string]string
+	// TlogUpload uploads the signature to the Rekor transparency log at RekorURL
+	TlogUpload bool
+	RekorURL   string
+}
+
+// Sign signs an image with a cosign private key and pushes the signature to the image repository.
+// It returns the digest of the signed image.
+func Sign(ctx context.Context, opts SignOptions) (string, error) {
+	ref, err := name.ParseReference(opts.ImageRef, opts.Client.NameOptions()...)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image %s", opts.ImageRef)
+	}
+	remoteOpts, err := opts.Client.Options(ctx)
+	if err != nil {
+		return "", fmt.Errorf("constructing cosign remote options: %w", err)
+	}
+	desc, err := gcrremote.Head(ref, remoteOpts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch image %s: %w", opts.ImageRef, err)
+	}
+	digestRef := ref.Context().Digest(desc.Digest.String())
+
+	signer, err := cosign.LoadPrivateKey(opts.Key, opts.Password)
+	if err != nil {
+		return "", fmt.Errorf("failed to load private key: %w", err)
+	}
+	annotations := map[string]interface{}{}
+	for key, value := range opts.Annotations {
+		annotations[key] = value
+	}
+	body, err := payload.Cosign{Image: digestRef, Annotations: annotations}.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to create payload: %w", err)
+	}
+	sig, err := signer.SignMessage(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+
+	var sigOpts []static.Option
+	if opts.TlogUpload {
+		publicKey, err := signer.PublicKey()
+		if err != nil {
+			return "", err
+		}
+		pem, err := cryptoutils.MarshalPublicKeyToPEM(publicKey)
+		if err != nil {
+			return "", err
+		}
+		rekor, err := rekorclient.GetRekorClient(opts.RekorURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to create Rekor client from URL %s: %w", opts.RekorURL, err)
+		}
+		checksum := sha256.New()
+		if _, err := checksum.Write(body); err != nil {
+			return "", err
+		}
+		entry, err := cosign.TLogUpload(ctx, rekor, sig, checksum, pem)
+		if err != nil {
+			return "", fmt.Errorf("failed to upload signature to %s: %w", opts.RekorURL, err)
+		}
+		sigOpts = append(sigOpts, static.WithBundle(cbundle.EntryToBundle(entry)))
+	}
+	ociSig, err := static.NewSignature(body, base64.StdEncoding.EncodeToString(sig), sigOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	cosignRemoteOpts := remote.WithRemoteOptions(remoteOpts...)
+	entity, err := remote.SignedEntity(digestRef, cosignRemoteOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch image %s: %w", opts.ImageRef, err)
+	}
+	entity, err = mutate.AttachSignatureToEntity(entity, ociSig)
+	if err != nil {
+		return "", err
+	}
+	if err := remote.WriteSignatures(digestRef.Repository, entity, cosignRemoteOpts); err != nil {
+		return "", fmt.Errorf("failed to write signature: %w", err)
+	}
+	logger.V(3).Info("signed image", "image", digestRef.String())
+	return desc.Digest.String(), nil
+}
@@ -0,0 +1,75 @@
+package cosign
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"gotest.tools/assert"
+)
+
+func TestSign(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/kyverno/test-sign:latest"
+	ref, err := name.ParseReference(imageRef)
+	assert.NilError(t, err)
+	img, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, gcrremote.Write(ref, img))
+	rc, err := registryclient.New()
+	assert.NilError(t, err)
+
+	password := func(bool) ([]byte, error) { return []byte("secret"), nil }
+	keys, err := cosign.GenerateKeyPair(password)
+	assert.NilError(t, err)
+	otherKeys, err := cosign.GenerateKeyPair(password)
+	assert.NilError(t, err)
+
+	_, err = Sign(context.TODO(), SignOptions{
+		ImageRef: imageRef,
+		Client:   rc,
+		Key:      keys.PrivateBytes,
+		Password: []byte("wrong"),
+	})
+	assert.ErrorContains(t, err, "failed to load private key")
+
+	digest, err := Sign(context.TODO(), SignOptions{
+		ImageRef:    imageRef,
+		Client:      rc,
+		Key:         keys.PrivateBytes,
+		Password:    []byte("secret"),
+		Annotations: map[string]string{"source": "test"},
+	})
+	assert.NilError(t, err)
+	expected, err := img.Digest()
+	assert.NilError(t, err)
+	assert.Equal(t, digest, expected.String())
+
+	verifier := &cosignVerifier{}
+	opts := images.Options{
+		ImageRef:    imageRef,
+		Client:      rc,
+		Key:         string(keys.PublicBytes),
+		Annotations: map[string]string{"source": "test"},
+		IgnoreTlog:  true,
+		IgnoreSCT:   true,
+	}
+	resp, err := verifier.VerifySignature(context.TODO(), opts)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.Digest, digest)
+
+	opts.Key = string(otherKeys.PublicBytes)
+	_, err = verifier.VerifySignature(context.TODO(), opts)
+	assert.Assert(t, err != nil)
+}
@@ -7,6 +7,8 @@ type AnchorMap struct {
 	anchorMap map[string]bool
 	// AnchorError - used in validate to break execution of the recursion when if condition fails
 	AnchorError validateAnchorError
+	// FailedAnchor - path of the innermost anchor that made the validation fail or skip
+	FailedAnchor string
 }
 
 // NewAnchorMap -initialize anchorMap
@@ -46,3 +48,10 @@ func (ac *AnchorMap) CheckAnchorInResource(pattern map[string]interface{}, resou
 		}
 	}
 }
+
+// SetFailedAnchor records the anchor that made the validation fail or skip, only the innermost anchor is kept
+func (ac *AnchorMap) SetFailedAnchor(path string) {
+	if ac.FailedAnchor == "" {
+		ac.FailedAnchor = path
+	}
+}
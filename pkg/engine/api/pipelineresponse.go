@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PipelineResponse is the response of running policies against a resource through the admission pipeline
+type PipelineResponse struct {
+	// Resource is the original resource
+	Resource unstructured.Unstructured
+	// PatchedResource is the resource after the mutation and image verification stages
+	PatchedResource unstructured.Unstructured
+	// Mutation contains the responses of the mutation stage
+	Mutation []EngineResponse
+	// ImageVerification contains the responses of the image verification stage
+	ImageVerification []EngineResponse
+	// Validation contains the responses of the validation stage
+	Validation []EngineResponse
+}
+
+// Responses returns the responses of all stages, in the order they were produced
+func (r PipelineResponse) Responses() []EngineResponse {
+	responses := make([]EngineResponse, 0, len(r.Mutation)+len(r.ImageVerification)+len(r.Validation))
+	responses = append(responses, r.Mutation...)
+	responses = append(responses, r.ImageVerification...)
+	responses = append(responses, r.Validation...)
+	return responses
+}
+
+// IsBlocked checks if the admission request would be denied by one of the responses
+func (r PipelineResponse) IsBlocked(ctx context.Context) bool {
+	for _, response := range r.Responses() {
+		if response.IsFailed() && response.GetValidationFailureAction().Enforce() {
+			return true
+		}
+		if response.IsError() {
+			if policy := response.Policy().AsKyvernoPolicy(); policy != nil && policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,6 +52,8 @@ type RuleResponse struct {
 	emitWarning bool
 	// properties are the additional properties from the rule that will be added to the policy report result
 	properties map[string]string
+	// trace is the evaluation trace of the rule (only if explain mode is enabled)
+	trace []explain.Entry
 }
 
 func NewRuleResponse(name string, ruleType RuleType, msg string, status RuleStatus, properties map[string]string) *RuleResponse {
@@ -133,6 +136,11 @@ func (r RuleResponse) WithProperties(m map[string]string) *RuleResponse {
 	return &r
 }
 
+func (r RuleResponse) WithTrace(trace []explain.Entry) RuleResponse {
+	r.trace = trace
+	return r
+}
+
 func (r *RuleResponse) Stats() ExecutionStats {
 	return r.stats
 }
@@ -185,6 +193,10 @@ func (r *RuleResponse) Properties() map[string]string {
 	return r.properties
 }
 
+func (r *RuleResponse) Trace() []explain.Entry {
+	return r.trace
+}
+
 // HasStatus checks if rule status is in a given list
 func (r *RuleResponse) HasStatus(status ...RuleStatus) bool {
 	for _, s := range status {
@@ -0,0 +1,87 @@
+package cel
+
+import (
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+)
+
+// Cache stores compiled CEL programs per policy and rule.
+type Cache interface {
+	// Get returns the compiled programs of a rule, compiling them if they are not cached yet.
+	// Programs are cached per policy UID and resource version, policies without UID are never cached.
+	Get(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) (*Programs, error)
+	// Invalidate removes the programs compiled for a policy, the key is the policy namespace/name key.
+	Invalidate(key string)
+}
+
+type compiled struct {
+	programs *Programs
+	err      error
+}
+
+type entry struct {
+	uid             types.UID
+	resourceVersion string
+	rules           map[string]compiled
+}
+
+type programCache struct {
+	lock    sync.RWMutex
+	entries map[string]*entry
+	clock   clock.PassiveClock
+}
+
+func NewCache() Cache {
+	return NewCacheWithClock(clock.RealClock{})
+}
+
+// NewCacheWithClock returns a cache compiling programs where kyverno.timeNow reads the given clock instead of the current time.
+func NewCacheWithClock(clock clock.PassiveClock) Cache {
+	return &programCache{
+		entries: map[string]*entry{},
+		clock:   clock,
+	}
+}
+
+func (c *programCache) Get(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) (*Programs, error) {
+	uid, resourceVersion := policy.GetUID(), policy.GetResourceVersion()
+	if uid == "" {
+		return CompileWithClock(rule, c.clock)
+	}
+	key, err := cache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		return CompileWithClock(rule, c.clock)
+	}
+	c.lock.RLock()
+	if entry, ok := c.entries[key]; ok && entry.uid == uid && entry.resourceVersion == resourceVersion {
+		if result, ok := entry.rules[rule.Name]; ok {
+			c.lock.RUnlock()
+			return result.programs, result.err
+		}
+	}
+	c.lock.RUnlock()
+	programs, err := CompileWithClock(rule, c.clock)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	current, ok := c.entries[key]
+	if !ok || current.uid != uid || current.resourceVersion != resourceVersion {
+		current = &entry{
+			uid:             uid,
+			resourceVersion: resourceVersion,
+			rules:           map[string]compiled{},
+		}
+		c.entries[key] = current
+	}
+	current.rules[rule.Name] = compiled{programs: programs, err: err}
+	return programs, err
+}
+
+func (c *programCache) Invalidate(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, key)
+}
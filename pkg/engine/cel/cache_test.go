@@ -0,0 +1,57 @@
+package cel
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func policy(uid, resourceVersion string) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test",
+			UID:             types.UID(uid),
+			ResourceVersion: resourceVersion,
+		},
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := NewCache()
+	rule := celRule("object.spec.replicas <= 5")
+	first, err := cache.Get(policy("1", "1"), rule)
+	assert.NoError(t, err)
+	second, err := cache.Get(policy("1", "1"), rule)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	// a new resource version gets compiled again
+	third, err := cache.Get(policy("1", "2"), rule)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, third)
+	// invalidation removes the policy programs
+	cache.Invalidate("test")
+	fourth, err := cache.Get(policy("1", "2"), rule)
+	assert.NoError(t, err)
+	assert.NotSame(t, third, fourth)
+}
+
+func TestCacheWithoutUID(t *testing.T) {
+	cache := NewCache()
+	rule := celRule("object.spec.replicas <= 5")
+	first, err := cache.Get(policy("", ""), rule)
+	assert.NoError(t, err)
+	second, err := cache.Get(policy("", ""), rule)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, second)
+}
+
+func TestCacheErrors(t *testing.T) {
+	cache := NewCache()
+	_, err := cache.Get(policy("1", "1"), celRule("object.("))
+	assert.Error(t, err)
+	_, err = cache.Get(policy("1", "1"), celRule("object.("))
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package cel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+)
+
+// WithRuleContext makes the rule context entries available to CEL expressions as `variables.context`.
+// Entries are queried from the JSON context the first time an expression accesses the variable.
+func WithRuleContext(ctx context.Context, jsonContext enginecontext.EvalInterface, entries []kyvernov1.ContextEntry) context.Context {
+	return celutils.WithContext(ctx, sync.OnceValues(func() (map[string]any, error) {
+		data := make(map[string]any, len(entries))
+		for _, entry := range entries {
+			value, err := jsonContext.Query(entry.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query context entry %s: %w", entry.Name, err)
+			}
+			data[entry.Name] = value
+		}
+		return data, nil
+	}))
+}
@@ -0,0 +1,118 @@
+package cel
+
+import (
+	"errors"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/utils/clock"
+)
+
+// Programs holds the compiled expressions of a validate.cel or mutate.cel rule.
+type Programs struct {
+	HasParams        bool
+	Validations      cel.Filter
+	Messages         cel.Filter
+	AuditAnnotations cel.Filter
+	MatchConditions  cel.Filter
+	// Mutations holds a filter per mutation of a mutate.cel rule, in the order they are applied
+	Mutations []cel.Filter
+}
+
+// Compile compiles the CEL expressions of a validate.cel or mutate.cel rule and its preconditions.
+// It returns an error if one of the expressions doesn't compile.
+func Compile(rule kyvernov1.Rule) (*Programs, error) {
+	return CompileWithClock(rule, clock.RealClock{})
+}
+
+// CompileWithClock compiles the CEL expressions of a rule like Compile, kyverno.timeNow reads the given clock.
+func CompileWithClock(rule kyvernov1.Rule, clock clock.PassiveClock) (*Programs, error) {
+	if rule.Validation != nil && rule.Validation.CEL != nil {
+		return compileValidation(rule, clock)
+	}
+	if rule.Mutation != nil && rule.Mutation.CEL != nil {
+		return compileMutation(rule, clock)
+	}
+	return nil, fmt.Errorf("rule %s is not a validate.cel or mutate.cel rule", rule.Name)
+}
+
+// compileValidation compiles the variables, validation, message, audit annotation and precondition expressions of a validate.cel rule.
+func compileValidation(rule kyvernov1.Rule, clock clock.PassiveClock) (*Programs, error) {
+	hasParam := rule.Validation.CEL.HasParam()
+	// validations without a message inherit the rule message, don't modify the rule in place
+	validations := make([]admissionregistrationv1beta1.Validation, len(rule.Validation.CEL.Expressions))
+	for i, validation := range rule.Validation.CEL.Expressions {
+		if validation.Message == "" {
+			validation.Message = rule.Validation.Message
+		}
+		validations[i] = validation
+	}
+	compiler, err := celutils.NewRuleCompiler(clock, validations, rule.Validation.CEL.AuditAnnotations, matchConditions(rule), rule.Validation.CEL.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL compiler (%w)", err)
+	}
+	optionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: true}
+	expressionOptionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: false}
+	errs := compiler.CompileVariables(optionalVars)
+	programs := &Programs{
+		HasParams:        hasParam,
+		Validations:      compiler.CompileValidateExpressions(optionalVars),
+		Messages:         compiler.CompileMessageExpressions(expressionOptionalVars),
+		AuditAnnotations: compiler.CompileAuditAnnotationsExpressions(optionalVars),
+		MatchConditions:  compiler.CompileMatchExpressions(optionalVars),
+	}
+	errs = append(errs, programs.Validations.CompilationErrors()...)
+	errs = append(errs, programs.Messages.CompilationErrors()...)
+	errs = append(errs, programs.AuditAnnotations.CompilationErrors()...)
+	errs = append(errs, programs.MatchConditions.CompilationErrors()...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("CEL compilation errors: %w", errors.Join(errs...))
+	}
+	return programs, nil
+}
+
+// compileMutation compiles the variables, mutation and precondition expressions of a mutate.cel rule.
+func compileMutation(rule kyvernov1.Rule, clock clock.PassiveClock) (*Programs, error) {
+	var expressions []string
+	var errs []error
+	for i, mutation := range rule.Mutation.CEL.Mutations {
+		expression := mutation.GetExpression()
+		if expression == "" {
+			errs = append(errs, fmt.Errorf("mutation %d: an expression is required for patch type %q", i, mutation.PatchType))
+		}
+		expressions = append(expressions, expression)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("CEL compilation errors: %w", errors.Join(errs...))
+	}
+	compiler, err := celutils.NewMutationCompiler(clock, matchConditions(rule), rule.Mutation.CEL.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL compiler (%w)", err)
+	}
+	optionalVars := cel.OptionalVariableDeclarations{HasAuthorizer: true}
+	errs = compiler.CompileVariables(optionalVars)
+	programs := &Programs{
+		MatchConditions: compiler.CompileMatchExpressions(optionalVars),
+		Mutations:       compiler.CompileMutationExpressions(expressions, optionalVars),
+	}
+	errs = append(errs, programs.MatchConditions.CompilationErrors()...)
+	for _, mutation := range programs.Mutations {
+		errs = append(errs, mutation.CompilationErrors()...)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("CEL compilation errors: %w", errors.Join(errs...))
+	}
+	return programs, nil
+}
+
+func matchConditions(rule kyvernov1.Rule) []admissionregistrationv1.MatchCondition {
+	matchConditions := make([]admissionregistrationv1.MatchCondition, 0, len(rule.CELPreconditions))
+	for _, condition := range rule.CELPreconditions {
+		matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition(condition))
+	}
+	return matchConditions
+}
@@ -0,0 +1,104 @@
+package cel
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+)
+
+func celRule(expressions ...string) kyvernov1.Rule {
+	var validations []admissionregistrationv1beta1.Validation
+	for _, expression := range expressions {
+		validations = append(validations, admissionregistrationv1beta1.Validation{Expression: expression})
+	}
+	return kyvernov1.Rule{
+		Name: "check",
+		Validation: &kyvernov1.Validation{
+			Message: "rule message",
+			CEL: &kyvernov1.CEL{
+				Expressions: validations,
+			},
+		},
+	}
+}
+
+func TestCompile(t *testing.T) {
+	rule := celRule("object.spec.replicas <= 5")
+	rule.CELPreconditions = []admissionregistrationv1beta1.MatchCondition{{Name: "create", Expression: "request.operation == 'CREATE'"}}
+	rule.Validation.CEL.Variables = []admissionregistrationv1beta1.Variable{{Name: "replicas", Expression: "object.spec.replicas"}}
+	rule.Validation.CEL.AuditAnnotations = []admissionregistrationv1beta1.AuditAnnotation{{Key: "replicas", ValueExpression: "string(variables.replicas)"}}
+	programs, err := Compile(rule)
+	assert.NoError(t, err)
+	assert.NotNil(t, programs)
+	assert.False(t, programs.HasParams)
+	// the rule is not modified
+	assert.Equal(t, "", rule.Validation.CEL.Expressions[0].Message)
+}
+
+func TestCompileErrors(t *testing.T) {
+	_, err := Compile(celRule("object.spec.replicas <="))
+	assert.ErrorContains(t, err, "CEL compilation errors")
+	rule := celRule("true")
+	rule.Validation.CEL.Variables = []admissionregistrationv1beta1.Variable{{Name: "broken", Expression: "object.("}}
+	_, err = Compile(rule)
+	assert.Error(t, err)
+	rule = celRule("true")
+	rule.CELPreconditions = []admissionregistrationv1beta1.MatchCondition{{Name: "broken", Expression: "request."}}
+	_, err = Compile(rule)
+	assert.Error(t, err)
+	_, err = Compile(kyvernov1.Rule{Name: "pattern", Validation: &kyvernov1.Validation{}})
+	assert.Error(t, err)
+	rule = celRule("true")
+	rule.Validation.CEL.Variables = []admissionregistrationv1beta1.Variable{{Name: "context", Expression: "'reserved'"}}
+	_, err = Compile(rule)
+	assert.ErrorContains(t, err, `variable name "context" is reserved`)
+}
+
+func TestCompileKyvernoLibrary(t *testing.T) {
+	rule := celRule("variables.context.allowed.registries.exists(r, r == kyverno.parseImage(object.spec.image).registry)")
+	_, err := Compile(rule)
+	assert.NoError(t, err)
+}
+
+func celMutateRule(mutations ...kyvernov1.CELMutation) kyvernov1.Rule {
+	return kyvernov1.Rule{
+		Name: "mutate",
+		Mutation: &kyvernov1.Mutation{
+			CEL: &kyvernov1.MutationCEL{
+				Mutations: mutations,
+			},
+		},
+	}
+}
+
+func TestCompileMutation(t *testing.T) {
+	rule := celMutateRule(
+		kyvernov1.CELMutation{
+			PatchType:          kyvernov1.PatchTypeApplyConfiguration,
+			ApplyConfiguration: &kyvernov1.ApplyConfiguration{Expression: `{"metadata": {"labels": {"env": variables.env}}}`},
+		},
+		kyvernov1.CELMutation{
+			PatchType: kyvernov1.PatchTypeJSONPatch,
+			JSONPatch: &kyvernov1.JSONPatch{Expression: `[{"op": "add", "path": "/metadata/annotations", "value": {"mutated": "true"}}]`},
+		},
+	)
+	rule.CELPreconditions = []admissionregistrationv1beta1.MatchCondition{{Name: "create", Expression: "request.operation == 'CREATE'"}}
+	rule.Mutation.CEL.Variables = []admissionregistrationv1beta1.Variable{{Name: "env", Expression: "'prod'"}}
+	programs, err := Compile(rule)
+	require.NoError(t, err)
+	assert.Len(t, programs.Mutations, 2)
+	assert.NotNil(t, programs.MatchConditions)
+}
+
+func TestCompileMutationErrors(t *testing.T) {
+	_, err := Compile(celMutateRule(kyvernov1.CELMutation{PatchType: kyvernov1.PatchTypeJSONPatch}))
+	assert.ErrorContains(t, err, "an expression is required")
+	_, err = Compile(celMutateRule(kyvernov1.CELMutation{
+		PatchType:          kyvernov1.PatchTypeApplyConfiguration,
+		ApplyConfiguration: &kyvernov1.ApplyConfiguration{Expression: `{"metadata": `},
+	}))
+	assert.ErrorContains(t, err, "CEL compilation errors")
+}
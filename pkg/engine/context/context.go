@@ -18,7 +18,6 @@ import (
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var (
@@ -101,7 +100,7 @@ type Interface interface {
 	// and updates the context
 	GenerateCustomImageInfo(resource *unstructured.Unstructured, imageExtractorConfigs kyvernov1.ImageExtractorConfigs, cfg config.Configuration) (map[string]map[string]apiutils.ImageInfo, error)
 
-	// Checkpoint creates a copy of the current internal state and pushes it into a stack of stored states.
+	// Checkpoint pushes the current internal state into a stack of stored states.
 	Checkpoint()
 
 	// Restore sets the internal state to the last checkpoint, and removes the checkpoint.
@@ -119,6 +118,7 @@ type context struct {
 	jp                 jmespath.Interface
 	jsonRaw            map[string]interface{}
 	jsonRawCheckpoints []map[string]interface{}
+	layer              layer
 	images             map[string]map[string]apiutils.ImageInfo
 	operation          kyvernov1.AdmissionOperation
 	deferred           DeferredLoaders
@@ -141,7 +141,7 @@ func NewContextFromRaw(jp jmespath.Interface, raw map[string]interface{}) Interf
 
 // addJSON merges json data
 func (ctx *context) addJSON(dataMap map[string]interface{}, overwriteMaps bool) error {
-	mergeMaps(dataMap, ctx.jsonRaw, overwriteMaps)
+	ctx.jsonRaw = ctx.layer.mergeMaps(dataMap, ctx.jsonRaw, overwriteMaps)
 	return nil
 }
 
@@ -209,21 +209,25 @@ func (ctx *context) ReplaceContextEntry(name string, dataRaw []byte) error {
 	return addToContext(ctx, data, false, name)
 }
 
+func (ctx *context) clearLeafValue(tags ...string) {
+	ctx.jsonRaw, _ = ctx.layer.clearLeafValue(ctx.jsonRaw, tags...)
+}
+
 // AddResource data at path: request.object
 func (ctx *context) AddResource(data map[string]interface{}) error {
-	clearLeafValue(ctx.jsonRaw, "request", "object")
+	ctx.clearLeafValue("request", "object")
 	return addToContext(ctx, data, false, "request", "object")
 }
 
 // AddOldResource data at path: request.oldObject
 func (ctx *context) AddOldResource(data map[string]interface{}) error {
-	clearLeafValue(ctx.jsonRaw, "request", "oldObject")
+	ctx.clearLeafValue("request", "oldObject")
 	return addToContext(ctx, data, false, "request", "oldObject")
 }
 
 // AddTargetResource adds data at path: target
 func (ctx *context) SetTargetResource(data map[string]interface{}) error {
-	clearLeafValue(ctx.jsonRaw, "target")
+	ctx.clearLeafValue("target")
 	return addToContext(ctx, data, false, "target")
 }
 
@@ -352,7 +356,7 @@ func (l *ImageInfoLoader) HasLoaded() bool {
 }
 
 func (l *ImageInfoLoader) LoadData() error {
-	images, err := apiutils.ExtractImagesFromResource(*l.resource, nil, l.cfg)
+	images, err := apiutils.ExtractImagesFromResource(*l.resource, nil, l.eCtx.jp, l.cfg)
 	if err != nil {
 		return err
 	}
@@ -384,7 +388,7 @@ func convertImagesToUnstructured(images map[string]map[string]apiutils.ImageInfo
 }
 
 func (ctx *context) GenerateCustomImageInfo(resource *unstructured.Unstructured, imageExtractorConfigs kyvernov1.ImageExtractorConfigs, cfg config.Configuration) (map[string]map[string]apiutils.ImageInfo, error) {
-	images, err := apiutils.ExtractImagesFromResource(*resource, imageExtractorConfigs, cfg)
+	images, err := apiutils.ExtractImagesFromResource(*resource, imageExtractorConfigs, ctx.jp, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract images: %w", err)
 	}
@@ -406,24 +410,11 @@ func (ctx *context) ImageInfo() map[string]map[string]apiutils.ImageInfo {
 	return ctx.images
 }
 
-// Checkpoint creates a copy of the current internal state and
-// pushes it into a stack of stored states.
+// Checkpoint pushes the current internal state into a stack of stored states.
+// The state is not copied, maps are copied on write when they are mutated.
 func (ctx *context) Checkpoint() {
-	jsonRawCheckpoint := ctx.copyContext(ctx.jsonRaw)
-	ctx.jsonRawCheckpoints = append(ctx.jsonRawCheckpoints, jsonRawCheckpoint)
-}
-
-func (ctx *context) copyContext(in map[string]interface{}) map[string]interface{} {
-	out := make(map[string]interface{}, len(in))
-	for k, v := range in {
-		if ReservedKeys.MatchString(k) {
-			out[k] = v
-		} else {
-			out[k] = runtime.DeepCopyJSONValue(v)
-		}
-	}
-
-	return out
+	ctx.jsonRawCheckpoints = append(ctx.jsonRawCheckpoints, ctx.jsonRaw)
+	ctx.layer = layer{}
 }
 
 // Restore sets the internal state to the last checkpoint, and removes the checkpoint.
@@ -450,11 +441,15 @@ func (ctx *context) resetCheckpoint(restore bool) bool {
 	n := len(ctx.jsonRawCheckpoints) - 1
 	jsonRawCheckpoint := ctx.jsonRawCheckpoints[n]
 
+	ctx.jsonRaw = jsonRawCheckpoint
 	if restore {
 		ctx.jsonRawCheckpoints = ctx.jsonRawCheckpoints[:n]
-		ctx.jsonRaw = jsonRawCheckpoint
+	}
+	// without remaining checkpoints the state is not shared anymore
+	if len(ctx.jsonRawCheckpoints) == 0 {
+		ctx.layer = nil
 	} else {
-		ctx.jsonRaw = ctx.copyContext(jsonRawCheckpoint)
+		ctx.layer = layer{}
 	}
 
 	return true
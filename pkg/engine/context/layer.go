@@ -0,0 +1,78 @@
+package context
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// layer tracks the maps of the context data allocated since the last checkpoint.
+// Checkpoints share their maps with the current state, a map owned by the layer is not
+// referenced by any checkpoint and can be mutated in place while other maps are copied
+// first, this way only the mutated paths are copied and checkpoints are O(1).
+// A nil layer means there is no checkpoint to preserve and all maps are mutated in place.
+type layer map[unsafe.Pointer]struct{}
+
+// writable returns a map that can safely be mutated in place, copying m if it may be shared.
+func (l layer) writable(m map[string]interface{}) map[string]interface{} {
+	if l == nil {
+		return m
+	}
+	if _, ok := l[reflect.ValueOf(m).UnsafePointer()]; ok {
+		return m
+	}
+	out := make(map[string]interface{}, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	l[reflect.ValueOf(out).UnsafePointer()] = struct{}{}
+	return out
+}
+
+// mergeMaps merges srcMap entries into destMap and returns the resulting map
+func (l layer) mergeMaps(srcMap, destMap map[string]interface{}, overwriteMaps bool) map[string]interface{} {
+	destMap = l.writable(destMap)
+	for k, v := range srcMap {
+		if nextSrcMap, ok := v.(map[string]interface{}); ok && !overwriteMaps {
+			if nextDestMap, ok := destMap[k].(map[string]interface{}); ok {
+				destMap[k] = l.mergeMaps(nextSrcMap, nextDestMap, overwriteMaps)
+			} else {
+				destMap[k] = nextSrcMap
+			}
+		} else {
+			destMap[k] = v
+		}
+	}
+	return destMap
+}
+
+// clearLeafValue deletes the value at the given path and returns the resulting map,
+// the returned boolean reports whether the path was found
+func (l layer) clearLeafValue(data map[string]interface{}, tags ...string) (map[string]interface{}, bool) {
+	if len(tags) == 0 {
+		return data, false
+	}
+	k := tags[0]
+	if len(tags) == 1 {
+		if _, ok := data[k]; !ok {
+			return data, true
+		}
+		data = l.writable(data)
+		delete(data, k)
+		return data, true
+	}
+	nextMap, ok := data[k].(map[string]interface{})
+	if !ok {
+		return data, false
+	}
+	clearedMap, found := l.clearLeafValue(nextMap, tags[1:]...)
+	if !found {
+		return data, false
+	}
+	// nothing was copied below, the path is unchanged
+	if reflect.ValueOf(clearedMap).UnsafePointer() == reflect.ValueOf(nextMap).UnsafePointer() {
+		return data, true
+	}
+	data = l.writable(data)
+	data[k] = clearedMap
+	return data, true
+}
@@ -0,0 +1,186 @@
+package context
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCheckpointIsolation(t *testing.T) {
+	ctx := newContext()
+	assert.NoError(t, ctx.AddContextEntry("configmap", []byte(`{"data":{"one":"1","two":"2"},"metadata":{"name":"cm"}}`)))
+	assert.NoError(t, ctx.AddResource(map[string]interface{}{"metadata": map[string]interface{}{"name": "pod"}}))
+	root := ctx.jsonRaw
+
+	ctx.Checkpoint()
+	assert.NoError(t, ctx.AddVariable("configmap.data.three", "3"))
+	assert.NoError(t, ctx.SetTargetResource(map[string]interface{}{"kind": "Pod"}))
+	assert.NoError(t, ctx.AddResource(map[string]interface{}{"metadata": map[string]interface{}{"name": "other"}}))
+	assert.NoError(t, ctx.AddElement("element", 0, 0))
+
+	// the checkpoint is left untouched
+	assert.Equal(t, map[string]interface{}{"one": "1", "two": "2"}, root["configmap"].(map[string]interface{})["data"])
+	assert.Nil(t, root["target"])
+	assert.Nil(t, root["element"])
+	// only the mutated paths are copied
+	assert.False(t, sameMap(ctx.jsonRaw["configmap"], root["configmap"]))
+	assert.True(t, sameMap(ctx.jsonRaw["configmap"].(map[string]interface{})["metadata"], root["configmap"].(map[string]interface{})["metadata"]))
+
+	three, err := ctx.Query("configmap.data.three")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", three)
+
+	ctx.Reset()
+	_, err = ctx.Query("configmap.data.three")
+	assert.Error(t, err)
+	name, err := ctx.Query("request.object.metadata.name")
+	assert.NoError(t, err)
+	assert.Equal(t, "pod", name)
+
+	// nested checkpoints are isolated from each other
+	assert.NoError(t, ctx.AddVariable("configmap.data.one", "one"))
+	ctx.Checkpoint()
+	assert.NoError(t, ctx.AddVariable("configmap.data.one", "uno"))
+	ctx.Restore()
+	one, err := ctx.Query("configmap.data.one")
+	assert.NoError(t, err)
+	assert.Equal(t, "one", one)
+
+	ctx.Restore()
+	one, err = ctx.Query("configmap.data.one")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", one)
+	assert.Nil(t, ctx.layer)
+
+	// without checkpoints the state is mutated in place
+	assert.NoError(t, ctx.AddVariable("configmap.data.one", "one"))
+	assert.Equal(t, "one", root["configmap"].(map[string]interface{})["data"].(map[string]interface{})["one"])
+}
+
+func TestLayerClearLeafValue(t *testing.T) {
+	data := map[string]interface{}{
+		"request": map[string]interface{}{
+			"object":    map[string]interface{}{"name": "pod"},
+			"oldObject": map[string]interface{}{"name": "pod"},
+		},
+	}
+	l := layer{}
+
+	result, found := l.clearLeafValue(data, "request", "missing")
+	assert.True(t, found)
+	assert.True(t, sameMap(data, result))
+
+	result, found = l.clearLeafValue(data, "missing", "object")
+	assert.False(t, found)
+	assert.True(t, sameMap(data, result))
+
+	result, found = l.clearLeafValue(data, "request", "object")
+	assert.True(t, found)
+	assert.NotNil(t, data["request"].(map[string]interface{})["object"])
+	assert.Nil(t, result["request"].(map[string]interface{})["object"])
+	assert.NotNil(t, result["request"].(map[string]interface{})["oldObject"])
+
+	// maps copied by the layer are mutated in place
+	again, found := l.clearLeafValue(result, "request", "oldObject")
+	assert.True(t, found)
+	assert.True(t, sameMap(result, again))
+	assert.Empty(t, again["request"])
+}
+
+func sameMap(a, b interface{}) bool {
+	return fmt.Sprintf("%p", a) == fmt.Sprintf("%p", b)
+}
+
+// deepCopyContext checkpoints the context by copying the whole state, like the context did before copy-on-write
+type deepCopyContext struct {
+	*context
+}
+
+func (ctx deepCopyContext) Checkpoint() {
+	ctx.jsonRawCheckpoints = append(ctx.jsonRawCheckpoints, ctx.copyContext(ctx.jsonRaw))
+}
+
+func (ctx deepCopyContext) Restore() {
+	n := len(ctx.jsonRawCheckpoints) - 1
+	ctx.jsonRaw = ctx.jsonRawCheckpoints[n]
+	ctx.jsonRawCheckpoints = ctx.jsonRawCheckpoints[:n]
+}
+
+func (ctx deepCopyContext) Reset() {
+	ctx.jsonRaw = ctx.copyContext(ctx.jsonRawCheckpoints[len(ctx.jsonRawCheckpoints)-1])
+}
+
+func (ctx deepCopyContext) copyContext(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		if ReservedKeys.MatchString(k) {
+			out[k] = v
+		} else {
+			out[k] = runtime.DeepCopyJSONValue(v)
+		}
+	}
+	return out
+}
+
+// benchmarkContext builds a context holding a pod with the given number of containers and a large context entry
+func benchmarkContext(b *testing.B, containers int) (*context, []interface{}) {
+	var elements []interface{}
+	for i := 0; i < containers; i++ {
+		elements = append(elements, map[string]interface{}{
+			"name":  fmt.Sprintf("container-%d", i),
+			"image": fmt.Sprintf("registry.io/image-%d:latest", i),
+		})
+	}
+	data := map[string]interface{}{}
+	for i := 0; i < 1000; i++ {
+		data[fmt.Sprintf("key-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	ctx := newContext()
+	if err := ctx.AddResource(map[string]interface{}{
+		"kind":     "Pod",
+		"metadata": map[string]interface{}{"name": "pod"},
+		"spec":     map[string]interface{}{"containers": elements},
+	}); err != nil {
+		b.Fatal(err)
+	}
+	if err := ctx.addJSON(map[string]interface{}{"configmap": map[string]interface{}{"data": data}}, false); err != nil {
+		b.Fatal(err)
+	}
+	return ctx, elements
+}
+
+// benchmarkForeach mimics the engine processing a foreach rule over every element
+func benchmarkForeach(b *testing.B, ctx Interface, elements []interface{}) {
+	for i := 0; i < b.N; i++ {
+		ctx.Checkpoint()
+		for index, element := range elements {
+			ctx.Reset()
+			if err := ctx.AddElement(element, index, 0); err != nil {
+				b.Fatal(err)
+			}
+			if err := ctx.AddVariable("image", fmt.Sprintf("image-%d", index)); err != nil {
+				b.Fatal(err)
+			}
+		}
+		ctx.Restore()
+	}
+}
+
+func BenchmarkCheckpointForeach(b *testing.B) {
+	for _, containers := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("copy-on-write/%d", containers), func(b *testing.B) {
+			ctx, elements := benchmarkContext(b, containers)
+			b.ReportAllocs()
+			b.ResetTimer()
+			benchmarkForeach(b, ctx, elements)
+		})
+		b.Run(fmt.Sprintf("deep-copy/%d", containers), func(b *testing.B) {
+			ctx, elements := benchmarkContext(b, containers)
+			b.ReportAllocs()
+			b.ResetTimer()
+			benchmarkForeach(b, deepCopyContext{ctx}, elements)
+		})
+	}
+}
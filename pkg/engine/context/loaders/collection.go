@@ -0,0 +1,65 @@
+package loaders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// CollectionEntryName is the name of the context entry holding the objects of a collection rule
+const CollectionEntryName = "collection"
+
+// CollectionStore serves the objects of the kinds referenced by collection rules
+type CollectionStore interface {
+	// Load returns the list of the objects of the given kinds decoded from JSON.
+	// The returned list can be shared and must not be modified.
+	Load(ctx context.Context, kinds ...string) ([]any, error)
+}
+
+type collectionLoader struct {
+	ctx        context.Context //nolint:containedctx
+	logger     logr.Logger
+	collection kyvernov1.Collection
+	enginectx  enginecontext.Interface
+	store      CollectionStore
+	data       []any
+}
+
+func NewCollectionLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	collection kyvernov1.Collection,
+	enginectx enginecontext.Interface,
+	store CollectionStore,
+) enginecontext.Loader {
+	return &collectionLoader{
+		ctx:        ctx,
+		logger:     logger,
+		collection: collection,
+		enginectx:  enginectx,
+		store:      store,
+	}
+}
+
+func (cl *collectionLoader) HasLoaded() bool {
+	return cl.data != nil
+}
+
+func (cl *collectionLoader) LoadData() error {
+	if cl.data == nil {
+		data, err := cl.store.Load(cl.ctx, cl.collection.Kinds...)
+		if err != nil {
+			return fmt.Errorf("failed to list collection objects: %w", err)
+		}
+		cl.logger.V(6).Info("loaded collection", "kinds", cl.collection.Kinds, "size", len(data))
+		cl.data = data
+	}
+	// the decoded collection is added as is, it is shared with the other rules evaluating it
+	if err := cl.enginectx.AddVariable(CollectionEntryName, cl.data); err != nil {
+		return fmt.Errorf("failed to add collection to the context: %w", err)
+	}
+	return nil
+}
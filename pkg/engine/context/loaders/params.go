@@ -0,0 +1,88 @@
+package loaders
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ParamsEntryName is the name of the context entry holding the policy parameters
+const ParamsEntryName = "params"
+
+// ParamsStore serves the objects bound to policies as parameters.
+// Returned objects can be shared and must not be modified.
+type ParamsStore interface {
+	Get(ctx context.Context, binding kyvernov1.PolicyParams) (*unstructured.Unstructured, error)
+	// Warm prepares the store to serve the object bound to a policy, it doesn't wait for the object to be available
+	Warm(binding kyvernov1.PolicyParams) error
+}
+
+type clientParamsStore struct {
+	client engineapi.ResourceClient
+}
+
+// NewClientParamsStore returns a ParamsStore fetching the parameters objects from the API server on every call,
+// it is meant to be used when no informer backed store is available (CLI, tests).
+func NewClientParamsStore(client engineapi.ResourceClient) ParamsStore {
+	return clientParamsStore{
+		client: client,
+	}
+}
+
+func (s clientParamsStore) Get(ctx context.Context, binding kyvernov1.PolicyParams) (*unstructured.Unstructured, error) {
+	return s.client.GetResource(ctx, binding.APIVersion, binding.Kind, binding.Namespace, binding.Name)
+}
+
+func (s clientParamsStore) Warm(binding kyvernov1.PolicyParams) error {
+	return nil
+}
+
+type paramsLoader struct {
+	ctx       context.Context //nolint:containedctx
+	binding   kyvernov1.PolicyParams
+	enginectx enginecontext.Interface
+	store     ParamsStore
+	data      []byte
+}
+
+func NewParamsLoader(
+	ctx context.Context,
+	binding kyvernov1.PolicyParams,
+	enginectx enginecontext.Interface,
+	store ParamsStore,
+) enginecontext.Loader {
+	return &paramsLoader{
+		ctx:       ctx,
+		binding:   binding,
+		enginectx: enginectx,
+		store:     store,
+	}
+}
+
+func (pl *paramsLoader) HasLoaded() bool {
+	return pl.data != nil
+}
+
+func (pl *paramsLoader) LoadData() error {
+	if pl.data == nil {
+		obj, err := pl.store.Get(pl.ctx, pl.binding)
+		if err != nil {
+			return fmt.Errorf("failed to get policy parameters %s %s/%s: %v", pl.binding.Kind, pl.binding.Namespace, pl.binding.Name, err)
+		}
+		obj = obj.DeepCopy()
+		obj.SetManagedFields(nil)
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to marshal policy parameters: %v", err)
+		}
+		pl.data = data
+	}
+	if err := pl.enginectx.AddContextEntry(ParamsEntryName, pl.data); err != nil {
+		return fmt.Errorf("failed to add policy parameters to the context: %v", err)
+	}
+	return nil
+}
@@ -39,25 +39,8 @@ func addToContext(ctx *context, data interface{}, overwriteMaps bool, tags ...st
 }
 
 func clearLeafValue(data map[string]interface{}, tags ...string) bool {
-	if len(tags) == 0 {
-		return false
-	}
-
-	for i := 0; i < len(tags); i++ {
-		k := tags[i]
-		if i == len(tags)-1 {
-			delete(data, k)
-			return true
-		}
-
-		if nextMap, ok := data[k].(map[string]interface{}); ok {
-			data = nextMap
-		} else {
-			return false
-		}
-	}
-
-	return false
+	_, found := layer(nil).clearLeafValue(data, tags...)
+	return found
 }
 
 // convertStructs converts structs, and pointers-to-structs, to map[string]interface{}
@@ -91,17 +74,7 @@ func push(data interface{}, tags ...string) map[string]interface{} {
 
 // mergeMaps merges srcMap entries into destMap
 func mergeMaps(srcMap, destMap map[string]interface{}, overwriteMaps bool) {
-	for k, v := range srcMap {
-		if nextSrcMap, ok := v.(map[string]interface{}); ok && !overwriteMaps {
-			if nextDestMap, ok := destMap[k].(map[string]interface{}); ok {
-				mergeMaps(nextSrcMap, nextDestMap, overwriteMaps)
-			} else {
-				destMap[k] = nextSrcMap
-			}
-		} else {
-			destMap[k] = v
-		}
-	}
+	layer(nil).mergeMaps(srcMap, destMap, overwriteMaps)
 }
 
 // toUnstructured converts a struct with JSON tags to a map[string]interface{}
@@ -10,10 +10,13 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -30,7 +33,10 @@ type engine struct {
 	configuration        config.Configuration
 	metricsConfiguration config.MetricsConfiguration
 	jp                   jmespath.Interface
+	celCache             enginecel.Cache
+	schemaCache          jsonschema.Cache
 	client               engineapi.Client
+	cmResolver           engineapi.ConfigmapResolver
 	isCluster            bool
 	rclientFactory       engineapi.RegistryClientFactory
 	ivCache              imageverifycache.Client
@@ -47,7 +53,10 @@ func NewEngine(
 	configuration config.Configuration,
 	metricsConfiguration config.MetricsConfiguration,
 	jp jmespath.Interface,
+	celCache enginecel.Cache,
+	schemaCache jsonschema.Cache,
 	client engineapi.Client,
+	cmResolver engineapi.ConfigmapResolver,
 	rclientFactory engineapi.RegistryClientFactory,
 	ivCache imageverifycache.Client,
 	contextLoader engineapi.ContextLoaderFactory,
@@ -77,7 +86,10 @@ func NewEngine(
 		configuration:        configuration,
 		metricsConfiguration: metricsConfiguration,
 		jp:                   jp,
+		celCache:             celCache,
+		schemaCache:          schemaCache,
 		client:               client,
+		cmResolver:           cmResolver,
 		rclientFactory:       rclientFactory,
 		ivCache:              ivCache,
 		isCluster:            *isCluster,
@@ -248,60 +260,92 @@ func (e *engine) invokeRuleHandler(
 		ctx,
 		"pkg/engine",
 		fmt.Sprintf("RULE %s", rule.Name),
-		func(ctx context.Context, span trace.Span) (patchedResource unstructured.Unstructured, results []engineapi.RuleResponse) {
-			// check if resource and rule match
-			if err := e.matches(rule, policyContext, resource); err != nil {
-				logger.V(4).Info("rule not matched", "reason", err.Error())
-				return resource, nil
+		func(ctx context.Context, span trace.Span) (unstructured.Unstructured, []engineapi.RuleResponse) {
+			if !explain.Enabled(ctx) {
+				return e.processRule(ctx, logger, handlerFactory, policyContext, resource, rule, ruleType)
 			}
-			if handlerFactory == nil {
-				return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", nil)
-			} else if handler, err := handlerFactory(); err != nil {
-				return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", err)
-			} else if handler != nil {
-				policyContext.JSONContext().Checkpoint()
-				defer func() {
-					policyContext.JSONContext().Restore()
-					if patchedResource.Object != nil {
-						if err := policyContext.JSONContext().AddResource(patchedResource.Object); err != nil {
-							logger.Error(err, "failed to add resource in the json context")
-						}
-					}
-				}()
-				// load rule context
-				contextLoader := e.ContextLoader(policyContext.Policy(), rule)
-				if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
-					if _, ok := err.(gojmespath.NotFoundError); ok {
-						logger.V(3).Info("failed to load context", "reason", err.Error())
-					} else {
-						logger.Error(err, "failed to load context")
-					}
-					return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
-				}
-				// check preconditions
-				preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
-				if err != nil {
-					return resource, handlers.WithError(rule, ruleType, "failed to evaluate preconditions", err)
-				}
-				if !preconditionsPassed {
-					s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-					return resource, handlers.WithSkip(rule, ruleType, s)
-				}
-				// substitute properties
-				if err := internal.SubstitutePropertiesInRule(logger, &rule, policyContext.JSONContext()); err != nil {
-					logger.Error(err, "failed to substitute variables in rule properties")
-				}
-				// get policy exceptions that matches both policy and rule name
-				exceptions, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
-				if err != nil {
-					logger.Error(err, "failed to get exceptions")
-					return resource, nil
-				}
-				// process handler
-				resource, ruleResponses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, exceptions)
-				return resource, ruleResponses
+			recorder := explain.NewRecorder()
+			patchedResource, results := e.processRule(
+				explain.NewContext(ctx, recorder),
+				logger,
+				handlerFactory,
+				newExplainPolicyContext(policyContext, recorder),
+				resource,
+				rule,
+				ruleType,
+			)
+			for i := range results {
+				results[i] = results[i].WithTrace(recorder.Entries())
 			}
-			return resource, nil
+			return patchedResource, results
 		},
 	)
 }
+
+func (e *engine) processRule(
+	ctx context.Context,
+	logger logr.Logger,
+	handlerFactory handlerFactory,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	ruleType engineapi.RuleType,
+) (patchedResource unstructured.Unstructured, results []engineapi.RuleResponse) {
+	// check if resource and rule match
+	if err := e.matches(rule, policyContext, resource); err != nil {
+		logger.V(4).Info("rule not matched", "reason", err.Error())
+		return resource, nil
+	}
+	if handlerFactory == nil {
+		return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", nil)
+	} else if handler, err := handlerFactory(); err != nil {
+		return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", err)
+	} else if handler != nil {
+		policyContext.JSONContext().Checkpoint()
+		defer func() {
+			policyContext.JSONContext().Restore()
+			if patchedResource.Object != nil {
+				if err := policyContext.JSONContext().AddResource(patchedResource.Object); err != nil {
+					logger.Error(err, "failed to add resource in the json context")
+				}
+			}
+		}()
+		// load rule context
+		contextLoader := e.ContextLoader(policyContext.Policy(), rule)
+		if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
+			if _, ok := err.(gojmespath.NotFoundError); ok {
+				logger.V(3).Info("failed to load context", "reason", err.Error())
+			} else {
+				logger.Error(err, "failed to load context")
+			}
+			return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
+		}
+		// check preconditions
+		preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+		if err != nil {
+			return resource, handlers.WithError(rule, ruleType, "failed to evaluate preconditions", err)
+		}
+		if !preconditionsPassed {
+			s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
+			explain.FromContext(ctx).Record(explain.Precondition, "preconditions", s, nil)
+			return resource, handlers.WithSkip(rule, ruleType, s)
+		}
+		if rule.GetAnyAllConditions() != nil {
+			explain.FromContext(ctx).Record(explain.Precondition, "preconditions", "preconditions passed", nil)
+		}
+		// substitute properties
+		if err := internal.SubstitutePropertiesInRule(logger, &rule, policyContext.JSONContext()); err != nil {
+			logger.Error(err, "failed to substitute variables in rule properties")
+		}
+		// get policy exceptions that matches both policy and rule name
+		exceptions, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
+		if err != nil {
+			logger.Error(err, "failed to get exceptions")
+			return resource, nil
+		}
+		// process handler
+		resource, ruleResponses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, exceptions)
+		return resource, ruleResponses
+	}
+	return resource, nil
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
+)
+
+// explainPolicyContext wraps a policy context so that variable substitutions are recorded
+type explainPolicyContext struct {
+	engineapi.PolicyContext
+	jsonContext enginecontext.Interface
+}
+
+func newExplainPolicyContext(policyContext engineapi.PolicyContext, recorder *explain.Recorder) engineapi.PolicyContext {
+	return explainPolicyContext{
+		PolicyContext: policyContext,
+		jsonContext:   explain.NewJSONContext(policyContext.JSONContext(), recorder),
+	}
+}
+
+func (c explainPolicyContext) JSONContext() enginecontext.Interface {
+	return c.jsonContext
+}
+
+func (c explainPolicyContext) Copy() engineapi.PolicyContext {
+	return explainPolicyContext{
+		PolicyContext: c.PolicyContext.Copy(),
+		jsonContext:   c.jsonContext,
+	}
+}
@@ -0,0 +1,153 @@
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// Kind identifies what a trace entry refers to
+type Kind string
+
+const (
+	// Variable is recorded every time a variable is substituted
+	Variable Kind = "Variable"
+	// Precondition is recorded when rule or foreach preconditions are evaluated
+	Precondition Kind = "Precondition"
+	// Pattern is recorded when a validation pattern does not match the resource
+	Pattern Kind = "Pattern"
+	// Foreach is recorded when a foreach element is skipped
+	Foreach Kind = "Foreach"
+)
+
+// Entry is a single step of a rule evaluation trace
+type Entry struct {
+	// Kind is the kind of evaluation step
+	Kind Kind `json:"kind"`
+	// Path is the location in the rule (or resource for patterns) the entry refers to
+	Path string `json:"path,omitempty"`
+	// Message describes the evaluation step
+	Message string `json:"message"`
+	// Value is the value produced by the evaluation step (if any)
+	Value interface{} `json:"value,omitempty"`
+}
+
+// String implements Stringer interface
+func (e Entry) String() string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(string(e.Kind)))
+	sb.WriteString(": ")
+	sb.WriteString(e.Message)
+	if e.Path != "" {
+		sb.WriteString(" at ")
+		sb.WriteString(e.Path)
+	}
+	if e.Value != nil {
+		sb.WriteString(" = ")
+		if raw, err := json.Marshal(e.Value); err == nil {
+			sb.Write(raw)
+		} else {
+			sb.WriteString(fmt.Sprint(e.Value))
+		}
+	}
+	return sb.String()
+}
+
+// Recorder collects the trace entries of a rule evaluation, a nil recorder discards everything
+type Recorder struct {
+	lock    sync.Mutex
+	entries []Entry
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record appends an entry to the trace
+func (r *Recorder) Record(kind Kind, path, message string, value interface{}) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, Entry{
+		Kind:    kind,
+		Path:    path,
+		Message: message,
+		Value:   value,
+	})
+}
+
+// Entries returns a copy of the recorded entries
+func (r *Recorder) Entries() []Entry {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.entries) == 0 {
+		return nil
+	}
+	entries := make([]Entry, len(r.entries))
+	copy(entries, r.entries)
+	return entries
+}
+
+type (
+	enabledKey  struct{}
+	recorderKey struct{}
+)
+
+// Enable returns a context for which the engine records evaluation traces
+func Enable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, enabledKey{}, true)
+}
+
+// Enabled returns true if evaluation traces should be recorded
+func Enabled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	enabled, _ := ctx.Value(enabledKey{}).(bool)
+	return enabled
+}
+
+// NewContext returns a context carrying the given recorder
+func NewContext(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// FromContext returns the recorder carried by the context, or nil
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+	recorder, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return recorder
+}
+
+type jsonContext struct {
+	enginecontext.Interface
+	recorder *Recorder
+}
+
+// NewJSONContext returns a JSON context carrying the given recorder,
+// variable substitutions happening on the returned context are recorded
+func NewJSONContext(ctx enginecontext.Interface, recorder *Recorder) enginecontext.Interface {
+	return jsonContext{
+		Interface: ctx,
+		recorder:  recorder,
+	}
+}
+
+// FromJSONContext returns the recorder carried by the JSON context, or nil
+func FromJSONContext(ctx enginecontext.EvalInterface) *Recorder {
+	if typed, ok := ctx.(jsonContext); ok {
+		return typed.recorder
+	}
+	return nil
+}
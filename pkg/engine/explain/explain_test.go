@@ -0,0 +1,72 @@
+package explain
+
+import (
+	"context"
+	"testing"
+
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryString(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		want  string
+	}{{
+		name:  "message only",
+		entry: Entry{Kind: Precondition, Message: "preconditions passed"},
+		want:  "precondition: preconditions passed",
+	}, {
+		name:  "with path and value",
+		entry: Entry{Kind: Variable, Path: "/validate/message", Message: "{{ request.object.metadata.name }}", Value: "nginx"},
+		want:  `variable: {{ request.object.metadata.name }} at /validate/message = "nginx"`,
+	}, {
+		name:  "with structured value",
+		entry: Entry{Kind: Variable, Message: "{{ request.object.metadata.labels }}", Value: map[string]interface{}{"app": "nginx"}},
+		want:  `variable: {{ request.object.metadata.labels }} = {"app":"nginx"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.String())
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var nilRecorder *Recorder
+	nilRecorder.Record(Variable, "", "{{ foo }}", "bar")
+	assert.Nil(t, nilRecorder.Entries())
+	recorder := NewRecorder()
+	assert.Nil(t, recorder.Entries())
+	recorder.Record(Variable, "/key", "{{ foo }}", "bar")
+	recorder.Record(Foreach, "request.object.spec.containers[0]", "element skipped", nil)
+	assert.Equal(t, []Entry{
+		{Kind: Variable, Path: "/key", Message: "{{ foo }}", Value: "bar"},
+		{Kind: Foreach, Path: "request.object.spec.containers[0]", Message: "element skipped"},
+	}, recorder.Entries())
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.TODO()
+	assert.False(t, Enabled(ctx))
+	assert.Nil(t, FromContext(ctx))
+	ctx = Enable(ctx)
+	assert.True(t, Enabled(ctx))
+	recorder := NewRecorder()
+	ctx = NewContext(ctx, recorder)
+	assert.Equal(t, recorder, FromContext(ctx))
+}
+
+func TestJSONContext(t *testing.T) {
+	jsonContext := enginecontext.NewContext(jmespath.New(nil))
+	assert.Nil(t, FromJSONContext(jsonContext))
+	recorder := NewRecorder()
+	wrapped := NewJSONContext(jsonContext, recorder)
+	assert.Equal(t, recorder, FromJSONContext(wrapped))
+	assert.NoError(t, wrapped.AddVariable("foo", "bar"))
+	value, err := jsonContext.Query("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+}
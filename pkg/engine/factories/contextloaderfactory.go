@@ -18,11 +18,18 @@ import (
 type ContextLoaderFactoryOptions func(*contextLoader)
 
 func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ...ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
-	return func(_ kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
+	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		cl := &contextLoader{
 			logger:     logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver: cmResolver,
 		}
+		if policy != nil && policy.GetSpec().Params != nil {
+			binding := policy.GetSpec().Params.Binding(policy.GetNamespace())
+			cl.params = &binding
+		}
+		if rule.HasValidateCollection() {
+			cl.collection = rule.Validation.Collection
+		}
 		for _, o := range opts {
 			o(cl)
 		}
@@ -48,12 +55,28 @@ func WithGlobalContextStore(gctxStore loaders.Store) ContextLoaderFactoryOptions
 	}
 }
 
+func WithCollectionStore(collectionStore loaders.CollectionStore) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.collectionStore = collectionStore
+	}
+}
+
+func WithParamsStore(paramsStore loaders.ParamsStore) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.paramsStore = paramsStore
+	}
+}
+
 type contextLoader struct {
-	logger        logr.Logger
-	cmResolver    engineapi.ConfigmapResolver
-	initializers  []engineapi.Initializer
-	apiCallConfig apicall.APICallConfiguration
-	gctxStore     loaders.Store
+	logger          logr.Logger
+	cmResolver      engineapi.ConfigmapResolver
+	initializers    []engineapi.Initializer
+	apiCallConfig   apicall.APICallConfiguration
+	gctxStore       loaders.Store
+	collection      *kyvernov1.Collection
+	collectionStore loaders.CollectionStore
+	params          *kyvernov1.PolicyParams
+	paramsStore     loaders.ParamsStore
 }
 
 func (l *contextLoader) Load(
@@ -69,26 +92,64 @@ func (l *contextLoader) Load(
 			return err
 		}
 	}
+	if l.params != nil {
+		loader, err := l.newParamsLoader(ctx, jsonContext)
+		if err != nil {
+			return fmt.Errorf("failed to create deferred loader for policy parameters")
+		}
+		if err := l.addLoader(ctx, loader, jsonContext); err != nil {
+			return err
+		}
+	}
+	if l.collection != nil {
+		loader, err := l.newCollectionLoader(ctx, jsonContext)
+		if err != nil {
+			return fmt.Errorf("failed to create deferred loader for collection")
+		}
+		if err := l.addLoader(ctx, loader, jsonContext); err != nil {
+			return err
+		}
+	}
 	for _, entry := range contextEntries {
 		loader, err := l.newLoader(ctx, jp, client, rclientFactory, entry, jsonContext, l.gctxStore)
 		if err != nil {
 			return fmt.Errorf("failed to create deferred loader for context entry %s", entry.Name)
 		}
-		if loader != nil {
-			if toggle.FromContext(ctx).EnableDeferredLoading() {
-				if err := jsonContext.AddDeferredLoader(loader); err != nil {
-					return err
-				}
-			} else {
-				if err := loader.LoadData(); err != nil {
-					return err
-				}
-			}
+		if err := l.addLoader(ctx, loader, jsonContext); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+func (l *contextLoader) addLoader(ctx context.Context, loader enginecontext.DeferredLoader, jsonContext enginecontext.Interface) error {
+	if loader == nil {
+		return nil
+	}
+	if toggle.FromContext(ctx).EnableDeferredLoading() {
+		return jsonContext.AddDeferredLoader(loader)
+	}
+	return loader.LoadData()
+}
+
+func (l *contextLoader) newCollectionLoader(ctx context.Context, jsonContext enginecontext.Interface) (enginecontext.DeferredLoader, error) {
+	if l.collectionStore == nil {
+		l.logger.Info("disabled loading of collection", "kinds", l.collection.Kinds)
+		return nil, nil
+	}
+	ldr := loaders.NewCollectionLoader(ctx, l.logger, *l.collection, jsonContext, l.collectionStore)
+	return enginecontext.NewDeferredLoader(loaders.CollectionEntryName, ldr, l.logger)
+}
+
+func (l *contextLoader) newParamsLoader(ctx context.Context, jsonContext enginecontext.Interface) (enginecontext.DeferredLoader, error) {
+	if l.paramsStore == nil {
+		l.logger.Info("disabled loading of policy parameters", "kind", l.params.Kind, "name", l.params.Name)
+		return nil, nil
+	}
+	ldr := loaders.NewParamsLoader(ctx, *l.params, jsonContext, l.paramsStore)
+	return enginecontext.NewDeferredLoader(loaders.ParamsEntryName, ldr, l.logger)
+}
+
 func (l *contextLoader) newLoader(
 	ctx context.Context,
 	jp jmespath.Interface,
@@ -28,7 +28,8 @@ func ForceMutate(
 	// TODO: if we apply autogen, tests will fail
 	spec := policy.GetSpec()
 	for _, rule := range spec.Rules {
-		if !rule.HasMutate() {
+		// CEL mutations need admission attributes and can't be forced
+		if !rule.HasMutate() || rule.HasMutateCEL() {
 			continue
 		}
 
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"testing"
 
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	kyvFuzz "github.com/kyverno/kyverno/pkg/utils/fuzz"
 
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +39,9 @@ var (
 		fuzzCfg,
 		config.NewDefaultMetricsConfiguration(),
 		fuzzJp,
+		enginecel.NewCache(),
+		nil,
+		nil,
 		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(regClient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
@@ -122,6 +126,9 @@ func FuzzVerifyImageAndPatchTest(f *testing.F) {
 			fuzzCfg,
 			fuzzMetricsCfg,
 			fuzzJp,
+			enginecel.NewCache(),
+			nil,
+			nil,
 			nil,
 			factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
 			imageverifycache.DisabledImageVerifyCache(),
@@ -268,7 +275,10 @@ func FuzzMutateTest(f *testing.F) {
 			fuzzCfg,
 			config.NewDefaultMetricsConfiguration(),
 			fuzzJp,
+			enginecel.NewCache(),
+			nil,
 			adapters.Client(fuzzInterface),
+			nil,
 			factories.DefaultRegistryClientFactory(adapters.RegistryClient(nil), nil),
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(nil),
@@ -8,10 +8,12 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/mutate"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -105,6 +107,7 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 
 		if !preconditionsPassed {
 			f.logger.Info("mutate.foreach.preconditions not met", "elementIndex", index, "message", msg)
+			explain.FromContext(ctx).Record(explain.Foreach, fmt.Sprintf("%s[%d]", foreach.List, index), stringutils.JoinNonEmpty([]string{"element skipped: preconditions not met", msg}, "; "), nil)
 			continue
 		}
 
@@ -0,0 +1,224 @@
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/common/types/ref"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/engine/internal"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	celplugin "k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/client-go/tools/cache"
+)
+
+type mutateCELHandler struct {
+	client    engineapi.Client
+	isCluster bool
+	programs  enginecel.Cache
+}
+
+func NewMutateCELHandler(client engineapi.Client, isCluster bool, programs enginecel.Cache) (handlers.Handler, error) {
+	return mutateCELHandler{
+		client:    client,
+		isCluster: isCluster,
+		programs:  programs,
+	}, nil
+}
+
+func (h mutateCELHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+	exceptions []*kyvernov2.PolicyException,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	// check if there are policy exceptions that match the incoming resource
+	matchedExceptions := engineutils.MatchesException(exceptions, policyContext, logger)
+	if len(matchedExceptions) > 0 {
+		var keys []string
+		for i, exception := range matchedExceptions {
+			key, err := cache.MetaNamespaceKeyFunc(&matchedExceptions[i])
+			if err != nil {
+				logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+				return resource, handlers.WithError(rule, engineapi.Mutation, "failed to compute exception key", err)
+			}
+			keys = append(keys, key)
+		}
+
+		logger.V(3).Info("policy rule is skipped due to policy exceptions", "exceptions", keys)
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Mutation, "rule is skipped due to policy exceptions"+strings.Join(keys, ", "), rule.ReportProperties).WithExceptions(matchedExceptions),
+		)
+	}
+
+	// deleted resources can't be mutated
+	if resource.Object == nil {
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Mutation, "no resource to mutate", rule.ReportProperties),
+		)
+	}
+
+	// get the compiled CEL expressions of the rule
+	programs, err := h.programs.Get(policyContext.Policy(), rule)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Mutation, "Error while compiling CEL expressions", err)
+	}
+	// expose the rule context entries to CEL expressions
+	ctx = enginecel.WithRuleContext(ctx, policyContext.JSONContext(), rule.Context)
+
+	gvr := schema.GroupVersionResource(policyContext.RequestResource())
+	gvk, _ := policyContext.ResourceKind()
+	ns, name := resource.GetNamespace(), resource.GetName()
+	var oldObject runtime.Object
+	if oldResource := policyContext.OldResource(); oldResource.Object != nil {
+		oldObject = oldResource.DeepCopyObject()
+	}
+
+	// Special case, the namespace object has the namespace of itself.
+	// unset it if the incoming object is a namespace
+	if gvk.Kind == "Namespace" && gvk.Version == "v1" && gvk.Group == "" {
+		ns = ""
+	}
+	var namespace *corev1.Namespace
+	if ns != "" {
+		if h.client != nil && h.isCluster {
+			namespace, err = h.client.GetNamespace(ctx, ns, metav1.GetOptions{})
+			if err != nil {
+				return resource, handlers.WithResponses(
+					engineapi.RuleError(rule.Name, engineapi.Mutation, "Error getting the resource's namespace", err, rule.ReportProperties),
+				)
+			}
+		} else {
+			namespace = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ns,
+				},
+			}
+		}
+	}
+
+	requestInfo := policyContext.AdmissionInfo()
+	userInfo := internal.NewUser(requestInfo.AdmissionUserInfo.Username, requestInfo.AdmissionUserInfo.UID, requestInfo.AdmissionUserInfo.Groups)
+	authorizer := internal.NewAuthorizer(h.client, gvk)
+	o := admission.NewObjectInterfacesFromScheme(runtime.NewScheme())
+	// attributes are built for every mutation, each mutation sees the object modified by the previous ones
+	attributes := func(object runtime.Object) (*admission.VersionedAttributes, error) {
+		attr := admission.NewAttributesRecord(object, oldObject, gvk, ns, name, gvr, "", admission.Operation(policyContext.Operation()), nil, false, &userInfo)
+		return admission.NewVersionedAttributes(attr, attr.GetKind(), o)
+	}
+
+	versionedAttr, err := attributes(resource.DeepCopyObject())
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Mutation, "error while creating versioned attributes", err)
+	}
+	matcher := matchconditions.NewMatcher(programs.MatchConditions, nil, policyContext.Policy().GetKind(), "", policyContext.Policy().GetName())
+	if result := matcher.Match(ctx, versionedAttr, nil, &authorizer); result.Error != nil {
+		return resource, handlers.WithError(rule, engineapi.Mutation, "failed to evaluate cel preconditions", result.Error)
+	} else if !result.Matches {
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Mutation, "cel preconditions not met", rule.ReportProperties),
+		)
+	}
+
+	patchedResource := resource.DeepCopy()
+	for i, program := range programs.Mutations {
+		mutation := rule.Mutation.CEL.Mutations[i]
+		versionedAttr, err := attributes(patchedResource.DeepCopyObject())
+		if err != nil {
+			return resource, handlers.WithError(rule, engineapi.Mutation, "error while creating versioned attributes", err)
+		}
+		request := celplugin.CreateAdmissionRequest(versionedAttr.Attributes, metav1.GroupVersionResource(gvr), metav1.GroupVersionKind(gvk))
+		results, _, err := program.ForInput(ctx, versionedAttr, request, celplugin.OptionalVariableBindings{Authorizer: &authorizer}, celplugin.CreateNamespaceObject(namespace), celconfig.RuntimeCELCostBudget)
+		if err != nil {
+			return resource, handlers.WithError(rule, engineapi.Mutation, fmt.Sprintf("failed to evaluate mutation %d", i), err)
+		}
+		if len(results) != 1 {
+			return resource, handlers.WithError(rule, engineapi.Mutation, fmt.Sprintf("failed to evaluate mutation %d", i), fmt.Errorf("expected one result, got %d", len(results)))
+		}
+		if results[0].Error != nil {
+			return resource, handlers.WithError(rule, engineapi.Mutation, fmt.Sprintf("failed to evaluate mutation %d", i), results[0].Error)
+		}
+		patched, err := applyCELPatch(logger, mutation.PatchType, results[0].EvalResult, patchedResource)
+		if err != nil {
+			return resource, handlers.WithError(rule, engineapi.Mutation, fmt.Sprintf("failed to apply mutation %d", i), err)
+		}
+		patchedResource = patched
+	}
+
+	if datautils.DeepEqual(resource.Object, patchedResource.Object) {
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Mutation, "no patches applied", rule.ReportProperties),
+		)
+	}
+	return *patchedResource, handlers.WithResponses(
+		engineapi.RulePass(rule.Name, engineapi.Mutation, buildSuccessMessage(*patchedResource), rule.ReportProperties),
+	)
+}
+
+// applyCELPatch applies the result of a CEL mutation expression to a resource.
+// Apply configurations are merged with server side apply semantics, Kyverno anchors are not interpreted.
+// JSON patches must be a list of operations.
+func applyCELPatch(logger logr.Logger, patchType kyvernov1.PatchType, result ref.Val, resource *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	value, err := result.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert CEL result (%w)", err)
+	}
+	native := value.(*structpb.Value).AsInterface()
+	resourceBytes, err := resource.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var patchedBytes []byte
+	switch patchType {
+	case kyvernov1.PatchTypeApplyConfiguration:
+		applyConfiguration, ok := native.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("an apply configuration must evaluate to an object, got %T", native)
+		}
+		return patch.ApplyConfiguration(resource, applyConfiguration)
+	case kyvernov1.PatchTypeJSONPatch:
+		if _, ok := native.([]interface{}); !ok {
+			return nil, fmt.Errorf("a JSON patch must evaluate to a list of operations, got %T", native)
+		}
+		patchBytes, err := json.Marshal(native)
+		if err != nil {
+			return nil, err
+		}
+		patchedBytes, err = patch.ProcessPatchJSON6902(logger, patchBytes, resourceBytes)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", patchType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var patched unstructured.Unstructured
+	if err := patched.UnmarshalJSON(patchedBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched resource (%w)", err)
+	}
+	return &patched, nil
+}
@@ -0,0 +1,101 @@
+package mutation
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/common/types"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_applyCELPatch(t *testing.T) {
+	newResource := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":   "nginx",
+				"labels": map[string]any{"app": "nginx", "tier": "web"},
+			},
+			"spec": map[string]any{
+				"containers": []any{
+					map[string]any{"name": "nginx", "image": "nginx"},
+					map[string]any{"name": "sidecar", "image": "envoy"},
+				},
+			},
+		}}
+	}
+	tests := []struct {
+		name      string
+		patchType kyvernov1.PatchType
+		result    any
+		want      func(*unstructured.Unstructured)
+		wantErr   string
+	}{{
+		name:      "apply configuration merges maps",
+		patchType: kyvernov1.PatchTypeApplyConfiguration,
+		result:    map[string]any{"metadata": map[string]any{"labels": map[string]any{"env": "prod"}}},
+		want: func(u *unstructured.Unstructured) {
+			_ = unstructured.SetNestedField(u.Object, "prod", "metadata", "labels", "env")
+		},
+	}, {
+		name:      "apply configuration merges lists by key and ignores null fields",
+		patchType: kyvernov1.PatchTypeApplyConfiguration,
+		result: map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{"tier": nil}},
+			"spec":     map[string]any{"containers": []any{map[string]any{"name": "nginx", "image": "nginx:1.27"}}},
+		},
+		want: func(u *unstructured.Unstructured) {
+			_ = unstructured.SetNestedSlice(u.Object, []any{
+				map[string]any{"name": "nginx", "image": "nginx:1.27"},
+				map[string]any{"name": "sidecar", "image": "envoy"},
+			}, "spec", "containers")
+		},
+	}, {
+		name:      "apply configuration can't set atomic lists",
+		patchType: kyvernov1.PatchTypeApplyConfiguration,
+		result: map[string]any{
+			"spec": map[string]any{"containers": []any{map[string]any{"name": "nginx", "args": []any{"--debug"}}}},
+		},
+		wantErr: "may not mutate atomic arrays, maps or structs: .spec.containers[0].args",
+	}, {
+		name:      "apply configuration doesn't interpret anchors",
+		patchType: kyvernov1.PatchTypeApplyConfiguration,
+		result:    map[string]any{"metadata": map[string]any{"labels": map[string]any{"+(app)": "httpd"}}},
+		want: func(u *unstructured.Unstructured) {
+			_ = unstructured.SetNestedField(u.Object, "httpd", "metadata", "labels", "+(app)")
+		},
+	}, {
+		name:      "apply configuration must be an object",
+		patchType: kyvernov1.PatchTypeApplyConfiguration,
+		result:    []any{},
+		wantErr:   "an apply configuration must evaluate to an object",
+	}, {
+		name:      "json patch",
+		patchType: kyvernov1.PatchTypeJSONPatch,
+		result:    []any{map[string]any{"op": "replace", "path": "/metadata/labels/app", "value": "httpd"}},
+		want: func(u *unstructured.Unstructured) {
+			_ = unstructured.SetNestedField(u.Object, "httpd", "metadata", "labels", "app")
+		},
+	}, {
+		name:      "json patch must be a list",
+		patchType: kyvernov1.PatchTypeJSONPatch,
+		result:    map[string]any{},
+		wantErr:   "a JSON patch must evaluate to a list of operations",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := applyCELPatch(logr.Discard(), tt.patchType, types.DefaultTypeAdapter.NativeToValue(tt.result), newResource())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			expected := newResource()
+			tt.want(expected)
+			assert.Equal(t, expected.Object, patched.Object)
+		})
+	}
+}
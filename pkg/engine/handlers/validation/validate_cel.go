@@ -9,12 +9,11 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
-	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
-	vaputils "github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,7 +21,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/admission"
-	"k8s.io/apiserver/pkg/admission/plugin/cel"
 	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
 	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
 	celconfig "k8s.io/apiserver/pkg/apis/cel"
@@ -32,12 +30,14 @@ import (
 type validateCELHandler struct {
 	client    engineapi.Client
 	isCluster bool
+	programs  enginecel.Cache
 }
 
-func NewValidateCELHandler(client engineapi.Client, isCluster bool) (handlers.Handler, error) {
+func NewValidateCELHandler(client engineapi.Client, isCluster bool, programs enginecel.Cache) (handlers.Handler, error) {
 	return validateCELHandler{
 		client:    client,
 		isCluster: isCluster,
+		programs:  programs,
 	}, nil
 }
 
@@ -103,37 +103,19 @@ func (h validateCELHandler) Process(
 		object = resource.DeepCopyObject()
 	}
 
-	// check if the rule uses parameter resources
-	hasParam := rule.Validation.CEL.HasParam()
-	// extract preconditions written as CEL expressions
-	matchConditions := rule.CELPreconditions
-	// extract CEL expressions used in validations and audit annotations
-	variables := rule.Validation.CEL.Variables
-	validations := rule.Validation.CEL.Expressions
-	for i := range validations {
-		if validations[i].Message == "" {
-			validations[i].Message = rule.Validation.Message
-		}
-	}
-	auditAnnotations := rule.Validation.CEL.AuditAnnotations
-
-	optionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: true}
-	expressionOptionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: false}
-	// compile CEL expressions
-	compiler, err := celutils.NewCompiler(validations, auditAnnotations, vaputils.ConvertMatchConditionsV1(matchConditions), variables)
+	// get the compiled CEL expressions of the rule
+	programs, err := h.programs.Get(policyContext.Policy(), rule)
 	if err != nil {
-		return resource, handlers.WithError(rule, engineapi.Validation, "Error while creating composited compiler", err)
+		return resource, handlers.WithError(rule, engineapi.Validation, "Error while compiling CEL expressions", err)
 	}
-	compiler.CompileVariables(optionalVars)
-	filter := compiler.CompileValidateExpressions(optionalVars)
-	messageExpressionfilter := compiler.CompileMessageExpressions(expressionOptionalVars)
-	auditAnnotationFilter := compiler.CompileAuditAnnotationsExpressions(optionalVars)
-	matchConditionFilter := compiler.CompileMatchExpressions(optionalVars)
+	// expose the rule context entries to CEL expressions
+	ctx = enginecel.WithRuleContext(ctx, policyContext.JSONContext(), rule.Context)
+	hasParam := programs.HasParams
 
 	// newMatcher will be used to check if the incoming resource matches the CEL preconditions
-	newMatcher := matchconditions.NewMatcher(matchConditionFilter, nil, policyKind, "", policyName)
+	newMatcher := matchconditions.NewMatcher(programs.MatchConditions, nil, policyKind, "", policyName)
 	// newValidator will be used to validate CEL expressions against the incoming object
-	validator := validating.NewValidator(filter, newMatcher, auditAnnotationFilter, messageExpressionfilter, nil)
+	validator := validating.NewValidator(programs.Validations, newMatcher, programs.AuditAnnotations, programs.Messages, nil)
 
 	var namespace *corev1.Namespace
 	// Special case, the namespace object has the namespace of itself.
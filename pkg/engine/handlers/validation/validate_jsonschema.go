@@ -0,0 +1,247 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	gojmespath "github.com/kyverno/go-jmespath"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
+	"github.com/xeipuuv/gojsonschema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type validateJSONSchemaHandler struct {
+	client         engineapi.Client
+	cmResolver     engineapi.ConfigmapResolver
+	rclientFactory engineapi.RegistryClientFactory
+	schemas        jsonschema.Cache
+}
+
+// NewValidateJSONSchemaHandler returns a handler validating resources against JSON schemas.
+// ConfigMaps are read from the resolver when one is given, compiled schemas are only cached when a cache is given.
+func NewValidateJSONSchemaHandler(
+	client engineapi.Client,
+	cmResolver engineapi.ConfigmapResolver,
+	rclientFactory engineapi.RegistryClientFactory,
+	schemas jsonschema.Cache,
+) (handlers.Handler, error) {
+	return validateJSONSchemaHandler{
+		client:         client,
+		cmResolver:     cmResolver,
+		rclientFactory: rclientFactory,
+		schemas:        schemas,
+	}, nil
+}
+
+func (h validateJSONSchemaHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+	exceptions []*kyvernov2.PolicyException,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	// check if there are policy exceptions that match the incoming resource
+	matchedExceptions := engineutils.MatchesException(exceptions, policyContext, logger)
+	if len(matchedExceptions) > 0 {
+		var keys []string
+		for i, exception := range matchedExceptions {
+			key, err := cache.MetaNamespaceKeyFunc(&matchedExceptions[i])
+			if err != nil {
+				logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+				return resource, handlers.WithError(rule, engineapi.Validation, "failed to compute exception key", err)
+			}
+			keys = append(keys, key)
+		}
+		logger.V(3).Info("policy rule is skipped due to policy exceptions", "exceptions", keys)
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Validation, "rule is skipped due to policy exceptions"+strings.Join(keys, ", "), rule.ReportProperties).WithExceptions(matchedExceptions),
+		)
+	}
+	// select the document to validate
+	jsonSchema := rule.Validation.JSONSchema
+	selector := jsonSchema.GetSelector()
+	value, err := policyContext.JSONContext().Query(selector)
+	if err != nil {
+		var notFound gojmespath.NotFoundError
+		if !errors.As(err, &notFound) {
+			return resource, handlers.WithError(rule, engineapi.Validation, "failed to evaluate selector", err)
+		}
+	}
+	if value == nil {
+		logger.V(3).Info("selector returned no document, skipping", "selector", selector)
+		return resource, handlers.WithSkip(rule, engineapi.Validation, fmt.Sprintf("selector %s returned no document", selector))
+	}
+	document, err := jsonschema.Document(value)
+	if err != nil {
+		return resource, handlers.WithFail(rule, engineapi.Validation, h.buildMessage(logger, policyContext, rule, fmt.Sprintf("document %s is not valid JSON or YAML: %s", selector, err)))
+	}
+	// load the schema and validate the document
+	schema, err := h.loadSchema(ctx, policyContext, rule.Name, *jsonSchema)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to load JSON schema", err)
+	}
+	violations, err := jsonschema.Validate(schema, document)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to validate JSON schema", err)
+	}
+	if len(violations) != 0 {
+		msg := fmt.Sprintf("document %s does not match the JSON schema: %s", selector, strings.Join(violations, "; "))
+		return resource, handlers.WithFail(rule, engineapi.Validation, h.buildMessage(logger, policyContext, rule, msg))
+	}
+	msg := fmt.Sprintf("Validation rule '%s' passed.", rule.Name)
+	return resource, handlers.WithPass(rule, engineapi.Validation, msg)
+}
+
+// loadSchema returns the compiled schema of a rule. External schemas are identified by the ConfigMap resource version
+// or the OCI digest so that they are only fetched and compiled again when they change.
+func (h validateJSONSchemaHandler) loadSchema(ctx context.Context, policyContext engineapi.PolicyContext, rule string, jsonSchema kyvernov1.JSONSchema) (*gojsonschema.Schema, error) {
+	switch {
+	case jsonSchema.Schema != nil:
+		return h.getSchema(policyContext, rule, "inline", func() (*gojsonschema.Schema, error) {
+			return jsonschema.Compile(jsonSchema.Schema.Raw)
+		})
+	case jsonSchema.ConfigMap != nil:
+		ref := jsonSchema.ConfigMap.Binding(policyContext.Policy().GetNamespace())
+		data, resourceVersion, err := h.getConfigMapData(ctx, ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in ConfigMap %s/%s", ref.Key, ref.Namespace, ref.Name)
+		}
+		source := ""
+		if resourceVersion != "" {
+			source = "configmap:" + ref.Namespace + "/" + ref.Name + "/" + ref.Key + "@" + resourceVersion
+		}
+		return h.getSchema(policyContext, rule, source, func() (*gojsonschema.Schema, error) {
+			return jsonschema.Compile([]byte(value))
+		})
+	case jsonSchema.OCI != nil:
+		if h.rclientFactory == nil {
+			return nil, errors.New("a registry client is required to load the schema from an OCI artifact")
+		}
+		rclient, err := h.rclientFactory.GetClient(ctx, jsonSchema.OCI.ImageRegistryCredentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get registry client: %w", err)
+		}
+		ref := jsonSchema.OCI.Reference
+		var desc *remote.Descriptor
+		fetch := func(ref string) error {
+			if desc != nil {
+				return nil
+			}
+			fetched, err := rclient.FetchImageDescriptor(ctx, ref)
+			if err != nil {
+				return fmt.Errorf("failed to fetch %s: %w", ref, err)
+			}
+			desc = fetched
+			return nil
+		}
+		// references pinned by digest don't need to be resolved, tags are resolved at most once per cache TTL
+		// and the schema is then fetched by digest
+		pinned := ref
+		digest := ""
+		if parsed, err := name.NewDigest(ref); err == nil {
+			digest = parsed.DigestStr()
+		} else {
+			parsed, err := name.ParseReference(ref)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reference %s: %w", ref, err)
+			}
+			digest, err = h.resolveDigest(ref, func() (string, error) {
+				if err := fetch(ref); err != nil {
+					return "", err
+				}
+				return desc.Digest.String(), nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			pinned = parsed.Context().Digest(digest).String()
+		}
+		return h.getSchema(policyContext, rule, "oci:"+ref+"@"+digest, func() (*gojsonschema.Schema, error) {
+			if err := fetch(pinned); err != nil {
+				return nil, err
+			}
+			img, err := desc.Image()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read image %s: %w", ref, err)
+			}
+			data, err := jsonschema.Load(img)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load schema from %s: %w", ref, err)
+			}
+			return jsonschema.Compile(data)
+		})
+	}
+	return nil, errors.New("one of schema, configMap or oci must be specified")
+}
+
+func (h validateJSONSchemaHandler) getSchema(policyContext engineapi.PolicyContext, rule string, source string, load func() (*gojsonschema.Schema, error)) (*gojsonschema.Schema, error) {
+	policy := policyContext.Policy()
+	if h.schemas == nil || policy == nil {
+		return load()
+	}
+	return h.schemas.Get(policy, rule, source, load)
+}
+
+// resolveDigest returns the digest a tagged reference points to, from the cache when there is one
+func (h validateJSONSchemaHandler) resolveDigest(ref string, resolve func() (string, error)) (string, error) {
+	if h.schemas == nil {
+		return resolve()
+	}
+	return h.schemas.Digest(ref, resolve)
+}
+
+// getConfigMapData returns the data and resource version of a ConfigMap, from the resolver if there is one
+func (h validateJSONSchemaHandler) getConfigMapData(ctx context.Context, namespace, name string) (map[string]string, string, error) {
+	if h.cmResolver != nil {
+		cm, err := h.cmResolver.Get(ctx, namespace, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return cm.Data, cm.ResourceVersion, nil
+	}
+	if h.client == nil {
+		return nil, "", errors.New("a client is required to load the schema from a ConfigMap")
+	}
+	obj, err := h.client.GetResource(ctx, "v1", "ConfigMap", namespace, name)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read data of ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return data, obj.GetResourceVersion(), nil
+}
+
+// buildMessage prepends the rule message, variables are only substituted in the rule message
+// as violations can contain user provided content.
+func (h validateJSONSchemaHandler) buildMessage(logger logr.Logger, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, msg string) string {
+	message := rule.Validation.Message
+	if message != "" {
+		if raw, err := variables.SubstituteAll(logger, policyContext.JSONContext(), message); err == nil {
+			if typed, ok := raw.(string); ok {
+				message = typed
+			}
+		}
+	}
+	return stringutils.JoinNonEmpty([]string{message, msg}, "; ")
+}
@@ -11,6 +11,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
@@ -139,7 +140,7 @@ func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
 			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "variable substitution failed", err, v.rule.ReportProperties)
 		}
 
-		ruleResponse = v.validateResourceWithRule()
+		ruleResponse = v.validateResourceWithRule(ctx)
 	} else if v.forEach != nil {
 		ruleResponse = v.validateForEach(ctx)
 	} else {
@@ -263,6 +264,7 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 		status := r.Status()
 		if status == engineapi.RuleStatusSkip {
 			v.log.V(2).Info("skip rule", "reason", r.Message())
+			explain.FromContext(ctx).Record(explain.Foreach, fmt.Sprintf("%s[%d]", foreach.List, index), "element skipped: "+r.Message(), nil)
 			continue
 		} else if status != engineapi.RuleStatusPass {
 			if status == engineapi.RuleStatusError {
@@ -328,26 +330,28 @@ func (v *validator) getDenyMessage(deny bool, msg string) string {
 	}
 }
 
-func (v *validator) validateResourceWithRule() *engineapi.RuleResponse {
+func (v *validator) validateResourceWithRule(ctx context.Context) *engineapi.RuleResponse {
 	element := v.policyContext.Element()
 	if !engineutils.IsEmptyUnstructured(&element) {
-		return v.validatePatterns(element)
+		return v.validatePatterns(ctx, element)
 	}
 	if engineutils.IsDeleteRequest(v.policyContext) {
 		v.log.V(3).Info("skipping validation on deleted resource")
 		return nil
 	}
-	resp := v.validatePatterns(v.policyContext.NewResource())
+	resp := v.validatePatterns(ctx, v.policyContext.NewResource())
 	return resp
 }
 
 // validatePatterns validate pattern and anyPattern
-func (v *validator) validatePatterns(resource unstructured.Unstructured) *engineapi.RuleResponse {
+func (v *validator) validatePatterns(ctx context.Context, resource unstructured.Unstructured) *engineapi.RuleResponse {
+	recorder := explain.FromContext(ctx)
 	if v.pattern != nil {
 		if err := validate.MatchPattern(v.log, resource.Object, v.pattern); err != nil {
 			pe, ok := err.(*validate.PatternError)
 			if ok {
 				v.log.V(3).Info("validation error", "path", pe.Path, "error", err.Error())
+				recordPatternError(recorder, "pattern", pe)
 
 				if pe.Skip {
 					return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, pe.Error(), v.rule.ReportProperties)
@@ -388,6 +392,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 			if pe, ok := err.(*validate.PatternError); ok {
 				var patternErr error
 				v.log.V(3).Info("validation rule failed", "anyPattern[%d]", idx, "path", pe.Path)
+				recordPatternError(recorder, fmt.Sprintf("anyPattern[%d]", idx), pe)
 
 				if pe.Skip {
 					patternErr = fmt.Errorf("rule %s[%d] skipped: %s", v.rule.Name, idx, err.Error())
@@ -426,6 +431,23 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 	return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.rule.Validation.Message, v.rule.ReportProperties)
 }
 
+// recordPatternError adds the pattern error to the evaluation trace, including the anchor responsible for it
+func recordPatternError(recorder *explain.Recorder, pattern string, pe *validate.PatternError) {
+	if recorder == nil {
+		return
+	}
+	verb := "failed"
+	if pe.Skip {
+		verb = "skipped"
+	}
+	msg := fmt.Sprintf("%s %s: %s", pattern, verb, pe.Error())
+	if pe.Anchor != "" {
+		msg = fmt.Sprintf("%s %s by anchor %s: %s", pattern, verb, pe.Anchor, pe.Error())
+	}
+	// the error message already contains the resource path
+	recorder.Record(explain.Pattern, "", msg, nil)
+}
+
 func deserializeAnyPattern(anyPattern apiextensions.JSON) ([]interface{}, error) {
 	if anyPattern == nil {
 		return nil, nil
@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
@@ -319,6 +320,9 @@ func testVerifyAndPatchImages(
 		cfg,
 		metricsCfg,
 		jp,
+		enginecel.NewCache(),
+		nil,
+		nil,
 		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
@@ -1055,6 +1059,9 @@ func testImageVerifyCache(
 		cfg,
 		metricsCfg,
 		jp,
+		enginecel.NewCache(),
+		nil,
+		nil,
 		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		ivCache,
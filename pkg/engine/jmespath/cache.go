@@ -0,0 +1,67 @@
+package jmespath
+
+import (
+	"context"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+	"k8s.io/utils/lru"
+)
+
+// meterName must match metrics.MeterName, pkg/metrics can't be imported here without an import cycle
+const meterName = "kyverno"
+
+// DefaultCacheSize is the maximum number of compiled queries kept in the cache
+const DefaultCacheSize = 4096
+
+var (
+	cacheHit  = sdkmetric.WithAttributes(attribute.String("result", "hit"))
+	cacheMiss = sdkmetric.WithAttributes(attribute.String("result", "miss"))
+)
+
+// queryCache is a bounded cache of compiled queries, safe for concurrent use.
+// Queries that fail to compile are not cached.
+type queryCache struct {
+	queries *lru.Cache
+	lookups sdkmetric.Int64Counter
+}
+
+func newQueryCache(size int) *queryCache {
+	logger := logging.WithName("jmespath")
+	meter := otel.GetMeterProvider().Meter(meterName)
+	lookups, err := meter.Int64Counter(
+		"kyverno_jmespath_query_cache_lookups",
+		sdkmetric.WithDescription("track the number of compiled JMESPath query cache lookups, by result (hit or miss)"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_jmespath_query_cache_lookups")
+	}
+	return &queryCache{
+		queries: lru.New(size),
+		lookups: lookups,
+	}
+}
+
+// compile returns the compiled query, compiling it only if it is not cached yet
+func (c *queryCache) compile(query string) (*gojmespath.JMESPath, error) {
+	if cached, ok := c.queries.Get(query); ok {
+		c.record(cacheHit)
+		return cached.(*gojmespath.JMESPath), nil
+	}
+	c.record(cacheMiss)
+	compiled, err := gojmespath.Compile(query)
+	if err != nil {
+		return nil, err
+	}
+	c.queries.Add(query, compiled)
+	return compiled, nil
+}
+
+func (c *queryCache) record(result sdkmetric.AddOption) {
+	if c.lookups != nil {
+		c.lookups.Add(context.Background(), 1, result)
+	}
+}
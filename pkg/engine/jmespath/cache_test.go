@@ -0,0 +1,74 @@
+package jmespath
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_queryCache(t *testing.T) {
+	cache := newQueryCache(2)
+	first, err := cache.compile("a.b")
+	assert.NilError(t, err)
+	second, err := cache.compile("a.b")
+	assert.NilError(t, err)
+	// the same compiled query is returned from the cache
+	assert.Equal(t, first, second)
+	assert.Equal(t, cache.queries.Len(), 1)
+}
+
+func Test_queryCache_Bounded(t *testing.T) {
+	cache := newQueryCache(2)
+	for i := 0; i < 10; i++ {
+		_, err := cache.compile(fmt.Sprintf("a[%d]", i))
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, cache.queries.Len(), 2)
+	_, ok := cache.queries.Get("a[0]")
+	assert.Assert(t, !ok)
+	_, ok = cache.queries.Get("a[9]")
+	assert.Assert(t, ok)
+}
+
+func Test_queryCache_InvalidQuery(t *testing.T) {
+	cache := newQueryCache(2)
+	_, err := cache.compile("a.[")
+	assert.Assert(t, err != nil)
+	assert.Equal(t, cache.queries.Len(), 0)
+}
+
+func Test_queryCache_Concurrent(t *testing.T) {
+	cache := newQueryCache(8)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				query := fmt.Sprintf("a[%d]", (i+j)%12)
+				compiled, err := cache.compile(query)
+				assert.Check(t, err == nil)
+				result, err := compiled.Search(map[string]interface{}{"a": []interface{}{0.0, 1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0, 11.0}})
+				assert.Check(t, err == nil)
+				assert.Check(t, result == float64((i+j)%12))
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Assert(t, cache.queries.Len() <= 8)
+}
+
+func Test_Precompile(t *testing.T) {
+	jp := jmespathInterface.(implementation)
+	err := jp.Precompile("a.b", "to_upper(a)", "a.[")
+	assert.ErrorContains(t, err, `failed to compile JMESPath query "a.["`)
+	_, ok := jp.cache.queries.Get("to_upper(a)")
+	assert.Assert(t, ok)
+	_, ok = jp.cache.queries.Get("a.[")
+	assert.Assert(t, !ok)
+	result, err := jp.Search("to_upper(a)", map[string]interface{}{"a": "foo"})
+	assert.NilError(t, err)
+	assert.Equal(t, result, "FOO")
+}
@@ -34,6 +34,7 @@ import (
 	regen "github.com/zach-klippenstein/goregen"
 	"golang.org/x/crypto/cryptobyte"
 	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/yaml"
 )
 
@@ -85,7 +86,13 @@ var (
 	MD5                    = "md5"
 )
 
+// GetFunctions returns the built-in functions followed by the user-defined functions declared in the configuration
 func GetFunctions(configuration config.Configuration) []FunctionEntry {
+	functions := getFunctions(configuration, clock.RealClock{})
+	return append(functions, getUserFunctions(configuration, functions)...)
+}
+
+func getFunctions(configuration config.Configuration, clock clock.PassiveClock) []FunctionEntry {
 	return []FunctionEntry{{
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: compare,
@@ -358,21 +365,21 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 				{Types: []jpType{jpString}},
 				{Types: []jpType{jpString}},
 			},
-			Handler: jpTimeSince,
+			Handler: jpTimeSince(clock),
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "calculate the difference between a start and end period of time where the end may either be a static definition or the then-current time",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name:    timeNow,
-			Handler: jpTimeNow,
+			Handler: jpTimeNow(clock),
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "returns current time in RFC 3339 format",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name:    timeNowUtc,
-			Handler: jpTimeNowUtc,
+			Handler: jpTimeNowUtc(clock),
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "returns current UTC time in RFC 3339 format",
@@ -1185,7 +1192,7 @@ func jpRandom(arguments []interface{}) (interface{}, error) {
 	return ans, nil
 }
 
-func encode[T any](in T) (interface{}, error) {
+func encode[T any](in T) (map[string]interface{}, error) {
 	buf := new(bytes.Buffer)
 	enc := json.NewEncoder(buf)
 	if err := enc.Encode(in); err != nil {
@@ -1199,6 +1206,15 @@ func encode[T any](in T) (interface{}, error) {
 }
 
 func jpX509Decode(arguments []interface{}) (interface{}, error) {
+	input, err := validateArg(x509_decode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	return X509Decode(input.String())
+}
+
+// X509Decode decodes a PEM encoded certificate or certificate request using the same representation as the x509_decode function.
+func X509Decode(input string) (map[string]interface{}, error) {
 	parseSubjectPublicKeyInfo := func(data []byte) (*rsa.PublicKey, error) {
 		spki := cryptobyte.String(data)
 		if !spki.ReadASN1(&spki, cryptobyte_asn1.SEQUENCE) {
@@ -1218,15 +1234,13 @@ func jpX509Decode(arguments []interface{}) (interface{}, error) {
 			return kk, nil
 		}
 	}
-	if input, err := validateArg(x509_decode, arguments, 0, reflect.String); err != nil {
-		return nil, err
-	} else if block, _ := pem.Decode([]byte(input.String())); block == nil {
+	if block, _ := pem.Decode([]byte(input)); block == nil {
 		return nil, errors.New("failed to decode PEM block")
 	} else {
 		switch block.Type {
 		case "CERTIFICATE":
-			var cert *x509.Certificate
-			if cert, err = x509.ParseCertificate(block.Bytes); err != nil {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
 				return nil, err
 			} else if cert.PublicKeyAlgorithm != x509.RSA {
 				return nil, errors.New("certificate should use rsa algorithm")
@@ -1240,8 +1254,8 @@ func jpX509Decode(arguments []interface{}) (interface{}, error) {
 				return encode(cert)
 			}
 		case "CERTIFICATE REQUEST":
-			var csr *x509.CertificateRequest
-			if csr, err = x509.ParseCertificateRequest(block.Bytes); err != nil {
+			csr, err := x509.ParseCertificateRequest(block.Bytes)
+			if err != nil {
 				return nil, err
 			} else if csr.PublicKeyAlgorithm != x509.RSA {
 				return nil, errors.New("certificate should use rsa algorithm")
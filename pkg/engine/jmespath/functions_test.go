@@ -11,7 +11,7 @@ import (
 	"gotest.tools/assert"
 )
 
-var jmespathInterface = newImplementation(config.NewDefaultConfiguration(false))
+var jmespathInterface = New(config.NewDefaultConfiguration(false))
 
 func Test_Compare(t *testing.T) {
 	testCases := []struct {
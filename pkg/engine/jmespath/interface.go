@@ -1,8 +1,13 @@
 package jmespath
 
 import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
 	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/utils/clock"
 )
 
 type Query interface {
@@ -12,20 +17,52 @@ type Query interface {
 type Interface interface {
 	Query(string) (Query, error)
 	Search(string, interface{}) (interface{}, error)
+	// Precompile compiles the given queries ahead of time so that later evaluations don't need to parse them,
+	// it returns an error for every query that failed to compile
+	Precompile(...string) error
 }
 
 type implementation struct {
-	functionCaller *gojmespath.FunctionCaller
+	functionCaller *atomic.Pointer[gojmespath.FunctionCaller]
+	generation     *atomic.Uint64
+	seen           *atomic.Uint64
+	cache          *queryCache
+	configuration  config.Configuration
+	clock          clock.PassiveClock
 }
 
 func New(configuration config.Configuration) Interface {
-	return newImplementation(configuration)
+	return newImplementation(configuration, clock.RealClock{})
+}
+
+// NewWithClock returns an interface where time functions use the given clock instead of the current time
+func NewWithClock(configuration config.Configuration, clock clock.PassiveClock) Interface {
+	return newImplementation(configuration, clock)
 }
 
 func (i implementation) Query(query string) (Query, error) {
-	return newJMESPath(query, i.functionCaller)
+	return newJMESPath(i.cache, query, i.getFunctionCaller())
 }
 
 func (i implementation) Search(query string, data interface{}) (interface{}, error) {
-	return newExecution(i.functionCaller, query, data)
+	return newExecution(i.cache, i.getFunctionCaller(), query, data)
+}
+
+// getFunctionCaller returns the function caller, registering functions again if the configuration changed
+func (i implementation) getFunctionCaller() *gojmespath.FunctionCaller {
+	seen := i.seen.Load()
+	if generation := i.generation.Load(); generation != seen && i.seen.CompareAndSwap(seen, generation) {
+		i.functionCaller.Store(newFunctionCaller(i.configuration, i.clock))
+	}
+	return i.functionCaller.Load()
+}
+
+func (i implementation) Precompile(queries ...string) error {
+	var errs []error
+	for _, query := range queries {
+		if _, err := i.cache.compile(query); err != nil {
+			errs = append(errs, fmt.Errorf("failed to compile JMESPath query %q: %w", query, err))
+		}
+	}
+	return errors.Join(errs...)
 }
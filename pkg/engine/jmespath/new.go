@@ -1,8 +1,13 @@
 package jmespath
 
 import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
 	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/utils/clock"
 )
 
 type QueryProxy struct {
@@ -14,8 +19,8 @@ func (q *QueryProxy) Search(data interface{}) (interface{}, error) {
 	return q.jmesPath.Search(data, gojmespath.WithFunctionCaller(q.functionCaller))
 }
 
-func newJMESPath(query string, functionCaller *gojmespath.FunctionCaller) (*QueryProxy, error) {
-	jmesPath, err := gojmespath.Compile(query)
+func newJMESPath(cache *queryCache, query string, functionCaller *gojmespath.FunctionCaller) (*QueryProxy, error) {
+	jmesPath, err := cache.compile(query)
 	if err != nil {
 		return nil, err
 	}
@@ -25,18 +30,64 @@ func newJMESPath(query string, functionCaller *gojmespath.FunctionCaller) (*Quer
 	}, nil
 }
 
-func newImplementation(configuration config.Configuration) Interface {
+// generations holds the number of times each configuration changed, keyed by configuration
+var generations sync.Map
+
+// configurationGeneration returns the change counter of the configuration.
+// The callback is registered only once per configuration as callbacks are never removed from it.
+// The callback must not read the configuration as it is invoked while the configuration is locked.
+func configurationGeneration(configuration config.Configuration) *atomic.Uint64 {
+	if configuration == nil {
+		return &atomic.Uint64{}
+	}
+	// configurations that can't be used as map keys get their own counter
+	if !reflect.TypeOf(configuration).Comparable() {
+		generation := &atomic.Uint64{}
+		configuration.OnChanged(func() { generation.Add(1) })
+		return generation
+	}
+	if generation, ok := generations.Load(configuration); ok {
+		return generation.(*atomic.Uint64)
+	}
+	generation, loaded := generations.LoadOrStore(configuration, &atomic.Uint64{})
+	if !loaded {
+		counter := generation.(*atomic.Uint64)
+		configuration.OnChanged(func() { counter.Add(1) })
+	}
+	return generation.(*atomic.Uint64)
+}
+
+func newImplementation(configuration config.Configuration, clock clock.PassiveClock) Interface {
+	i := implementation{
+		functionCaller: &atomic.Pointer[gojmespath.FunctionCaller]{},
+		generation:     configurationGeneration(configuration),
+		seen:           &atomic.Uint64{},
+		cache:          newQueryCache(DefaultCacheSize),
+		configuration:  configuration,
+		clock:          clock,
+	}
+	// user-defined functions are registered again on the next query when the configuration changes
+	i.seen.Store(i.generation.Load())
+	i.functionCaller.Store(newFunctionCaller(configuration, clock))
+	return i
+}
+
+func newFunctionCaller(configuration config.Configuration, clock clock.PassiveClock) *gojmespath.FunctionCaller {
 	functionCaller := gojmespath.NewFunctionCaller()
-	functions := GetFunctions(configuration)
+	functions := getFunctions(configuration, clock)
 	for _, f := range functions {
 		functionCaller.Register(f.FunctionEntry)
 	}
-
-	return implementation{
-		functionCaller,
+	for _, f := range getUserFunctions(configuration, functions) {
+		functionCaller.Register(f.FunctionEntry)
 	}
+	return functionCaller
 }
 
-func newExecution(fCall *gojmespath.FunctionCaller, query string, data interface{}) (interface{}, error) {
-	return gojmespath.Search(query, data, gojmespath.WithFunctionCaller(fCall))
+func newExecution(cache *queryCache, fCall *gojmespath.FunctionCaller, query string, data interface{}) (interface{}, error) {
+	jmesPath, err := cache.compile(query)
+	if err != nil {
+		return nil, err
+	}
+	return jmesPath.Search(data, gojmespath.WithFunctionCaller(fCall))
 }
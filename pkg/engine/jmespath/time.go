@@ -4,6 +4,9 @@ import (
 	"reflect"
 	"strconv"
 	"time"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	"k8s.io/utils/clock"
 )
 
 // function names
@@ -40,7 +43,13 @@ func getDurationArg(f string, arguments []interface{}, index int) (time.Duration
 	return time.ParseDuration(arg.String())
 }
 
-func jpTimeSince(arguments []interface{}) (interface{}, error) {
+func jpTimeSince(clock clock.PassiveClock) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		return timeSinceImpl(clock, arguments)
+	}
+}
+
+func timeSinceImpl(clock clock.PassiveClock, arguments []interface{}) (interface{}, error) {
 	var err error
 	layout, err := validateArg(timeSince, arguments, 0, reflect.String)
 	if err != nil {
@@ -63,7 +72,7 @@ func jpTimeSince(arguments []interface{}) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	t2 = time.Now()
+	t2 = clock.Now()
 	if ts2.String() != "" {
 		if layout.String() != "" {
 			t2, err = time.Parse(layout.String(), ts2.String())
@@ -77,12 +86,16 @@ func jpTimeSince(arguments []interface{}) (interface{}, error) {
 	return t2.Sub(t1).String(), nil
 }
 
-func jpTimeNow(arguments []interface{}) (interface{}, error) {
-	return time.Now().Format(time.RFC3339), nil
+func jpTimeNow(clock clock.PassiveClock) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		return clock.Now().Format(time.RFC3339), nil
+	}
 }
 
-func jpTimeNowUtc(arguments []interface{}) (interface{}, error) {
-	return time.Now().UTC().Format(time.RFC3339), nil
+func jpTimeNowUtc(clock clock.PassiveClock) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		return clock.Now().UTC().Format(time.RFC3339), nil
+	}
 }
 
 func jpTimeToCron(arguments []interface{}) (interface{}, error) {
@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyverno/kyverno/pkg/config"
 	"gotest.tools/assert"
+	clocktesting "k8s.io/utils/clock/testing"
 )
 
 func Test_TimeSince(t *testing.T) {
@@ -39,6 +41,31 @@ func Test_TimeSince(t *testing.T) {
 	}
 }
 
+func Test_TimeWithClock(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	jp := NewWithClock(config.NewDefaultConfiguration(false), clocktesting.NewFakePassiveClock(now))
+	testCases := []struct {
+		test           string
+		expectedResult string
+	}{
+		{
+			test:           "time_now_utc()",
+			expectedResult: "2024-03-01T12:00:00Z",
+		},
+		{
+			test:           "time_since('', '2024-03-01T10:30:00Z', '')",
+			expectedResult: "1h30m0s",
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			res, err := jp.Search(tc.test, "")
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
 func Test_TimeToCron(t *testing.T) {
 	testCases := []struct {
 		test           string
@@ -0,0 +1,127 @@
+package jmespath
+
+import (
+	"fmt"
+	"reflect"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// standardFunctions are the functions defined by the JMESPath specification
+var standardFunctions = []string{
+	"abs", "avg", "ceil", "contains", "ends_with", "floor", "join", "keys", "length", "map", "max", "max_by",
+	"merge", "min", "min_by", "not_null", "reverse", "sort", "sort_by", "starts_with", "sum", "to_array",
+	"to_number", "to_string", "type", "values",
+}
+
+// getUserFunctions returns the functions declared in the configuration, functions that fail to compile
+// or conflict with a built-in function are skipped.
+// JMESPath expressions are evaluated with a function caller holding the built-in functions and the user-defined
+// functions registered before them, a function can't call itself so evaluation always terminates.
+// The configuration orders functions so that a function comes after the functions it calls.
+func getUserFunctions(configuration config.Configuration, builtins []FunctionEntry) []FunctionEntry {
+	if configuration == nil {
+		return nil
+	}
+	logger := logging.WithName("jmespath")
+	reserved := map[string]struct{}{}
+	for _, name := range standardFunctions {
+		reserved[name] = struct{}{}
+	}
+	for _, function := range builtins {
+		reserved[function.Name] = struct{}{}
+	}
+	var functions []FunctionEntry
+	for _, function := range configuration.GetJMESPathFunctions() {
+		if _, ok := reserved[function.Name]; ok {
+			logger.Error(fmt.Errorf("function %q conflicts with a built-in function", function.Name), "failed to register user-defined function")
+			continue
+		}
+		functionCaller := gojmespath.NewFunctionCaller()
+		for _, f := range builtins {
+			functionCaller.Register(f.FunctionEntry)
+		}
+		for _, f := range functions {
+			functionCaller.Register(f.FunctionEntry)
+		}
+		entry, err := newUserFunction(function, functionCaller)
+		if err != nil {
+			logger.Error(err, "failed to register user-defined function", "name", function.Name)
+			continue
+		}
+		functions = append(functions, entry)
+	}
+	return functions
+}
+
+func newUserFunction(function config.JMESPathFunction, functionCaller *gojmespath.FunctionCaller) (FunctionEntry, error) {
+	var eval func(map[string]interface{}) (interface{}, error)
+	if function.JMESPath != "" {
+		query, err := gojmespath.Compile(function.JMESPath)
+		if err != nil {
+			return FunctionEntry{}, fmt.Errorf("failed to compile JMESPath expression: %w", err)
+		}
+		eval = func(data map[string]interface{}) (interface{}, error) {
+			return query.Search(data, gojmespath.WithFunctionCaller(functionCaller))
+		}
+	} else {
+		program, err := compileCEL(function)
+		if err != nil {
+			return FunctionEntry{}, fmt.Errorf("failed to compile CEL expression: %w", err)
+		}
+		eval = func(data map[string]interface{}) (interface{}, error) {
+			out, _, err := program.Eval(data)
+			if err != nil {
+				return nil, err
+			}
+			value, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+			if err != nil {
+				return nil, err
+			}
+			return value.(*structpb.Value).AsInterface(), nil
+		}
+	}
+	arguments := make([]argSpec, 0, len(function.Arguments))
+	for range function.Arguments {
+		arguments = append(arguments, argSpec{Types: []jpType{jpAny}})
+	}
+	return FunctionEntry{
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name:      function.Name,
+			Arguments: arguments,
+			Handler: func(values []interface{}) (interface{}, error) {
+				if len(values) != len(function.Arguments) {
+					return nil, formatError(genericError, function.Name, fmt.Sprintf("expected %d arguments, got %d", len(function.Arguments), len(values)))
+				}
+				data := make(map[string]interface{}, len(values))
+				for i, name := range function.Arguments {
+					data[name] = values[i]
+				}
+				return eval(data)
+			},
+		},
+		ReturnType: []jpType{jpAny},
+		Note:       function.Description,
+	}, nil
+}
+
+func compileCEL(function config.JMESPathFunction) (celgo.Program, error) {
+	options := []celgo.EnvOption{ext.Strings()}
+	for _, name := range function.Arguments {
+		options = append(options, celgo.Variable(name, celgo.DynType))
+	}
+	env, err := celgo.NewEnv(options...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(function.CEL)
+	if err := issues.Err(); err != nil {
+		return nil, err
+	}
+	return env.Program(ast)
+}
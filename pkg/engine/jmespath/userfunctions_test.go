@@ -0,0 +1,150 @@
+package jmespath
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newUserFunctionsConfiguration(functions string) config.Configuration {
+	cfg := config.NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"jmespathFunctions": functions,
+		},
+	})
+	return cfg
+}
+
+func Test_UserFunctions(t *testing.T) {
+	jp := New(newUserFunctionsConfiguration(`[
+		{"name": "normalize_team", "arguments": ["team"], "jmesPath": "to_lower(trim(team, ' '))"},
+		{"name": "team_label", "arguments": ["labels"], "jmesPath": "normalize_team(labels.team || '')"},
+		{"name": "prefixed", "arguments": ["prefix", "value"], "cel": "prefix + '-' + value"},
+		{"name": "split_all", "arguments": ["value"], "cel": "value.split(',')"},
+		{"name": "to_upper", "arguments": ["value"], "jmesPath": "value"},
+		{"name": "broken", "jmesPath": "[", "arguments": []}
+	]`))
+	testCases := []struct {
+		query    string
+		data     interface{}
+		expected interface{}
+	}{{
+		query:    "normalize_team(' Platform ')",
+		expected: "platform",
+	}, {
+		query:    "team_label(metadata.labels)",
+		data:     map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"team": "Payments "}}},
+		expected: "payments",
+	}, {
+		query:    "prefixed('team', 'platform')",
+		expected: "team-platform",
+	}, {
+		query:    "split_all('a,b')",
+		expected: []interface{}{"a", "b"},
+	}, {
+		// built-in functions can't be overridden
+		query:    "to_upper('a')",
+		expected: "A",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			result, err := jp.Search(tc.query, tc.data)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, result)
+		})
+	}
+	// functions that fail to compile are not registered
+	_, err := jp.Search("broken()", nil)
+	assert.ErrorContains(t, err, "unknown function: broken")
+	_, err = jp.Search("normalize_team('a', 'b')", nil)
+	assert.ErrorContains(t, err, "incorrect number of args")
+}
+
+func Test_UserFunctionsReload(t *testing.T) {
+	cfg := newUserFunctionsConfiguration(`[{"name": "greet", "arguments": ["name"], "jmesPath": "join(' ', ['hello', name])"}]`)
+	jp := New(cfg)
+	result, err := jp.Search("greet('world')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, "hello world", result)
+
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"jmespathFunctions": `[{"name": "greet", "arguments": ["name"], "jmesPath": "join(' ', ['bye', name])"}]`,
+		},
+	})
+	result, err = jp.Search("greet('world')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, "bye world", result)
+
+	cfg.Load(nil)
+	_, err = jp.Search("greet('world')", nil)
+	assert.ErrorContains(t, err, "unknown function: greet")
+}
+
+func Test_UserFunctionsReloadShared(t *testing.T) {
+	cfg := newUserFunctionsConfiguration(`[{"name": "greet", "arguments": ["name"], "jmesPath": "join(' ', ['hello', name])"}]`)
+	first := New(cfg).(implementation)
+	second := New(cfg).(implementation)
+	// the configuration callback is registered once and shared by every interface created from it
+	assert.Equal(t, first.generation, second.generation)
+
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"jmespathFunctions": `[{"name": "greet", "arguments": ["name"], "jmesPath": "join(' ', ['bye', name])"}]`,
+		},
+	})
+	for _, jp := range []Interface{first, second} {
+		result, err := jp.Search("greet('world')", nil)
+		assert.NilError(t, err)
+		assert.Equal(t, "bye world", result)
+	}
+}
+
+func Test_GetFunctionsWithUserFunctions(t *testing.T) {
+	functions := GetFunctions(newUserFunctionsConfiguration(`[{"name": "normalize_team", "description": "normalizes a team name", "arguments": ["team"], "jmesPath": "to_lower(team)"}]`))
+	last := functions[len(functions)-1]
+	assert.Equal(t, "normalize_team(any) any (normalizes a team name)", last.String())
+}
+
+// recursiveConfiguration returns user-defined functions without the validation done when loading the configuration
+type recursiveConfiguration struct {
+	config.Configuration
+	functions []config.JMESPathFunction
+}
+
+func (c recursiveConfiguration) GetJMESPathFunctions() []config.JMESPathFunction {
+	return c.functions
+}
+
+func Test_UserFunctionsRecursion(t *testing.T) {
+	// recursive definitions are rejected when the configuration is loaded
+	jp := New(newUserFunctionsConfiguration(`[
+		{"name": "loop", "arguments": ["x"], "jmesPath": "loop(x)"}
+	]`))
+	_, err := jp.Search("loop('a')", nil)
+	assert.ErrorContains(t, err, "unknown function: loop")
+	jp = New(newUserFunctionsConfiguration(`[
+		{"name": "ping", "arguments": ["x"], "jmesPath": "pong(x)"},
+		{"name": "pong", "arguments": ["x"], "jmesPath": "ping(x)"}
+	]`))
+	_, err = jp.Search("ping('a')", nil)
+	assert.ErrorContains(t, err, "unknown function: ping")
+	// functions can only call the functions registered before them, evaluation can't recurse
+	jp = New(recursiveConfiguration{
+		Configuration: config.NewDefaultConfiguration(false),
+		functions: []config.JMESPathFunction{
+			{Name: "loop", Arguments: []string{"x"}, JMESPath: "loop(x)"},
+			{Name: "ping", Arguments: []string{"x"}, JMESPath: "pong(x)"},
+			{Name: "pong", Arguments: []string{"x"}, JMESPath: "ping(x)"},
+		},
+	})
+	_, err = jp.Search("loop('a')", nil)
+	assert.ErrorContains(t, err, "unknown function: loop")
+	_, err = jp.Search("ping('a')", nil)
+	assert.ErrorContains(t, err, "unknown function: pong")
+	_, err = jp.Search("pong('a')", nil)
+	assert.ErrorContains(t, err, "unknown function: pong")
+}
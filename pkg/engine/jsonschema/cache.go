@@ -0,0 +1,125 @@
+package jsonschema
+
+import (
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/xeipuuv/gojsonschema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+)
+
+// digestTTL is the time a tag resolved to a digest is cached, a tag moved to another digest
+// is picked up once the cached resolution expired
+const digestTTL = 5 * time.Minute
+
+// Cache stores compiled JSON schemas per policy and rule.
+type Cache interface {
+	// Get returns the compiled schema of a rule, calling load if it is not cached yet.
+	// Schemas are cached per policy UID and resource version and per source, the source identifies the version
+	// of an external schema (ConfigMap resource version, OCI digest). Policies without UID, empty sources
+	// and failed loads are never cached.
+	Get(policy kyvernov1.PolicyInterface, rule string, source string, load func() (*gojsonschema.Schema, error)) (*gojsonschema.Schema, error)
+	// Invalidate removes the schemas compiled for a policy, the key is the policy namespace/name key.
+	Invalidate(key string)
+	// Digest returns the digest a tagged OCI reference points to, calling resolve if it is not cached or expired.
+	// Failed resolutions are never cached.
+	Digest(ref string, resolve func() (string, error)) (string, error)
+}
+
+type compiled struct {
+	source string
+	schema *gojsonschema.Schema
+}
+
+type entry struct {
+	uid             types.UID
+	resourceVersion string
+	rules           map[string]compiled
+}
+
+type resolved struct {
+	digest  string
+	expires time.Time
+}
+
+type schemaCache struct {
+	lock    sync.RWMutex
+	entries map[string]*entry
+	digests map[string]resolved
+	clock   clock.PassiveClock
+}
+
+func NewCache() Cache {
+	return newCache(clock.RealClock{})
+}
+
+func newCache(clock clock.PassiveClock) *schemaCache {
+	return &schemaCache{
+		entries: map[string]*entry{},
+		digests: map[string]resolved{},
+		clock:   clock,
+	}
+}
+
+func (c *schemaCache) Get(policy kyvernov1.PolicyInterface, rule string, source string, load func() (*gojsonschema.Schema, error)) (*gojsonschema.Schema, error) {
+	uid, resourceVersion := policy.GetUID(), policy.GetResourceVersion()
+	if uid == "" || source == "" {
+		return load()
+	}
+	key, err := cache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		return load()
+	}
+	c.lock.RLock()
+	if entry, ok := c.entries[key]; ok && entry.uid == uid && entry.resourceVersion == resourceVersion {
+		if result, ok := entry.rules[rule]; ok && result.source == source {
+			c.lock.RUnlock()
+			return result.schema, nil
+		}
+	}
+	c.lock.RUnlock()
+	schema, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	current, ok := c.entries[key]
+	if !ok || current.uid != uid || current.resourceVersion != resourceVersion {
+		current = &entry{
+			uid:             uid,
+			resourceVersion: resourceVersion,
+			rules:           map[string]compiled{},
+		}
+		c.entries[key] = current
+	}
+	current.rules[rule] = compiled{source: source, schema: schema}
+	return schema, nil
+}
+
+func (c *schemaCache) Invalidate(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *schemaCache) Digest(ref string, resolve func() (string, error)) (string, error) {
+	now := c.clock.Now()
+	c.lock.RLock()
+	cached, ok := c.digests[ref]
+	c.lock.RUnlock()
+	if ok && now.Before(cached.expires) {
+		return cached.digest, nil
+	}
+	digest, err := resolve()
+	if err != nil {
+		return "", err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.digests[ref] = resolved{digest: digest, expires: now.Add(digestTTL)}
+	return digest, nil
+}
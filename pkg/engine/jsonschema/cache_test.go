@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func policy(uid, resourceVersion string) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test",
+			UID:             types.UID(uid),
+			ResourceVersion: resourceVersion,
+		},
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := NewCache()
+	loads := 0
+	load := func() (*gojsonschema.Schema, error) {
+		loads++
+		return Compile([]byte(schema))
+	}
+	first, err := cache.Get(policy("1", "1"), "rule", "cm-1", load)
+	require.NoError(t, err)
+	second, err := cache.Get(policy("1", "1"), "rule", "cm-1", load)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Equal(t, 1, loads)
+	// a new schema source is loaded again
+	_, err = cache.Get(policy("1", "1"), "rule", "cm-2", load)
+	require.NoError(t, err)
+	require.Equal(t, 2, loads)
+	// a new policy resource version is loaded again
+	_, err = cache.Get(policy("1", "2"), "rule", "cm-2", load)
+	require.NoError(t, err)
+	require.Equal(t, 3, loads)
+	// invalidation removes the policy schemas
+	cache.Invalidate("test")
+	_, err = cache.Get(policy("1", "2"), "rule", "cm-2", load)
+	require.NoError(t, err)
+	require.Equal(t, 4, loads)
+}
+
+func TestCacheNotCached(t *testing.T) {
+	cache := NewCache()
+	loads := 0
+	load := func() (*gojsonschema.Schema, error) {
+		loads++
+		return Compile([]byte(schema))
+	}
+	// policies without UID and unversioned sources are never cached
+	for i := 0; i < 2; i++ {
+		_, err := cache.Get(policy("", ""), "rule", "cm-1", load)
+		require.NoError(t, err)
+		_, err = cache.Get(policy("1", "1"), "rule", "", load)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, loads)
+	// failed loads are retried
+	fail := func() (*gojsonschema.Schema, error) {
+		loads++
+		return nil, errors.New("registry unavailable")
+	}
+	for i := 0; i < 2; i++ {
+		_, err := cache.Get(policy("1", "1"), "rule", "sha256:1", fail)
+		require.Error(t, err)
+	}
+	require.Equal(t, 6, loads)
+}
+
+func TestCacheDigest(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	cache := newCache(clock)
+	resolves := 0
+	digest := "sha256:1"
+	resolve := func() (string, error) {
+		resolves++
+		return digest, nil
+	}
+	resolved, err := cache.Digest("ghcr.io/schemas/app:v1", resolve)
+	require.NoError(t, err)
+	require.Equal(t, "sha256:1", resolved)
+	// the tag is resolved once per TTL
+	digest = "sha256:2"
+	resolved, err = cache.Digest("ghcr.io/schemas/app:v1", resolve)
+	require.NoError(t, err)
+	require.Equal(t, "sha256:1", resolved)
+	require.Equal(t, 1, resolves)
+	clock.SetTime(clock.Now().Add(digestTTL))
+	resolved, err = cache.Digest("ghcr.io/schemas/app:v1", resolve)
+	require.NoError(t, err)
+	require.Equal(t, "sha256:2", resolved)
+	require.Equal(t, 2, resolves)
+	// failed resolutions are not cached
+	_, err = cache.Digest("ghcr.io/schemas/app:v2", func() (string, error) {
+		return "", errors.New("unauthorized")
+	})
+	require.Error(t, err)
+	resolved, err = cache.Digest("ghcr.io/schemas/app:v2", resolve)
+	require.NoError(t, err)
+	require.Equal(t, "sha256:2", resolved)
+}
@@ -0,0 +1,124 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+)
+
+// LayerMediaType is the media type of the OCI layer holding a JSON Schema
+const LayerMediaType = "application/schema+json"
+
+// maxSchemaSize limits the size of the schemas read from OCI artifacts
+const maxSchemaSize = 4 << 20
+
+// Compile parses a JSON Schema.
+// Only local references (starting with `#`) are allowed, the schema must be self contained.
+func Compile(data []byte) (*gojsonschema.Schema, error) {
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if err := checkReferences(document); err != nil {
+		return nil, err
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+	return schema, nil
+}
+
+// Validate validates a document against a schema and returns the violations, formatted as `field: description`.
+// Violations are sorted to produce stable messages.
+func Validate(schema *gojsonschema.Schema, document any) ([]string, error) {
+	result, err := schema.Validate(gojsonschema.NewGoLoader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate document: %w", err)
+	}
+	var violations []string
+	for _, err := range result.Errors() {
+		violations = append(violations, fmt.Sprintf("%s: %s", err.Field(), err.Description()))
+	}
+	slices.Sort(violations)
+	return violations, nil
+}
+
+// Document returns the document to validate from the value returned by a selector,
+// strings are parsed as JSON or YAML.
+func Document(value any) (any, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	data, err := yaml.YAMLToJSON([]byte(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	return document, nil
+}
+
+// Load reads the JSON Schema stored in an OCI image layer with the LayerMediaType media type.
+func Load(img v1.Image) ([]byte, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("getting image layers: %w", err)
+	}
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer media type: %w", err)
+		}
+		if mediaType != LayerMediaType {
+			continue
+		}
+		blob, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer blob: %w", err)
+		}
+		defer blob.Close()
+		content, err := io.ReadAll(io.LimitReader(blob, maxSchemaSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("reading layer blob: %w", err)
+		}
+		if len(content) > maxSchemaSize {
+			return nil, errors.New("schema exceeds the maximum size")
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("no layer with media type %s found", LayerMediaType)
+}
+
+// checkReferences rejects references to external documents, they would make the engine
+// read files or send requests to arbitrary locations.
+func checkReferences(document any) error {
+	switch typed := document.(type) {
+	case map[string]any:
+		for key, value := range typed {
+			if ref, ok := value.(string); ok && key == "$ref" && !strings.HasPrefix(ref, "#") {
+				return fmt.Errorf("external schema reference %s is not allowed", ref)
+			}
+			if err := checkReferences(value); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, value := range typed {
+			if err := checkReferences(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"gotest.tools/assert"
+)
+
+const schema = `{
+  "type": "object",
+  "required": ["replicas"],
+  "properties": {
+    "replicas": {"type": "integer", "minimum": 1},
+    "tags": {"type": "array", "items": {"$ref": "#/definitions/tag"}}
+  },
+  "definitions": {
+    "tag": {"type": "string", "pattern": "^[a-z]+$"}
+  }
+}`
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{{
+		name:   "local references",
+		schema: schema,
+	}, {
+		name:    "remote reference",
+		schema:  `{"properties": {"a": {"$ref": "https://example.com/schema.json"}}}`,
+		wantErr: "external schema reference https://example.com/schema.json is not allowed",
+	}, {
+		name:    "file reference",
+		schema:  `{"items": [{"$ref": "file:///etc/passwd"}]}`,
+		wantErr: "external schema reference file:///etc/passwd is not allowed",
+	}, {
+		name:    "invalid json",
+		schema:  `{`,
+		wantErr: "failed to parse schema: unexpected end of JSON input",
+	}, {
+		name:    "invalid schema",
+		schema:  `{"type": 1}`,
+		wantErr: "failed to compile schema: Invalid type. Expected: string/array of strings, given: type",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile([]byte(tt.schema))
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	compiled, err := Compile([]byte(schema))
+	assert.NilError(t, err)
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{{
+		name:     "valid json",
+		document: `{"replicas": 2, "tags": ["a", "b"]}`,
+	}, {
+		name:     "valid yaml",
+		document: "replicas: 2\ntags:\n- a\n",
+	}, {
+		name:     "invalid",
+		document: `{"replicas": 0, "tags": ["a", "B"]}`,
+		want: []string{
+			"replicas: Must be greater than or equal to 1",
+			"tags.1: Does not match pattern '^[a-z]+$'",
+		},
+	}, {
+		name:     "missing property",
+		document: `{}`,
+		want:     []string{"(root): replicas is required"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := Document(tt.document)
+			assert.NilError(t, err)
+			violations, err := Validate(compiled, document)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, violations, tt.want)
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	document, err := Document(map[string]any{"a": "b"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, document, map[string]any{"a": "b"})
+	_, err = Document("a: [")
+	assert.ErrorContains(t, err, "failed to parse document")
+}
+
+func TestLoad(t *testing.T) {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	_, err := Load(img)
+	assert.Error(t, err, "no layer with media type application/schema+json found")
+	img, err = mutate.Append(img,
+		mutate.Addendum{Layer: static.NewLayer([]byte("# readme"), types.MediaType("text/markdown"))},
+		mutate.Addendum{Layer: static.NewLayer([]byte(schema), LayerMediaType)},
+	)
+	assert.NilError(t, err)
+	data, err := Load(img)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), schema)
+}
@@ -0,0 +1,151 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/client-go/applyconfigurations"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	smdschema "sigs.k8s.io/structured-merge-diff/v4/schema"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+var (
+	// builtinTypeConverter knows the schemas of the kubernetes built-in types
+	builtinTypeConverter = applyconfigurations.NewTypeConverter(scheme.Scheme)
+	// deducedTypeConverter is used for other types, maps are merged and lists are atomic
+	deducedTypeConverter = managedfields.NewDeducedTypeConverter()
+)
+
+// ApplyConfiguration merges an apply configuration into a resource with server side apply semantics,
+// the same way the API server applies MutatingAdmissionPolicy apply configurations:
+//   - lists are merged according to their list type (containers are merged by name for example)
+//   - apply configurations setting atomic lists, maps or structs are rejected
+//   - null values are ignored, an apply configuration can't remove fields
+//
+// Built-in types are merged with their schemas, other types (custom resources) are merged structurally
+// and their lists are atomic.
+func ApplyConfiguration(resource *unstructured.Unstructured, applyConfiguration map[string]interface{}) (*unstructured.Unstructured, error) {
+	patch, err := toUnstructured(resource, applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	typeConverter := deducedTypeConverter
+	if scheme.Scheme.Recognizes(resource.GroupVersionKind()) {
+		typeConverter = builtinTypeConverter
+	}
+	patchTyped, err := typeConverter.ObjectToTyped(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert apply configuration to typed object (%w)", err)
+	}
+	if atomics := findAtomics(nil, patchTyped.Schema(), patchTyped.TypeRef(), patchTyped.AsValue()); len(atomics) != 0 {
+		return nil, fmt.Errorf("invalid apply configuration: may not mutate atomic arrays, maps or structs: %s", strings.Join(atomics, ", "))
+	}
+	liveTyped, err := typeConverter.ObjectToTyped(resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resource to typed object (%w)", err)
+	}
+	mergedTyped, err := liveTyped.Merge(patchTyped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge apply configuration (%w)", err)
+	}
+	merged, err := typeConverter.TypedToObject(mergedTyped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert typed object to resource (%w)", err)
+	}
+	// round trip through JSON to normalize the types of the merged values
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	var patched unstructured.Unstructured
+	if err := patched.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+// toUnstructured converts an apply configuration to an object of the same kind as the resource, without null values
+func toUnstructured(resource *unstructured.Unstructured, applyConfiguration map[string]interface{}) (*unstructured.Unstructured, error) {
+	object := removeNulls(applyConfiguration).(map[string]interface{})
+	object["apiVersion"] = resource.GetAPIVersion()
+	object["kind"] = resource.GetKind()
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var patch unstructured.Unstructured
+	if err := patch.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to convert apply configuration (%w)", err)
+	}
+	return &patch, nil
+}
+
+func removeNulls(in interface{}) interface{} {
+	switch typed := in.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			if v != nil {
+				out[k] = removeNulls(v)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(typed))
+		for _, v := range typed {
+			if v != nil {
+				out = append(out, removeNulls(v))
+			}
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+// findAtomics returns the paths of the atomic lists, maps and structs set by an apply configuration.
+// Setting an atomic replaces it entirely, which silently drops the fields that are not part of the apply configuration.
+func findAtomics(path []fieldpath.PathElement, s *smdschema.Schema, tr smdschema.TypeRef, v value.Value) []string {
+	var paths []string
+	atom, ok := s.Resolve(tr)
+	if !ok {
+		return nil
+	}
+	if v.IsMap() && atom.Map != nil {
+		if atom.Map.ElementRelationship == smdschema.Atomic {
+			paths = append(paths, pathString(path))
+		}
+		v.AsMap().Iterate(func(key string, val value.Value) bool {
+			elementType := atom.Map.ElementType
+			if field, ok := atom.Map.FindField(key); ok {
+				elementType = field.Type
+			}
+			paths = append(paths, findAtomics(append(path, fieldpath.PathElement{FieldName: &key}), s, elementType, val)...)
+			return true
+		})
+	}
+	if v.IsList() && atom.List != nil {
+		if atom.List.ElementRelationship == smdschema.Atomic {
+			paths = append(paths, pathString(path))
+		}
+		list := v.AsList()
+		for i := 0; i < list.Length(); i++ {
+			index := i
+			paths = append(paths, findAtomics(append(path, fieldpath.PathElement{Index: &index}), s, atom.List.ElementType, list.At(i))...)
+		}
+	}
+	return paths
+}
+
+func pathString(path []fieldpath.PathElement) string {
+	var sb strings.Builder
+	for _, element := range path {
+		sb.WriteString(element.String())
+	}
+	return sb.String()
+}
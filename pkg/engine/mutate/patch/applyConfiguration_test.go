@@ -0,0 +1,67 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplyConfiguration(t *testing.T) {
+	tests := []struct {
+		name               string
+		resource           string
+		applyConfiguration map[string]interface{}
+		want               string
+		wantErr            string
+	}{{
+		name:     "built-in lists are merged by key",
+		resource: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","labels":{"app":"test"}},"spec":{"containers":[{"name":"nginx","image":"nginx"},{"name":"sidecar","image":"envoy"}]}}`,
+		applyConfiguration: map[string]interface{}{
+			"metadata": map[string]interface{}{"labels": map[string]interface{}{"env": "prod", "app": nil}},
+			"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx:1.27"},
+				map[string]interface{}{"name": "init", "image": "busybox"},
+			}},
+		},
+		want: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","labels":{"app":"test","env":"prod"}},"spec":{"containers":[{"name":"nginx","image":"nginx:1.27"},{"name":"sidecar","image":"envoy"},{"name":"init","image":"busybox"}]}}`,
+	}, {
+		name:     "built-in atomic lists are rejected",
+		resource: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test"},"spec":{"containers":[{"name":"nginx","image":"nginx","args":["--verbose"]}]}}`,
+		applyConfiguration: map[string]interface{}{
+			"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "args": []interface{}{"--debug"}},
+			}},
+		},
+		wantErr: "invalid apply configuration: may not mutate atomic arrays, maps or structs: .spec.containers[0].args",
+	}, {
+		name:     "custom resource maps are merged",
+		resource: `{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"test"},"spec":{"size":1,"options":{"color":"red"}}}`,
+		applyConfiguration: map[string]interface{}{
+			"spec": map[string]interface{}{"options": map[string]interface{}{"shape": "round"}},
+		},
+		want: `{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"test"},"spec":{"size":1,"options":{"color":"red","shape":"round"}}}`,
+	}, {
+		name:     "custom resource lists are atomic",
+		resource: `{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"test"},"spec":{"items":["a","b"]}}`,
+		applyConfiguration: map[string]interface{}{
+			"spec": map[string]interface{}{"items": []interface{}{"c"}},
+		},
+		wantErr: "invalid apply configuration: may not mutate atomic arrays, maps or structs: .spec.items",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resource unstructured.Unstructured
+			assert.NoError(t, resource.UnmarshalJSON([]byte(tt.resource)))
+			got, err := ApplyConfiguration(&resource, tt.applyConfiguration)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var want unstructured.Unstructured
+			assert.NoError(t, want.UnmarshalJSON([]byte(tt.want)))
+			assert.Equal(t, want.Object, got.Object)
+		})
+	}
+}
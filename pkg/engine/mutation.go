@@ -36,6 +36,9 @@ func (e *engine) mutate(
 			if !rule.HasMutate() {
 				return nil, nil
 			}
+			if rule.HasMutateCEL() {
+				return mutation.NewMutateCELHandler(e.client, e.isCluster, e.celCache)
+			}
 			if !policyContext.AdmissionOperation() && rule.HasMutateExisting() {
 				if e.client == nil {
 					return nil, fmt.Errorf("Handler factory requires a client but a nil client was passed, likely due to a bug or unsupported operation.")
@@ -11,7 +11,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -37,7 +39,10 @@ func testMutate(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jp,
+		enginecel.NewCache(),
+		nil,
 		adapters.Client(client),
+		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
 		contextLoader,
@@ -2702,3 +2707,232 @@ func Test_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func Test_mutateCEL(t *testing.T) {
+	resourceRaw := []byte(`{
+    "apiVersion": "apps/v1",
+    "kind": "Deployment",
+    "metadata": {
+      "name": "nginx",
+      "labels": {
+        "app": "nginx"
+      }
+    },
+    "spec": {
+      "replicas": 1,
+      "template": {
+        "spec": {
+          "containers": [
+            {
+              "image": "nginx:latest",
+              "name": "nginx"
+            }
+          ]
+        }
+      }
+    }
+  }`)
+	policyRaw := []byte(`{
+    "apiVersion": "kyverno.io/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {
+      "name": "cel-mutate"
+    },
+    "spec": {
+      "rules": [
+        {
+          "name": "mutate",
+          "match": {
+            "any": [
+              {
+                "resources": {
+                  "kinds": [
+                    "Deployment"
+                  ]
+                }
+              }
+            ]
+          },
+          "celPreconditions": [
+            {
+              "name": "nginx",
+              "expression": "object.metadata.labels.app == 'nginx'"
+            }
+          ],
+          "mutate": {
+            "cel": {
+              "variables": [
+                {
+                  "name": "env",
+                  "expression": "'prod'"
+                }
+              ],
+              "mutations": [
+                {
+                  "patchType": "ApplyConfiguration",
+                  "applyConfiguration": {
+                    "expression": "{\"metadata\": {\"labels\": {\"env\": variables.env}}, \"spec\": {\"replicas\": 3, \"paused\": false}}"
+                  }
+                },
+                {
+                  "patchType": "JSONPatch",
+                  "jsonPatch": {
+                    "expression": "[{\"op\": \"add\", \"path\": \"/metadata/annotations\", \"value\": {\"owner\": object.metadata.labels.env}}]"
+                  }
+                }
+              ]
+            }
+          }
+        }
+      ]
+    }
+  }`)
+	policy := loadResource[kyverno.ClusterPolicy](t, policyRaw)
+	resource := loadUnstructured(t, resourceRaw)
+	policyContext := createContext(t, &policy, resource)
+
+	er := testMutate(context.TODO(), nil, nil, policyContext, nil)
+	require.Equal(t, 1, len(er.PolicyResponse.Rules))
+	require.Equal(t, engineapi.RuleStatusPass, er.PolicyResponse.Rules[0].Status(), er.PolicyResponse.Rules[0].Message())
+
+	expected := resource.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(expected.Object, "prod", "metadata", "labels", "env"))
+	require.NoError(t, unstructured.SetNestedField(expected.Object, "prod", "metadata", "annotations", "owner"))
+	require.NoError(t, unstructured.SetNestedField(expected.Object, int64(3), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(expected.Object, false, "spec", "paused"))
+	require.Equal(t, *expected, er.PatchedResource)
+
+	// the rule is skipped when the CEL preconditions are not met
+	unstructured.SetNestedField(resource.Object, "httpd", "metadata", "labels", "app")
+	policyContext = createContext(t, &policy, resource)
+	er = testMutate(context.TODO(), nil, nil, policyContext, nil)
+	require.Equal(t, 1, len(er.PolicyResponse.Rules))
+	require.Equal(t, engineapi.RuleStatusSkip, er.PolicyResponse.Rules[0].Status())
+	require.Equal(t, resource, er.PatchedResource)
+}
+
+func Test_PolicyParams(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "team-defaults"
+		},
+		"spec": {
+			"params": {
+				"kind": "ConfigMap",
+				"name": "team-params",
+				"namespace": "default"
+			},
+			"rules": [
+				{
+					"name": "add-team-label",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"mutate": {
+						"patchStrategicMerge": {
+							"metadata": {
+								"labels": {
+									"team": "{{ params.data.team }}"
+								}
+							}
+						}
+					}
+				},
+				{
+					"name": "prefix-registry",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"mutate": {
+						"foreach": [
+							{
+								"list": "request.object.spec.containers",
+								"patchStrategicMerge": {
+									"spec": {
+										"containers": [
+											{
+												"name": "{{ element.name }}",
+												"image": "{{ params.data.registry }}/{{ element.image }}"
+											}
+										]
+									}
+								}
+							}
+						]
+					}
+				}
+			]
+		}
+	}`)
+	paramsRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "ConfigMap",
+		"metadata": {
+			"name": "team-params",
+			"namespace": "default"
+		},
+		"data": {
+			"team": "payments",
+			"registry": "registry.payments.io"
+		}
+	}`)
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "pod",
+			"namespace": "default"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "app",
+					"image": "app:v1"
+				},
+				{
+					"name": "sidecar",
+					"image": "sidecar:v1"
+				}
+			]
+		}
+	}`)
+
+	policy := loadResource[kyverno.ClusterPolicy](t, policyRaw)
+	params := loadUnstructured(t, paramsRaw)
+	resource := loadUnstructured(t, resourceRaw)
+
+	dclient, err := client.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, &params)
+	require.NoError(t, err)
+	dclient.SetDiscovery(client.NewFakeDiscoveryClient(nil))
+
+	paramsLoader := func(dclient client.Interface) engineapi.ContextLoaderFactory {
+		return factories.DefaultContextLoaderFactory(nil, factories.WithParamsStore(loaders.NewClientParamsStore(adapters.Client(dclient))))
+	}
+	er := testMutate(context.TODO(), dclient, registryclient.NewOrDie(), createContext(t, &policy, resource), paramsLoader(dclient))
+	require.Len(t, er.PolicyResponse.Rules, 2)
+	for _, r := range er.PolicyResponse.Rules {
+		require.Equal(t, engineapi.RuleStatusPass, r.Status(), r.Message())
+	}
+	patched := er.PatchedResource
+	require.Equal(t, "payments", patched.GetLabels()["team"])
+	containers, _, err := unstructured.NestedSlice(patched.Object, "spec", "containers")
+	require.NoError(t, err)
+	require.Equal(t, "registry.payments.io/app:v1", containers[0].(map[string]interface{})["image"])
+	require.Equal(t, "registry.payments.io/sidecar:v1", containers[1].(map[string]interface{})["image"])
+
+	// rules fail when the parameters object doesn't exist
+	dclient, err = client.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{})
+	require.NoError(t, err)
+	dclient.SetDiscovery(client.NewFakeDiscoveryClient(nil))
+
+	er = testMutate(context.TODO(), dclient, registryclient.NewOrDie(), createContext(t, &policy, resource), paramsLoader(dclient))
+	require.NotEmpty(t, er.PolicyResponse.Rules)
+	require.Equal(t, engineapi.RuleStatusError, er.PolicyResponse.Rules[0].Status())
+	require.Contains(t, er.PolicyResponse.Rules[0].Message(), "failed to get policy parameters")
+}
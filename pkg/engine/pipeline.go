@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/logging"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PipelineContextFactory builds the policy context used to apply a policy to the resource produced by the previous stage
+type PipelineContextFactory = func(policy kyvernov1.PolicyInterface, resource unstructured.Unstructured) (engineapi.PolicyContext, error)
+
+// PipelineOption configures RunPipeline
+type PipelineOption func(*pipeline)
+
+// WithMutateExisting includes the policies with mutate existing rules in the mutation stage,
+// by default only the policies with standard mutate rules are applied like in the admission webhooks.
+func WithMutateExisting() PipelineOption {
+	return func(p *pipeline) {
+		p.mutateExisting = true
+	}
+}
+
+type pipeline struct {
+	mutateExisting bool
+}
+
+func (p pipeline) mutates(policy kyvernov1.PolicyInterface) bool {
+	if p.mutateExisting {
+		return policy.GetSpec().HasMutate()
+	}
+	return policy.GetSpec().HasMutateStandard()
+}
+
+// RunPipeline applies policies to a resource in the same order as the admission webhooks: mutation first,
+// then image verification and finally validation. Every policy operates on the resource produced by the
+// previous one so that the interactions between policies are taken into account.
+func RunPipeline(
+	ctx context.Context,
+	engine engineapi.Engine,
+	resource unstructured.Unstructured,
+	policies []kyvernov1.PolicyInterface,
+	newPolicyContext PipelineContextFactory,
+	opts ...PipelineOption,
+) (engineapi.PipelineResponse, error) {
+	var p pipeline
+	for _, opt := range opts {
+		opt(&p)
+	}
+	response := engineapi.PipelineResponse{
+		Resource: resource,
+	}
+	// mutate
+	for _, policy := range policies {
+		if !p.mutates(policy) {
+			continue
+		}
+		policyContext, err := newPolicyContext(policy, resource)
+		if err != nil {
+			return response, err
+		}
+		mutateResponse := engine.Mutate(ctx, policyContext)
+		response.Mutation = append(response.Mutation, mutateResponse)
+		resource = mutateResponse.PatchedResource
+	}
+	// verify images
+	for _, policy := range policies {
+		if !policy.GetSpec().HasVerifyImages() {
+			continue
+		}
+		policyContext, err := newPolicyContext(policy, resource)
+		if err != nil {
+			return response, err
+		}
+		verifyImageResponse, verifiedImageData := engine.VerifyAndPatchImages(ctx, policyContext)
+		if !verifiedImageData.IsEmpty() {
+			patched, err := annotateVerifiedImages(verifyImageResponse.PatchedResource, verifiedImageData)
+			if err != nil {
+				return response, err
+			}
+			verifyImageResponse.PatchedResource = patched
+		}
+		response.ImageVerification = append(response.ImageVerification, verifyImageResponse)
+		resource = verifyImageResponse.PatchedResource
+	}
+	response.PatchedResource = resource
+	// validate
+	for _, policy := range policies {
+		spec := policy.GetSpec()
+		if !spec.HasValidate() && !spec.HasVerifyImageChecks() {
+			continue
+		}
+		policyContext, err := newPolicyContext(policy, resource)
+		if err != nil {
+			return response, err
+		}
+		response.Validation = append(response.Validation, engine.Validate(ctx, policyContext))
+	}
+	return response, nil
+}
+
+// annotateVerifiedImages adds the verified images annotation to the resource, like the image verification webhook does
+func annotateVerifiedImages(resource unstructured.Unstructured, verifiedImageData engineapi.ImageVerificationMetadata) (unstructured.Unstructured, error) {
+	patches, err := verifiedImageData.Patches(len(resource.GetAnnotations()) != 0, logging.WithName("pipeline"))
+	if err != nil {
+		return resource, err
+	}
+	decoded, err := jsonpatch.DecodePatch(jsonutils.JoinPatches(patch.ConvertPatches(patches...)...))
+	if err != nil {
+		return resource, err
+	}
+	resourceBytes, err := resource.MarshalJSON()
+	if err != nil {
+		return resource, err
+	}
+	options := &jsonpatch.ApplyOptions{SupportNegativeIndices: true, AllowMissingPathOnRemove: true, EnsurePathExistsOnAdd: true}
+	patchedBytes, err := decoded.ApplyWithOptions(resourceBytes, options)
+	if err != nil {
+		return resource, fmt.Errorf("failed to annotate verified images: %w", err)
+	}
+	var patched unstructured.Unstructured
+	if err := patched.UnmarshalJSON(patchedBytes); err != nil {
+		return resource, err
+	}
+	return patched, nil
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_RunPipeline(t *testing.T) {
+	addTeam := loadResource[kyverno.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "add-team"},
+		"spec": {
+			"rules": [{
+				"name": "add-team-label",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"+(team)": "payments"}}}}
+			}]
+		}
+	}`))
+	requireTeam := loadResource[kyverno.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-team"},
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [{
+				"name": "require-team-label",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}]
+		}
+	}`))
+	restrictTeam := loadResource[kyverno.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "restrict-team"},
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [{
+				"name": "restrict-team-label",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"pattern": {"metadata": {"labels": {"team": "checkout"}}}}
+			}]
+		}
+	}`))
+	labelNamespace := loadResource[kyverno.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "label-namespace"},
+		"spec": {
+			"rules": [{
+				"name": "label-namespace",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"mutate": {
+					"targets": [{"apiVersion": "v1", "kind": "Namespace", "name": "{{ request.object.metadata.namespace }}"}],
+					"patchStrategicMerge": {"metadata": {"labels": {"has-pods": "true"}}}
+				}
+			}]
+		}
+	}`))
+	resource := loadUnstructured(t, []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "pod", "namespace": "default"},
+		"spec": {"containers": [{"name": "app", "image": "app:v1"}]}
+	}`))
+	e := NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		enginecel.NewCache(),
+		nil,
+		nil,
+		nil,
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+	)
+	newPolicyContext := func(policy kyverno.PolicyInterface, resource unstructured.Unstructured) (engineapi.PolicyContext, error) {
+		return createContext(t, policy, resource), nil
+	}
+
+	// validation policies see the mutated resource, whatever the order of the policies
+	response, err := RunPipeline(context.TODO(), e, resource, []kyverno.PolicyInterface{&requireTeam, &addTeam}, newPolicyContext)
+	require.NoError(t, err)
+	require.Len(t, response.Mutation, 1)
+	require.Empty(t, response.ImageVerification)
+	require.Len(t, response.Validation, 1)
+	require.Len(t, response.Responses(), 2)
+	require.Empty(t, response.Resource.GetLabels())
+	require.Equal(t, "payments", response.PatchedResource.GetLabels()["team"])
+	require.True(t, response.Validation[0].IsSuccessful())
+	require.False(t, response.IsBlocked(context.TODO()))
+
+	// a validation policy conflicting with a mutation blocks the request
+	response, err = RunPipeline(context.TODO(), e, resource, []kyverno.PolicyInterface{&addTeam, &requireTeam, &restrictTeam}, newPolicyContext)
+	require.NoError(t, err)
+	require.Len(t, response.Validation, 2)
+	require.True(t, response.Validation[1].IsFailed())
+	require.True(t, response.IsBlocked(context.TODO()))
+
+	// mutate existing policies are only applied when requested
+	response, err = RunPipeline(context.TODO(), e, resource, []kyverno.PolicyInterface{&addTeam, &labelNamespace}, newPolicyContext)
+	require.NoError(t, err)
+	require.Len(t, response.Mutation, 1)
+	response, err = RunPipeline(context.TODO(), e, resource, []kyverno.PolicyInterface{&addTeam, &labelNamespace}, newPolicyContext, WithMutateExisting())
+	require.NoError(t, err)
+	require.Len(t, response.Mutation, 2)
+	require.Equal(t, "payments", response.PatchedResource.GetLabels()["team"])
+}
@@ -16,6 +16,8 @@ type PatternError struct {
 	Err  error
 	Path string
 	Skip bool
+	// Anchor is the path of the anchor that made the validation fail or skip (if any)
+	Anchor string
 }
 
 func (e *PatternError) Error() string {
@@ -35,21 +37,21 @@ func MatchPattern(logger logr.Logger, resource, pattern interface{}) error {
 	if err != nil {
 		if skip(err) {
 			logger.V(2).Info("resource skipped", "reason", ac.AnchorError.Error())
-			return &PatternError{err, "", true}
+			return &PatternError{Err: err, Skip: true, Anchor: ac.FailedAnchor}
 		}
 
 		if fail(err) {
 			logger.V(2).Info("failed to apply rule on resource", "msg", ac.AnchorError.Error())
-			return &PatternError{err, elemPath, false}
+			return &PatternError{Err: err, Path: elemPath, Anchor: ac.FailedAnchor}
 		}
 
 		// check if an anchor defined in the policy rule is missing in the resource
 		if ac.KeysAreMissing() {
 			logger.V(3).Info("missing anchor in resource")
-			return &PatternError{err, "", false}
+			return &PatternError{Err: err, Anchor: ac.FailedAnchor}
 		}
 
-		return &PatternError{err, elemPath, false}
+		return &PatternError{Err: err, Path: elemPath, Anchor: ac.FailedAnchor}
 	}
 
 	return nil
@@ -130,6 +132,7 @@ func validateMap(log logr.Logger, resourceMap, patternMap map[string]interface{}
 
 	// Evaluate anchors
 	var skipErrors []error
+	var skipKeys []string
 	var applyCount int
 	for _, key := range keys {
 		patternElement := anchors[key]
@@ -142,6 +145,7 @@ func validateMap(log logr.Logger, resourceMap, patternMap map[string]interface{}
 		if err != nil {
 			if skip(err) {
 				skipErrors = append(skipErrors, err)
+				skipKeys = append(skipKeys, path+key)
 				continue
 			}
 
@@ -153,6 +157,7 @@ func validateMap(log logr.Logger, resourceMap, patternMap map[string]interface{}
 				}
 			}
 			if !skipSiblingExists {
+				ac.SetFailedAnchor(path + key)
 				return handlerPath, err
 			} else {
 				continue
@@ -164,6 +169,7 @@ func validateMap(log logr.Logger, resourceMap, patternMap map[string]interface{}
 
 	if len(skipErrors) > 0 {
 		if applyCount == 0 {
+			ac.SetFailedAnchor(skipKeys[0])
 			return path, &PatternError{
 				Err:  multierr.Combine(skipErrors...),
 				Path: path,
@@ -178,6 +184,7 @@ func validateMap(log logr.Logger, resourceMap, patternMap map[string]interface{}
 				}
 			}
 			if skipSiblingExists {
+				ac.SetFailedAnchor(skipKeys[0])
 				return path, &PatternError{
 					Err:  multierr.Combine(skipErrors...),
 					Path: path,
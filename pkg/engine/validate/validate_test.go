@@ -1761,3 +1761,42 @@ func testMatchPattern(t *testing.T, testCase struct {
 		assert.Assert(t, err == nil, fmt.Sprintf("\nexpected error - test: %s\npattern: %s\nresource: %s\n", testCase.name, pattern, resource))
 	}
 }
+
+func TestMatchPattern_FailedAnchor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  []byte
+		resource []byte
+		skip     bool
+		anchor   string
+	}{
+		{
+			name:     "equality anchor",
+			pattern:  []byte(`{"spec": {"=(hostNetwork)": false}}`),
+			resource: []byte(`{"spec": {"hostNetwork": true}}`),
+			anchor:   "/spec/=(hostNetwork)",
+		},
+		{
+			name:     "conditional anchor",
+			pattern:  []byte(`{"metadata": {"labels": {"(app)": "nginx"}}, "spec": {"replicas": 2}}`),
+			resource: []byte(`{"metadata": {"labels": {"app": "redis"}}, "spec": {"replicas": 1}}`),
+			skip:     true,
+			anchor:   "/metadata/labels/(app)",
+		},
+		{
+			name:     "no anchor",
+			pattern:  []byte(`{"spec": {"replicas": 2}}`),
+			resource: []byte(`{"spec": {"replicas": 1}}`),
+		},
+	}
+	for _, tc := range testCases {
+		var pattern, resource interface{}
+		assert.NilError(t, json.Unmarshal(tc.pattern, &pattern))
+		assert.NilError(t, json.Unmarshal(tc.resource, &resource))
+		err := MatchPattern(logr.Discard(), resource, pattern)
+		pe, ok := err.(*PatternError)
+		assert.Assert(t, ok, tc.name)
+		assert.Equal(t, tc.skip, pe.Skip, tc.name)
+		assert.Equal(t, tc.anchor, pe.Anchor, tc.name)
+	}
+}
@@ -36,6 +36,10 @@ func (e *engine) validate(
 			if !hasValidate && !hasVerifyImageChecks {
 				return nil, nil
 			}
+			// collection rules need the full set of objects and are only evaluated in background scans
+			if policyContext.AdmissionOperation() && rule.HasValidateCollection() {
+				return nil, nil
+			}
 			if hasValidate {
 				if rule.Validation.Assert.Value != nil {
 					return validation.NewValidateAssertHandler()
@@ -43,6 +47,7 @@ func (e *engine) validate(
 				hasVerifyManifest := rule.HasVerifyManifests()
 				hasValidatePss := rule.HasValidatePodSecurity()
 				hasValidateCEL := rule.HasValidateCEL()
+				hasValidateJSONSchema := rule.HasValidateJSONSchema()
 				if hasVerifyManifest {
 					return validation.NewValidateManifestHandler(
 						policyContext,
@@ -51,7 +56,9 @@ func (e *engine) validate(
 				} else if hasValidatePss {
 					return validation.NewValidatePssHandler()
 				} else if hasValidateCEL {
-					return validation.NewValidateCELHandler(e.client, e.isCluster)
+					return validation.NewValidateCELHandler(e.client, e.isCluster, e.celCache)
+				} else if hasValidateJSONSchema {
+					return validation.NewValidateJSONSchemaHandler(e.client, e.cmResolver, e.rclientFactory, e.schemaCache)
 				} else {
 					return validation.NewValidateResourceHandler()
 				}
@@ -11,14 +11,19 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/xeipuuv/gojsonschema"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -36,6 +41,9 @@ func testValidate(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jp,
+		enginecel.NewCache(),
+		nil,
+		nil,
 		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
@@ -3311,3 +3319,279 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateCELWithContext(t *testing.T) {
+	policyRaw := []byte(`{
+    "apiVersion": "kyverno.io/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {
+      "name": "allowed-registries"
+    },
+    "spec": {
+      "rules": [
+        {
+          "name": "check-registries",
+          "match": {
+            "any": [
+              {
+                "resources": {
+                  "kinds": [
+                    "Pod"
+                  ]
+                }
+              }
+            ]
+          },
+          "context": [
+            {
+              "name": "allowed",
+              "variable": {
+                "value": {
+                  "registries": [
+                    "ghcr.io",
+                    "registry.k8s.io"
+                  ]
+                }
+              }
+            }
+          ],
+          "validate": {
+            "failureAction": "Enforce",
+            "cel": {
+              "variables": [
+                {
+                  "name": "registries",
+                  "expression": "object.spec.containers.map(c, kyverno.parseImage(c.image).registry)"
+                }
+              ],
+              "expressions": [
+                {
+                  "expression": "variables.registries.all(r, r in variables.context.allowed.registries)",
+                  "messageExpression": "'registries ' + variables.registries.filter(r, !(r in variables.context.allowed.registries)).join(', ') + ' are not allowed'"
+                }
+              ]
+            }
+          }
+        }
+      ]
+    }
+  }`)
+	testCases := []struct {
+		name    string
+		image   string
+		status  engineapi.RuleStatus
+		message string
+	}{
+		{
+			name:   "allowed",
+			image:  "ghcr.io/kyverno/kyverno:latest",
+			status: engineapi.RuleStatusPass,
+		},
+		{
+			name:    "not-allowed",
+			image:   "nginx",
+			status:  engineapi.RuleStatusFail,
+			message: "registries docker.io are not allowed",
+		},
+	}
+	policy := loadResource[kyvernov1.ClusterPolicy](t, policyRaw)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "spec": {"containers": [{"name": "test", "image": "`+tc.image+`"}]}}`))
+			er := testValidate(context.TODO(), nil, createContext(t, &policy, resource), cfg, nil)
+			assert.Equal(t, 1, len(er.PolicyResponse.Rules))
+			assert.Equal(t, tc.status, er.PolicyResponse.Rules[0].Status(), er.PolicyResponse.Rules[0].Message())
+			if tc.message != "" {
+				assert.Equal(t, tc.message, er.PolicyResponse.Rules[0].Message())
+			}
+		})
+	}
+}
+
+type fakeCollectionStore []unstructured.Unstructured
+
+func (s fakeCollectionStore) Load(_ context.Context, kinds ...string) ([]any, error) {
+	objs := []any{}
+	for _, obj := range s {
+		for _, kind := range kinds {
+			if obj.GetKind() == kind {
+				objs = append(objs, obj.Object)
+			}
+		}
+	}
+	return objs, nil
+}
+
+func Test_ValidateCollection(t *testing.T) {
+	policy := loadResource[kyvernov1.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-network-policy"},
+		"spec": {
+			"rules": [{
+				"name": "require-network-policy",
+				"match": {"any": [{"resources": {"kinds": ["Namespace"]}}]},
+				"validate": {
+					"message": "namespace {{ request.object.metadata.name }} has no NetworkPolicy",
+					"collection": {"kinds": ["NetworkPolicy"]},
+					"deny": {
+						"conditions": {
+							"all": [{
+								"key": "{{ length(collection[?metadata.namespace == '{{ request.object.metadata.name }}']) }}",
+								"operator": "Equals",
+								"value": 0
+							}]
+						}
+					}
+				}
+			}]
+		}
+	}`))
+	store := fakeCollectionStore{
+		loadUnstructured(t, []byte(`{"apiVersion": "networking.k8s.io/v1", "kind": "NetworkPolicy", "metadata": {"name": "default-deny", "namespace": "team-a"}}`)),
+		loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config", "namespace": "team-b"}}`)),
+	}
+	contextLoader := factories.DefaultContextLoaderFactory(nil, factories.WithCollectionStore(store))
+	teamA := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "team-a"}}`))
+	teamB := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "team-b"}}`))
+
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, teamA, kyvernov1.Create, nil).WithPolicy(&policy), cfg, contextLoader)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusPass)
+
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, teamB, kyvernov1.Create, nil).WithPolicy(&policy), cfg, contextLoader)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message(), "namespace team-b has no NetworkPolicy")
+
+	// collection rules are not evaluated at admission
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, teamB, kyvernov1.Create, nil).WithPolicy(&policy).WithAdmissionOperation(true), cfg, contextLoader)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 0)
+}
+
+func Test_ValidateJSONSchema(t *testing.T) {
+	policy := loadResource[kyvernov1.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "validate-app-config"},
+		"spec": {
+			"rules": [{
+				"name": "validate-app-config",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"validate": {
+					"message": "invalid config in {{ request.object.metadata.name }}",
+					"jsonSchema": {
+						"selector": "request.object.data.\"config.json\"",
+						"schema": {
+							"type": "object",
+							"required": ["replicas"],
+							"properties": {
+								"replicas": {"type": "integer", "minimum": 1},
+								"mode": {"enum": ["active", "passive"]}
+							}
+						}
+					}
+				}
+			}]
+		}
+	}`))
+	valid := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "valid", "namespace": "default"}, "data": {"config.json": "{\"replicas\": 2, \"mode\": \"active\"}"}}`))
+	invalid := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "invalid", "namespace": "default"}, "data": {"config.json": "{\"replicas\": \"2\", \"mode\": \"standby\"}"}}`))
+	other := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "other", "namespace": "default"}, "data": {"key": "value"}}`))
+
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, valid, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusPass)
+
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, invalid, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message(), `invalid config in invalid; document request.object.data."config.json" does not match the JSON schema: mode: mode must be one of the following: "active", "passive"; replicas: Invalid type. Expected: integer, given: string`)
+
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, other, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusSkip)
+}
+
+type countingConfigMapResolver struct {
+	configMap *corev1.ConfigMap
+	calls     int
+}
+
+func (r *countingConfigMapResolver) Get(_ context.Context, _, _ string) (*corev1.ConfigMap, error) {
+	r.calls++
+	return r.configMap, nil
+}
+
+func Test_ValidateJSONSchemaCache(t *testing.T) {
+	policy := loadResource[kyvernov1.ClusterPolicy](t, []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "validate-app-config", "uid": "6f1c3d4e", "resourceVersion": "1"},
+		"spec": {
+			"rules": [{
+				"name": "validate-app-config",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"validate": {
+					"jsonSchema": {
+						"selector": "request.object.data.\"config.json\"",
+						"configMap": {"namespace": "kyverno", "name": "schemas", "key": "app-config"}
+					}
+				}
+			}]
+		}
+	}`))
+	resolver := &countingConfigMapResolver{
+		configMap: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "schemas", ResourceVersion: "10"},
+			Data:       map[string]string{"app-config": `{"type": "object", "required": ["replicas"]}`},
+		},
+	}
+	compiled := 0
+	schemas := countingSchemaCache{Cache: jsonschema.NewCache(), compiled: &compiled}
+	e := NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		enginecel.NewCache(),
+		schemas,
+		nil,
+		resolver,
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+	)
+	valid := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "valid", "namespace": "default"}, "data": {"config.json": "{\"replicas\": 2}"}}`))
+	invalid := loadUnstructured(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "invalid", "namespace": "default"}, "data": {"config.json": "{}"}}`))
+
+	er := e.Validate(context.TODO(), newPolicyContext(t, valid, kyvernov1.Create, nil).WithPolicy(&policy))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusPass)
+	er = e.Validate(context.TODO(), newPolicyContext(t, invalid, kyvernov1.Create, nil).WithPolicy(&policy))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	// the ConfigMap is read from the resolver on every evaluation but the schema is only compiled once
+	assert.Equal(t, resolver.calls, 2)
+	assert.Equal(t, compiled, 1)
+
+	// a new version of the ConfigMap is compiled again
+	resolver.configMap = resolver.configMap.DeepCopy()
+	resolver.configMap.ResourceVersion = "11"
+	resolver.configMap.Data["app-config"] = `{"type": "object"}`
+	er = e.Validate(context.TODO(), newPolicyContext(t, invalid, kyvernov1.Create, nil).WithPolicy(&policy))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusPass)
+	assert.Equal(t, compiled, 2)
+}
+
+// countingSchemaCache counts the schemas loaded through the cache
+type countingSchemaCache struct {
+	jsonschema.Cache
+	compiled *int
+}
+
+func (c countingSchemaCache) Get(policy kyvernov1.PolicyInterface, rule string, source string, load func() (*gojsonschema.Schema, error)) (*gojsonschema.Schema, error) {
+	return c.Cache.Get(policy, rule, source, func() (*gojsonschema.Schema, error) {
+		*c.compiled++
+		return load()
+	})
+}
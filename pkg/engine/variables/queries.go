@@ -0,0 +1,52 @@
+package variables
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+)
+
+// Queries returns the JMESPath queries found in a document, usually a policy converted to an untyped object.
+// It includes the expressions of {{ }} variables, as they are evaluated by the default variable resolver, and
+// the jmesPath fields of context entries. Variables that depend on the position in the document (@) are skipped.
+func Queries(document interface{}) []string {
+	queries := map[string]struct{}{}
+	walkQueries(document, "", queries)
+	result := make([]string, 0, len(queries))
+	for query := range queries {
+		result = append(result, query)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func walkQueries(document interface{}, key string, queries map[string]struct{}) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			walkQueries(v, k, queries)
+		}
+	case []interface{}:
+		for _, v := range typed {
+			walkQueries(v, "", queries)
+		}
+	case string:
+		vars := regex.RegexVariables.FindAllStringSubmatch(typed, -1)
+		for _, v := range vars {
+			variable, _ := replaceBracesAndTrimSpaces(v[2])
+			if variable == "" || strings.Contains(variable, "@") {
+				continue
+			}
+			queries[variable] = struct{}{}
+			// delete requests evaluate request.object variables against the old object
+			if strings.Contains(variable, "request.object") {
+				queries[strings.ReplaceAll(variable, "request.object", "request.oldObject")] = struct{}{}
+			}
+		}
+		// context entries jmesPath are queries themselves, unless they contain variables
+		if key == "jmesPath" && len(vars) == 0 && typed != "" {
+			queries[typed] = struct{}{}
+		}
+	}
+}
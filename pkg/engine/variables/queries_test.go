@@ -0,0 +1,34 @@
+package variables
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Queries(t *testing.T) {
+	var document interface{}
+	assert.NilError(t, json.Unmarshal([]byte(`{
+		"rules": [
+			{
+				"context": [
+					{ "name": "a", "variable": { "jmesPath": "request.object.spec.containers[].name" } },
+					{ "name": "b", "apiCall": { "urlPath": "/api/v1/namespaces/{{ request.namespace }}", "jmesPath": "items[?name == '{{ a }}']" } }
+				],
+				"preconditions": { "all": [{ "key": "{{ request.object.metadata.name }}", "operator": "Equals", "value": "{{- to_upper(b) }}" }] },
+				"validate": {
+					"pattern": { "metadata": { "name": "{{ @ }}", "labels": { "app": "\\{{ escaped }}", "team": "{{ a }}-{{ b }}" } } }
+				}
+			}
+		]
+	}`), &document))
+	assert.DeepEqual(t, Queries(document), []string{
+		"a",
+		"b",
+		"request.namespace",
+		"request.object.metadata.name",
+		"request.object.spec.containers[].name",
+		"request.oldObject.metadata.name",
+		"to_upper(b)",
+	})
+}
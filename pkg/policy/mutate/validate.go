@@ -8,6 +8,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policy/auth"
@@ -43,6 +44,21 @@ func NewMutateFactory(rule *kyvernov1.Rule, client dclient.Interface, mock bool,
 
 // Validate validates the 'mutate' rule
 func (m *Mutate) Validate(ctx context.Context, _ []string) (warnings []string, path string, err error) {
+	if m.hasCEL() {
+		if m.hasForEach() || m.hasPatchStrategicMerge() || m.hasPatchesJSON6902() {
+			return nil, "cel", fmt.Errorf("only one of `cel`, `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed")
+		}
+		if m.rule.Mutation.Targets != nil {
+			return nil, "cel", fmt.Errorf("`cel` can't be used to mutate existing resources, `targets` must not be set")
+		}
+		if len(m.rule.Mutation.CEL.Mutations) == 0 {
+			return nil, "cel.mutations", fmt.Errorf("at least one mutation is required")
+		}
+		if _, err := enginecel.Compile(*m.rule); err != nil {
+			return nil, "cel", err
+		}
+	}
+
 	if m.hasForEach() {
 		if m.hasPatchStrategicMerge() || m.hasPatchesJSON6902() {
 			return nil, "foreach", fmt.Errorf("only one of `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed")
@@ -101,6 +117,10 @@ func (m *Mutate) hasForEach() bool {
 	return len(m.rule.Mutation.ForEachMutation) > 0
 }
 
+func (m *Mutate) hasCEL() bool {
+	return m.rule.Mutation.CEL != nil
+}
+
 func (m *Mutate) hasPatchStrategicMerge() bool {
 	return m.rule.Mutation.GetPatchStrategicMerge() != nil
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	gojmespath "github.com/kyverno/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/jsonschema"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policy/auth"
 	"github.com/kyverno/kyverno/pkg/policy/auth/fake"
@@ -139,6 +142,25 @@ func (v *Validate) Validate(ctx context.Context, _ []string) (warnings []string,
 				}
 			}
 		}
+
+		// report compilation errors at admission time instead of evaluation time
+		if _, err := enginecel.Compile(*v.rule); err != nil {
+			return nil, "cel", err
+		}
+	}
+
+	if v.validationRule.JSONSchema != nil {
+		if selector := v.validationRule.JSONSchema.Selector; selector != "" {
+			if _, err := gojmespath.NewParser().Parse(selector); err != nil {
+				return nil, "jsonSchema.selector", fmt.Errorf("failed to parse JMESPath %s: %v", selector, err)
+			}
+		}
+		// report schema errors at admission time instead of evaluation time
+		if v.validationRule.JSONSchema.Schema != nil {
+			if _, err := jsonschema.Compile(v.validationRule.JSONSchema.Schema.Raw); err != nil {
+				return nil, "jsonSchema.schema", err
+			}
+		}
 	}
 
 	if w, err := v.validateAuth(ctx); err != nil {
@@ -213,6 +235,10 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.JSONSchema != nil {
+		count++
+	}
+
 	if v.Manifests != nil && len(v.Manifests.Attestors) != 0 {
 		count++
 	}
@@ -380,3 +380,68 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_CEL_CompilationError(t *testing.T) {
+	rawRule := []byte(`
+	{
+		"name": "check-replicas",
+		"validate": {
+			"cel": {
+				"expressions": [
+					{
+						"expression": "object.spec.replicas <="
+					}
+				]
+			}
+		}
+	}`)
+	var rule kyverno.Rule
+	err := json.Unmarshal(rawRule, &rule)
+	assert.NilError(t, err)
+	checker := NewMockValidateFactory(&rule)
+	_, path, err := checker.Validate(context.TODO(), nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, "cel", path)
+}
+
+func Test_Validate_JSONSchema(t *testing.T) {
+	testcases := []struct {
+		name    string
+		rule    string
+		path    string
+		wantErr bool
+	}{{
+		name: "valid",
+		rule: `{"name": "config", "validate": {"jsonSchema": {"selector": "request.object.data.config", "schema": {"type": "object"}}}}`,
+	}, {
+		name:    "invalid selector",
+		rule:    `{"name": "config", "validate": {"jsonSchema": {"selector": "request.object.data.[", "schema": {"type": "object"}}}}`,
+		path:    "jsonSchema.selector",
+		wantErr: true,
+	}, {
+		name:    "invalid schema",
+		rule:    `{"name": "config", "validate": {"jsonSchema": {"schema": {"type": 1}}}}`,
+		path:    "jsonSchema.schema",
+		wantErr: true,
+	}, {
+		name:    "remote reference",
+		rule:    `{"name": "config", "validate": {"jsonSchema": {"schema": {"$ref": "http://example.com/schema.json"}}}}`,
+		path:    "jsonSchema.schema",
+		wantErr: true,
+	}, {
+		name:    "combined with pattern",
+		rule:    `{"name": "config", "validate": {"pattern": {"data": {"config": "?*"}}, "jsonSchema": {"schema": {"type": "object"}}}}`,
+		wantErr: true,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rule kyverno.Rule
+			err := json.Unmarshal([]byte(tc.rule), &rule)
+			assert.NilError(t, err)
+			checker := NewMockValidateFactory(&rule)
+			_, path, err := checker.Validate(context.TODO(), nil)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.path, path)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package policybundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigMediaType is the media type of the policy bundle config
+	ConfigMediaType = "application/vnd.cncf.kyverno.config.v1+json"
+	// PolicyLayerMediaType is the media type of policy layers
+	PolicyLayerMediaType = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+	// ExceptionLayerMediaType is the media type of policy exception layers
+	ExceptionLayerMediaType = "application/vnd.cncf.kyverno.exception.layer.v1+yaml"
+	// GlobalContextEntryLayerMediaType is the media type of global context entry layers
+	GlobalContextEntryLayerMediaType = "application/vnd.cncf.kyverno.globalcontextentry.layer.v1+yaml"
+	// TestLayerMediaType is the media type of test layers, a gzipped tarball of a test and the files it references
+	TestLayerMediaType = "application/vnd.cncf.kyverno.test.layer.v1.tar+gzip"
+)
+
+// maxLayerSize limits the size of the layers read from a bundle
+const maxLayerSize = 16 << 20
+
+// Bundle is the content of a policy bundle image
+type Bundle struct {
+	// Digest is the digest of the image the resources were read from
+	Digest string
+	// Resources are the policies, policy exceptions and global context entries of the bundle
+	Resources []*unstructured.Unstructured
+}
+
+// VerificationOptions converts a policy bundle verification into image verification options
+func VerificationOptions(verification kyvernov2alpha1.PolicyBundleVerification) images.Options {
+	var opts images.Options
+	var rekor *kyvernov1.Rekor
+	var ctlog *kyvernov1.CTLog
+	if keys := verification.Keys; keys != nil {
+		if keys.PublicKeys != "" {
+			opts.Key = keys.PublicKeys
+		} else if keys.Secret != nil {
+			opts.Key = fmt.Sprintf("k8s://%s/%s", keys.Secret.Namespace, keys.Secret.Name)
+		} else if keys.KMS != "" {
+			opts.Key = keys.KMS
+		}
+		opts.SignatureAlgorithm = keys.SignatureAlgorithm
+		rekor, ctlog = keys.Rekor, keys.CTLog
+	} else if keyless := verification.Keyless; keyless != nil {
+		opts.Roots = keyless.Roots
+		opts.Issuer = keyless.Issuer
+		opts.IssuerRegExp = keyless.IssuerRegExp
+		opts.Subject = keyless.Subject
+		opts.SubjectRegExp = keyless.SubjectRegExp
+		opts.AdditionalExtensions = keyless.AdditionalExtensions
+		rekor, ctlog = keyless.Rekor, keyless.CTLog
+	}
+	if rekor != nil {
+		opts.RekorURL = rekor.URL
+		opts.RekorPubKey = rekor.RekorPubKey
+		opts.IgnoreTlog = rekor.IgnoreTlog
+	} else {
+		opts.RekorURL = "https://rekor.sigstore.dev"
+	}
+	if ctlog != nil {
+		opts.IgnoreSCT = ctlog.IgnoreSCT
+		opts.CTLogsPubKey = ctlog.CTLogPubKey
+		opts.TSACertChain = ctlog.TSACertChain
+	}
+	return opts
+}
+
+// Fetch verifies the signature of a policy bundle image and returns its resources.
+// The resources are read from the verified digest, not from the tag which could have been moved in the meantime.
+func Fetch(ctx context.Context, rclient registryclient.Client, verifier images.ImageVerifier, image string, opts images.Options) (*Bundle, error) {
+	ref, err := name.ParseReference(image, rclient.NameOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image reference: %w", err)
+	}
+	opts.ImageRef = ref.String()
+	opts.Client = rclient
+	response, err := verifier.VerifySignature(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("verifying image %s: %w", ref.Name(), err)
+	}
+	if response.Digest == "" {
+		return nil, errors.New("signature does not contain the image digest")
+	}
+	digestRef := ref.Context().Digest(response.Digest)
+	desc, err := rclient.FetchImageDescriptor(ctx, digestRef.String())
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %w", err)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %w", err)
+	}
+	resources, err := Load(img)
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{
+		Digest:    response.Digest,
+		Resources: resources,
+	}, nil
+}
+
+// Load returns the policies, policy exceptions and global context entries of a policy bundle image
+func Load(img v1.Image) ([]*unstructured.Unstructured, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("getting image manifest: %w", err)
+	}
+	if manifest.Config.MediaType != ConfigMediaType {
+		return nil, fmt.Errorf("image config media type %s is not %s", manifest.Config.MediaType, ConfigMediaType)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("getting image layers: %w", err)
+	}
+	var resources []*unstructured.Unstructured
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer media type: %w", err)
+		}
+		var kinds []string
+		switch mediaType {
+		case PolicyLayerMediaType:
+			kinds = []string{"ClusterPolicy", "Policy"}
+		case ExceptionLayerMediaType:
+			kinds = []string{"PolicyException"}
+		case GlobalContextEntryLayerMediaType:
+			kinds = []string{"GlobalContextEntry"}
+		default:
+			// tests and unknown layers are not synchronized
+			continue
+		}
+		content, err := readLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		documents, err := extyaml.SplitDocuments(content)
+		if err != nil {
+			return nil, fmt.Errorf("splitting layer documents: %w", err)
+		}
+		for _, document := range documents {
+			data, err := yaml.YAMLToJSON(document)
+			if err != nil {
+				return nil, fmt.Errorf("converting layer blob: %w", err)
+			}
+			resource, err := kubeutils.BytesToUnstructured(data)
+			if err != nil {
+				return nil, fmt.Errorf("unmarshaling layer blob: %w", err)
+			}
+			if err := checkKind(resource, kinds...); err != nil {
+				return nil, err
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+func checkKind(resource *unstructured.Unstructured, kinds ...string) error {
+	if resource.GroupVersionKind().Group != kyvernov1.GroupName {
+		return fmt.Errorf("unexpected resource %s %s in layer", resource.GetAPIVersion(), resource.GetKind())
+	}
+	for _, kind := range kinds {
+		if resource.GetKind() == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected resource %s %s in layer", resource.GetAPIVersion(), resource.GetKind())
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	blob, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("getting layer blob: %w", err)
+	}
+	defer blob.Close()
+	content, err := io.ReadAll(io.LimitReader(blob, maxLayerSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading layer blob: %w", err)
+	}
+	if len(content) > maxLayerSize {
+		return nil, errors.New("layer exceeds the maximum size")
+	}
+	return content, nil
+}
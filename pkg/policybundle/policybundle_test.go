@@ -0,0 +1,154 @@
+package policybundle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+)
+
+const (
+	policy = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules: []
+`
+	exception = `apiVersion: kyverno.io/v2
+kind: PolicyException
+metadata:
+  name: system-pods
+  namespace: kube-system
+spec:
+  exceptions: []
+`
+	deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+`
+)
+
+func newImage(t *testing.T, configMediaType types.MediaType, layers map[types.MediaType]string) v1.Image {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, configMediaType)
+	for _, mediaType := range []types.MediaType{PolicyLayerMediaType, ExceptionLayerMediaType, TestLayerMediaType} {
+		if content, ok := layers[mediaType]; ok {
+			var err error
+			img, err = mutate.Append(img, mutate.Addendum{Layer: static.NewLayer([]byte(content), mediaType)})
+			assert.NilError(t, err)
+		}
+	}
+	return img
+}
+
+func TestLoad(t *testing.T) {
+	img := newImage(t, ConfigMediaType, map[types.MediaType]string{
+		PolicyLayerMediaType:    policy,
+		ExceptionLayerMediaType: exception,
+		TestLayerMediaType:      "not yaml",
+	})
+	resources, err := Load(img)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 2)
+	assert.Equal(t, resources[0].GetKind(), "ClusterPolicy")
+	assert.Equal(t, resources[0].GetName(), "require-labels")
+	assert.Equal(t, resources[1].GetKind(), "PolicyException")
+	assert.Equal(t, resources[1].GetNamespace(), "kube-system")
+
+	_, err = Load(newImage(t, "application/vnd.oci.image.config.v1+json", nil))
+	assert.ErrorContains(t, err, "image config media type")
+
+	_, err = Load(newImage(t, ConfigMediaType, map[types.MediaType]string{PolicyLayerMediaType: deployment}))
+	assert.ErrorContains(t, err, "unexpected resource apps/v1 Deployment")
+
+	_, err = Load(newImage(t, ConfigMediaType, map[types.MediaType]string{ExceptionLayerMediaType: policy}))
+	assert.ErrorContains(t, err, "unexpected resource kyverno.io/v1 ClusterPolicy")
+}
+
+type fakeVerifier struct {
+	digest string
+	err    error
+}
+
+func (v *fakeVerifier) VerifySignature(context.Context, images.Options) (*images.Response, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return &images.Response{Digest: v.digest}, nil
+}
+
+func (v *fakeVerifier) FetchAttestations(context.Context, images.Options) (*images.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/kyverno/policies:latest"
+	ref, err := name.ParseReference(imageRef)
+	assert.NilError(t, err)
+	signed := newImage(t, ConfigMediaType, map[types.MediaType]string{PolicyLayerMediaType: policy})
+	assert.NilError(t, gcrremote.Write(ref, signed))
+	digest, err := signed.Digest()
+	assert.NilError(t, err)
+	rc, err := registryclient.New()
+	assert.NilError(t, err)
+
+	bundle, err := Fetch(context.TODO(), rc, &fakeVerifier{digest: digest.String()}, imageRef, images.Options{})
+	assert.NilError(t, err)
+	assert.Equal(t, bundle.Digest, digest.String())
+	assert.Equal(t, len(bundle.Resources), 1)
+
+	// the tag is moved after the signature was verified, the verified digest is used
+	moved := newImage(t, ConfigMediaType, map[types.MediaType]string{ExceptionLayerMediaType: exception})
+	assert.NilError(t, gcrremote.Write(ref, moved))
+	bundle, err = Fetch(context.TODO(), rc, &fakeVerifier{digest: digest.String()}, imageRef, images.Options{})
+	assert.NilError(t, err)
+	assert.Equal(t, bundle.Resources[0].GetKind(), "ClusterPolicy")
+
+	_, err = Fetch(context.TODO(), rc, &fakeVerifier{err: errors.New("no matching signatures")}, imageRef, images.Options{})
+	assert.ErrorContains(t, err, "no matching signatures")
+
+	_, err = Fetch(context.TODO(), rc, &fakeVerifier{}, imageRef, images.Options{})
+	assert.ErrorContains(t, err, "signature does not contain the image digest")
+}
+
+func TestVerificationOptions(t *testing.T) {
+	opts := VerificationOptions(kyvernov2alpha1.PolicyBundleVerification{
+		Keys: &kyvernov1.StaticKeyAttestor{
+			Secret: &kyvernov1.SecretReference{Namespace: "kyverno", Name: "cosign"},
+			Rekor:  &kyvernov1.Rekor{IgnoreTlog: true},
+		},
+	})
+	assert.Equal(t, opts.Key, "k8s://kyverno/cosign")
+	assert.Equal(t, opts.IgnoreTlog, true)
+
+	opts = VerificationOptions(kyvernov2alpha1.PolicyBundleVerification{
+		Keyless: &kyvernov1.KeylessAttestor{
+			Subject: "https://github.com/kyverno/policies/.github/workflows/release.yaml@refs/heads/main",
+			Issuer:  "https://token.actions.githubusercontent.com",
+		},
+	})
+	assert.Equal(t, opts.Key, "")
+	assert.Equal(t, opts.Issuer, "https://token.actions.githubusercontent.com")
+	assert.Equal(t, opts.RekorURL, "https://rekor.sigstore.dev")
+}
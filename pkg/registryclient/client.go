@@ -168,6 +168,14 @@ func WithLocalKeychain() Option {
 	}
 }
 
+// WithKeychain provides initialize registry client option that allows to use a given keychain.
+func WithKeychain(keychain authn.Keychain) Option {
+	return func(c *config) error {
+		c.keychain = append(c.keychain, keychain)
+		return nil
+	}
+}
+
 // WithTracing enables tracing in the http client.
 func WithTracing() Option {
 	return func(c *config) error {
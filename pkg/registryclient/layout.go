@@ -0,0 +1,106 @@
+package registryclient
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	imagespecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// NewFromLayout creates a Client serving the content of OCI image layout directories from an in-process registry.
+// Nothing leaves the process, it is meant to run image verification and image registry context entries hermetically.
+// Every manifest in the layout index must carry the full reference it is served under in the
+// `org.opencontainers.image.ref.name` annotation (for example `ghcr.io/kyverno/test-verify-image:signed`),
+// this includes cosign signatures and attestations (`<repository>:sha256-<digest>.sig`).
+// Manifests with a subject (notary signatures for example) are served through the referrers API.
+func NewFromLayout(paths ...string) (Client, error) {
+	transport := &inProcessTransport{
+		handler: registry.New(
+			registry.Logger(log.New(io.Discard, "", 0)),
+			registry.WithReferrersSupport(true),
+		),
+	}
+	for _, path := range paths {
+		if err := loadLayout(transport, path); err != nil {
+			return nil, fmt.Errorf("failed to load image layout %s (%w)", path, err)
+		}
+	}
+	return &client{
+		keychain:  defaultKeychain,
+		transport: transport,
+	}, nil
+}
+
+func loadLayout(transport http.RoundTripper, path string) error {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return err
+	}
+	index, err := p.ImageIndex()
+	if err != nil {
+		return err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return err
+	}
+	for _, desc := range manifest.Manifests {
+		refName := desc.Annotations[imagespecv1.AnnotationRefName]
+		if refName == "" {
+			return fmt.Errorf("manifest %s has no %s annotation", desc.Digest, imagespecv1.AnnotationRefName)
+		}
+		ref, err := name.ParseReference(refName)
+		if err != nil {
+			return err
+		}
+		if desc.MediaType.IsIndex() {
+			child, err := index.ImageIndex(desc.Digest)
+			if err != nil {
+				return err
+			}
+			if err := gcrremote.WriteIndex(ref, child, gcrremote.WithTransport(transport)); err != nil {
+				return fmt.Errorf("failed to load %s (%w)", refName, err)
+			}
+		} else {
+			image, err := index.Image(desc.Digest)
+			if err != nil {
+				return err
+			}
+			if err := gcrremote.Write(ref, image, gcrremote.WithTransport(transport)); err != nil {
+				return fmt.Errorf("failed to load %s (%w)", refName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// inProcessTransport serves requests from an http handler instead of the network.
+// Repositories are prefixed with the registry host so that identical repository names on different registries don't collide.
+type inProcessTransport struct {
+	handler http.Handler
+}
+
+func (t *inProcessTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	// unlike client requests, server requests always have a body
+	if req.Body == nil {
+		req.Body = http.NoBody
+	}
+	if rest, ok := strings.CutPrefix(req.URL.Path, "/v2/"); ok && rest != "" && !strings.HasPrefix(rest, req.URL.Host+"/") {
+		req.URL.Path = "/v2/" + req.URL.Host + "/" + rest
+		req.URL.RawPath = ""
+	}
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package registryclient
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	imagespecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/assert"
+)
+
+func TestNewFromLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "layout")
+	p, err := layout.Write(dir, empty.Index)
+	assert.NilError(t, err)
+	img, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	digest, err := img.Digest()
+	assert.NilError(t, err)
+	assert.NilError(t, p.AppendImage(img, layout.WithAnnotations(map[string]string{imagespecv1.AnnotationRefName: "ghcr.io/kyverno/test:v1"})))
+	// an artifact referring to the image is served through the referrers API
+	desc, err := partial.Descriptor(img)
+	assert.NilError(t, err)
+	artifact, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	artifact = mutate.Subject(artifact, *desc).(v1.Image)
+	assert.NilError(t, p.AppendImage(artifact, layout.WithAnnotations(map[string]string{imagespecv1.AnnotationRefName: "ghcr.io/kyverno/test:artifact"})))
+
+	c, err := NewFromLayout(dir)
+	assert.NilError(t, err)
+
+	fetched, err := c.FetchImageDescriptor(context.TODO(), "ghcr.io/kyverno/test:v1")
+	assert.NilError(t, err)
+	assert.Equal(t, digest, fetched.Digest)
+
+	_, err = c.FetchImageDescriptor(context.TODO(), "docker.io/kyverno/test:v1")
+	assert.ErrorContains(t, err, "failed to fetch image reference")
+
+	ref, err := name.ParseReference("ghcr.io/kyverno/test@" + digest.String())
+	assert.NilError(t, err)
+	opts, err := c.Options(context.TODO())
+	assert.NilError(t, err)
+	referrers, err := gcrremote.Referrers(ref.(name.Digest), opts...)
+	assert.NilError(t, err)
+	manifest, err := referrers.IndexManifest()
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(manifest.Manifests))
+}
+
+func TestNewFromLayoutWithoutRefName(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "layout")
+	p, err := layout.Write(dir, empty.Index)
+	assert.NilError(t, err)
+	img, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, p.AppendImage(img))
+	_, err = NewFromLayout(dir)
+	assert.ErrorContains(t, err, "has no org.opencontainers.image.ref.name annotation")
+}
@@ -13,6 +13,7 @@ type Toggles interface {
 	GenerateValidatingAdmissionPolicy() bool
 	DumpMutatePatches() bool
 	AutogenV2() bool
+	ExplainAdmission() bool
 }
 
 type defaultToggles struct{}
@@ -41,6 +42,10 @@ func (defaultToggles) AutogenV2() bool {
 	return AutogenV2.enabled()
 }
 
+func (defaultToggles) ExplainAdmission() bool {
+	return ExplainAdmission.enabled()
+}
+
 type contextKey struct{}
 
 func NewContext(ctx context.Context, toggles Toggles) context.Context {
@@ -36,6 +36,11 @@ const (
 	AutogenV2Description = "Set the flag to 'true', to enable autogen v2."
 	autogenV2EnvVar      = "FLAG_AUTOGEN_V2"
 	defaultAutogenV2     = false
+	// explain admission
+	ExplainAdmissionFlagName    = "explainAdmission"
+	ExplainAdmissionDescription = "Set the flag to 'true', to add policy evaluation traces to admission response warnings for resources annotated with kyverno.io/explain=true (debug only)."
+	explainAdmissionEnvVar      = "FLAG_EXPLAIN_ADMISSION"
+	defaultExplainAdmission     = false
 )
 
 var (
@@ -45,6 +50,7 @@ var (
 	GenerateValidatingAdmissionPolicy = newToggle(defaultGenerateValidatingAdmissionPolicy, generateValidatingAdmissionPolicyEnvVar)
 	DumpMutatePatches                 = newToggle(defaultDumpMutatePatches, dumpMutatePatchesEnvVar)
 	AutogenV2                         = newToggle(defaultAutogenV2, autogenV2EnvVar)
+	ExplainAdmission                  = newToggle(defaultExplainAdmission, explainAdmissionEnvVar)
 )
 
 type ToggleFlag interface {
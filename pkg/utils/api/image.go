@@ -43,9 +43,9 @@ type imageExtractor struct {
 	JMESPath string
 }
 
-func (i *imageExtractor) ExtractFromResource(resource interface{}, cfg config.Configuration) (map[string]ImageInfo, error) {
+func (i *imageExtractor) ExtractFromResource(resource interface{}, jp jmespath.Interface, cfg config.Configuration) (map[string]ImageInfo, error) {
 	imageInfo := map[string]ImageInfo{}
-	if err := extract(resource, []string{}, i.Key, i.Value, i.Fields, i.JMESPath, &imageInfo, cfg); err != nil {
+	if err := extract(resource, []string{}, i.Key, i.Value, i.Fields, i.JMESPath, &imageInfo, jp, cfg); err != nil {
 		return nil, err
 	}
 	return imageInfo, nil
@@ -59,6 +59,7 @@ func extract(
 	fields []string,
 	jmesPath string,
 	imageInfos *map[string]ImageInfo,
+	jp jmespath.Interface,
 	cfg config.Configuration,
 ) error {
 	if obj == nil {
@@ -68,13 +69,13 @@ func extract(
 		switch typedObj := obj.(type) {
 		case []interface{}:
 			for i, v := range typedObj {
-				if err := extract(v, append(path, strconv.Itoa(i)), keyPath, valuePath, fields[1:], jmesPath, imageInfos, cfg); err != nil {
+				if err := extract(v, append(path, strconv.Itoa(i)), keyPath, valuePath, fields[1:], jmesPath, imageInfos, jp, cfg); err != nil {
 					return err
 				}
 			}
 		case map[string]interface{}:
 			for i, v := range typedObj {
-				if err := extract(v, append(path, i), keyPath, valuePath, fields[1:], jmesPath, imageInfos, cfg); err != nil {
+				if err := extract(v, append(path, i), keyPath, valuePath, fields[1:], jmesPath, imageInfos, jp, cfg); err != nil {
 					return err
 				}
 			}
@@ -103,8 +104,6 @@ func extract(
 			return nil
 		}
 		if jmesPath != "" {
-			// TODO: should be injected
-			jp := jmespath.New(cfg)
 			q, err := jp.Query(jmesPath)
 			if err != nil {
 				return fmt.Errorf("invalid jmespath %s: %v", jmesPath, err)
@@ -127,7 +126,7 @@ func extract(
 		return nil
 	}
 	currentPath := fields[0]
-	return extract(output[currentPath], append(path, currentPath), keyPath, valuePath, fields[1:], jmesPath, imageInfos, cfg)
+	return extract(output[currentPath], append(path, currentPath), keyPath, valuePath, fields[1:], jmesPath, imageInfos, jp, cfg)
 }
 
 func BuildStandardExtractors(tags ...string) []imageExtractor {
@@ -180,14 +179,14 @@ func lookupImageExtractor(kind string, configs kyvernov1.ImageExtractorConfigs)
 	return registeredExtractors[kind]
 }
 
-func ExtractImagesFromResource(resource unstructured.Unstructured, configs kyvernov1.ImageExtractorConfigs, cfg config.Configuration) (map[string]map[string]ImageInfo, error) {
+func ExtractImagesFromResource(resource unstructured.Unstructured, configs kyvernov1.ImageExtractorConfigs, jp jmespath.Interface, cfg config.Configuration) (map[string]map[string]ImageInfo, error) {
 	infos := map[string]map[string]ImageInfo{}
 	extractors := lookupImageExtractor(resource.GetKind(), configs)
 	if extractors != nil && len(extractors) == 0 {
 		return nil, fmt.Errorf("no extractors found for %s", resource.GetKind())
 	}
 	for _, extractor := range extractors {
-		if infoMap, err := extractor.ExtractFromResource(resource.Object, cfg); err != nil {
+		if infoMap, err := extractor.ExtractFromResource(resource.Object, jp, cfg); err != nil {
 			return nil, err
 		} else if len(infoMap) > 0 {
 			infos[extractor.Name] = infoMap
@@ -5,12 +5,16 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
 )
 
-var cfg = config.NewDefaultConfiguration(false)
+var (
+	cfg = config.NewDefaultConfiguration(false)
+	jp  = jmespath.New(cfg)
+)
 
 func Test_extractImageInfo(t *testing.T) {
 	tests := []struct {
@@ -273,7 +277,7 @@ func Test_extractImageInfo(t *testing.T) {
 	for _, test := range tests {
 		resource, err := kubeutils.BytesToUnstructured(test.raw)
 		assert.NilError(t, err)
-		images, err := ExtractImagesFromResource(*resource, test.extractionConfig, cfg)
+		images, err := ExtractImagesFromResource(*resource, test.extractionConfig, jp, cfg)
 		assert.NilError(t, err)
 		assert.DeepEqual(t, test.images, images)
 	}
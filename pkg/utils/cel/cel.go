@@ -1,12 +1,19 @@
 package cel
 
 import (
+	"fmt"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/overloads"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apiserver/pkg/admission/plugin/cel"
 	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
 	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
 	"k8s.io/apiserver/pkg/cel/environment"
+	"k8s.io/utils/clock"
 )
 
 type Compiler struct {
@@ -37,12 +44,93 @@ func NewCompiler(
 	}, nil
 }
 
-func (c Compiler) CompileVariables(optionalVars cel.OptionalVariableDeclarations) {
-	c.compositedCompiler.CompileAndStoreVariables(
-		c.convertVariables(),
-		optionalVars,
-		environment.StoredExpressions,
+// NewRuleCompiler creates a compiler for Kyverno rules. In addition to the standard environment,
+// expressions can use the Kyverno library and the rule context entries under `variables.context`.
+// kyverno.timeNow reads the given clock.
+func NewRuleCompiler(
+	clock clock.PassiveClock,
+	validations []admissionregistrationv1beta1.Validation,
+	auditAnnotations []admissionregistrationv1beta1.AuditAnnotation,
+	matchConditions []admissionregistrationv1.MatchCondition,
+	variables []admissionregistrationv1beta1.Variable,
+) (*Compiler, error) {
+	return newRuleCompiler(clock, validations, auditAnnotations, matchConditions, variables)
+}
+
+// NewMutationCompiler creates a compiler for mutation rules. In addition to the rule environment,
+// list and map literals nested in a dyn() call may hold values of different types so that patches
+// like `{"spec": {"replicas": 3, "paused": false}}` can be written without converting every value.
+func NewMutationCompiler(
+	clock clock.PassiveClock,
+	matchConditions []admissionregistrationv1.MatchCondition,
+	variables []admissionregistrationv1beta1.Variable,
+) (*Compiler, error) {
+	return newRuleCompiler(clock, nil, nil, matchConditions, variables, celgo.ASTValidators(dynLiteralsValidator{}))
+}
+
+func newRuleCompiler(
+	clock clock.PassiveClock,
+	validations []admissionregistrationv1beta1.Validation,
+	auditAnnotations []admissionregistrationv1beta1.AuditAnnotation,
+	matchConditions []admissionregistrationv1.MatchCondition,
+	variables []admissionregistrationv1beta1.Variable,
+	options ...celgo.EnvOption,
+) (*Compiler, error) {
+	envSet, err := environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), false).Extend(
+		environment.VersionedOptions{
+			IntroducedVersion: version.MajorMinor(1, 0),
+			EnvOptions:        append([]celgo.EnvOption{Kyverno(clock)}, options...),
+		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	compositedCompiler, err := cel.NewCompositedCompiler(envSet)
+	if err != nil {
+		return nil, err
+	}
+	if err := storeContextVariable(compositedCompiler); err != nil {
+		return nil, err
+	}
+	return &Compiler{
+		compositedCompiler:         *compositedCompiler,
+		validateExpressions:        validations,
+		auditAnnotationExpressions: auditAnnotations,
+		matchExpressions:           matchConditions,
+		variables:                  variables,
+	}, nil
+}
+
+// dynLiteralsValidator exempts literals nested in dyn() calls from the homogeneous aggregate literals check.
+type dynLiteralsValidator struct{}
+
+func (dynLiteralsValidator) Name() string {
+	return "kyverno.validate.types.dyn"
+}
+
+func (dynLiteralsValidator) Configure(config celgo.MutableValidatorConfig) error {
+	functions := config.GetOrDefault(celgo.HomogeneousAggregateLiteralExemptFunctions, []string{}).([]string)
+	functions = append(functions, overloads.TypeConvertDyn)
+	return config.Set(celgo.HomogeneousAggregateLiteralExemptFunctions, functions)
+}
+
+func (dynLiteralsValidator) Validate(*celgo.Env, celgo.ValidatorConfig, *ast.AST, *celgo.Issues) {}
+
+// CompileVariables compiles variables and stores them so that other expressions can reference them,
+// it returns the compilation errors.
+func (c Compiler) CompileVariables(optionalVars cel.OptionalVariableDeclarations) []error {
+	var errs []error
+	for _, variable := range c.convertVariables() {
+		if _, ok := c.compositedCompiler.CompositionEnv.CompiledVariables[variable.GetName()].Program.(contextProgram); ok {
+			errs = append(errs, fmt.Errorf("variable name %q is reserved", variable.GetName()))
+			continue
+		}
+		result := c.compositedCompiler.CompileAndStoreVariable(variable, optionalVars, environment.StoredExpressions)
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+		}
+	}
+	return errs
 }
 
 func (c Compiler) CompileValidateExpressions(optionalVars cel.OptionalVariableDeclarations) cel.Filter {
@@ -77,6 +165,35 @@ func (c Compiler) CompileMatchExpressions(optionalVars cel.OptionalVariableDecla
 	)
 }
 
+// CompileMutationExpressions compiles every mutation expression into its own filter, so that mutations
+// can be evaluated one after the other against the mutated object.
+func (c Compiler) CompileMutationExpressions(expressions []string, optionalVars cel.OptionalVariableDeclarations) []cel.Filter {
+	filters := make([]cel.Filter, 0, len(expressions))
+	for _, expression := range expressions {
+		filters = append(filters, c.compositedCompiler.Compile(
+			// the expression is wrapped on its own line so that reported error positions only shift by a line
+			[]cel.ExpressionAccessor{&MutationExpression{Expression: "dyn(\n" + expression + "\n)"}},
+			optionalVars,
+			environment.StoredExpressions,
+		))
+	}
+	return filters
+}
+
+// MutationExpression is a CEL expression producing a patch, it can evaluate to any type
+// and the result is checked when the patch is applied.
+type MutationExpression struct {
+	Expression string
+}
+
+func (m *MutationExpression) GetExpression() string {
+	return m.Expression
+}
+
+func (m *MutationExpression) ReturnTypes() []*celgo.Type {
+	return []*celgo.Type{celgo.AnyType}
+}
+
 func (c Compiler) convertValidations() []cel.ExpressionAccessor {
 	celExpressionAccessor := make([]cel.ExpressionAccessor, len(c.validateExpressions))
 	for i, validation := range c.validateExpressions {
@@ -0,0 +1,67 @@
+package cel
+
+import (
+	"context"
+	"fmt"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/cel/environment"
+)
+
+// ContextVarName is the name of the variable exposing the rule context entries, available as `variables.context`.
+// The variable is declared as dyn: entries hold the results of API calls, ConfigMaps, global context
+// entries or JMESPath expressions, their type is only known once they are loaded at evaluation time.
+// Accessing a field that doesn't exist fails at evaluation time instead of compile time.
+const ContextVarName = "context"
+
+// ContextResolver returns the rule context entries indexed by name.
+type ContextResolver func() (map[string]any, error)
+
+type contextKey struct{}
+
+// WithContext returns a context carrying the resolver used to evaluate `variables.context`.
+// The resolver is only invoked if an expression accesses the variable.
+func WithContext(ctx context.Context, resolver ContextResolver) context.Context {
+	return context.WithValue(ctx, contextKey{}, resolver)
+}
+
+// contextProgram evaluates to the rule context entries found in the evaluation context.
+// The embedded program evaluates a constant, it is only used to produce evaluation details
+// as the composition environment requires them to account for the cost of variables.
+type contextProgram struct {
+	celgo.Program
+}
+
+func (p contextProgram) ContextEval(ctx context.Context, input any) (ref.Val, *celgo.EvalDetails, error) {
+	_, details, err := p.Program.ContextEval(ctx, input)
+	if err != nil {
+		return nil, details, err
+	}
+	resolver, ok := ctx.Value(contextKey{}).(ContextResolver)
+	if !ok || resolver == nil {
+		return types.NewStringInterfaceMap(types.DefaultTypeAdapter, map[string]any{}), details, nil
+	}
+	data, err := resolver()
+	if err != nil {
+		return nil, details, fmt.Errorf("failed to resolve context entries: %w", err)
+	}
+	return types.DefaultTypeAdapter.NativeToValue(data), details, nil
+}
+
+// storeContextVariable declares `variables.context` in the composition environment of the compiler.
+func storeContextVariable(compiler *cel.CompositedCompiler) error {
+	result := compiler.Compiler.CompileCELExpression(&MutationExpression{Expression: "null"}, cel.OptionalVariableDeclarations{}, environment.StoredExpressions)
+	if result.Error != nil {
+		return result.Error
+	}
+	compiler.CompositionEnv.AddField(ContextVarName, celgo.DynType)
+	compiler.CompositionEnv.CompiledVariables[ContextVarName] = cel.CompilationResult{
+		Program:            contextProgram{Program: result.Program},
+		ExpressionAccessor: result.ExpressionAccessor,
+		OutputType:         celgo.DynType,
+	}
+	return nil
+}
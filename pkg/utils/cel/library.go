@@ -0,0 +1,172 @@
+package cel
+
+import (
+	"strconv"
+
+	"github.com/blang/semver/v4"
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	"k8s.io/utils/clock"
+)
+
+// Kyverno returns the Kyverno CEL library, it mirrors a subset of the Kyverno JMESPath functions:
+//
+//	kyverno.imageNormalize(<string>) <string>
+//	kyverno.parseImage(<string>) <map<string, string>>
+//	kyverno.semverCompare(<string>, <string>) <bool>
+//	kyverno.x509Decode(<string>) <map<string, dyn>>
+//	kyverno.timeNow() <timestamp>
+//	kyverno.timeToCron(<timestamp>) <string>
+//	kyverno.timeTruncate(<timestamp>, <duration>) <timestamp>
+//
+// Timestamps and durations support arithmetic natively in CEL, e.g. `kyverno.timeNow() + duration('24h')`.
+// kyverno.timeNow reads the given clock, like the time functions of the JMESPath interface returned by jmespath.NewWithClock.
+func Kyverno(clock clock.PassiveClock) celgo.EnvOption {
+	return celgo.Lib(kyvernoLib{clock: clock})
+}
+
+// images are normalized against the default configuration, that is `docker.io` is the default registry
+var imageConfiguration = config.NewDefaultConfiguration(false)
+
+type kyvernoLib struct {
+	clock clock.PassiveClock
+}
+
+func (kyvernoLib) LibraryName() string {
+	return "kyverno.kyverno"
+}
+
+func (l kyvernoLib) CompileOptions() []celgo.EnvOption {
+	return []celgo.EnvOption{
+		celgo.Function("kyverno.imageNormalize",
+			celgo.Overload("kyverno_image_normalize_string", []*celgo.Type{celgo.StringType}, celgo.StringType,
+				celgo.UnaryBinding(imageNormalize),
+			),
+		),
+		celgo.Function("kyverno.parseImage",
+			celgo.Overload("kyverno_parse_image_string", []*celgo.Type{celgo.StringType}, celgo.MapType(celgo.StringType, celgo.StringType),
+				celgo.UnaryBinding(parseImage),
+			),
+		),
+		celgo.Function("kyverno.semverCompare",
+			celgo.Overload("kyverno_semver_compare_string_string", []*celgo.Type{celgo.StringType, celgo.StringType}, celgo.BoolType,
+				celgo.BinaryBinding(semverCompare),
+			),
+		),
+		celgo.Function("kyverno.x509Decode",
+			celgo.Overload("kyverno_x509_decode_string", []*celgo.Type{celgo.StringType}, celgo.MapType(celgo.StringType, celgo.DynType),
+				celgo.UnaryBinding(x509Decode),
+			),
+		),
+		celgo.Function("kyverno.timeNow",
+			celgo.Overload("kyverno_time_now", []*celgo.Type{}, celgo.TimestampType,
+				celgo.FunctionBinding(l.timeNow),
+			),
+		),
+		celgo.Function("kyverno.timeToCron",
+			celgo.Overload("kyverno_time_to_cron_timestamp", []*celgo.Type{celgo.TimestampType}, celgo.StringType,
+				celgo.UnaryBinding(timeToCron),
+			),
+		),
+		celgo.Function("kyverno.timeTruncate",
+			celgo.Overload("kyverno_time_truncate_timestamp_duration", []*celgo.Type{celgo.TimestampType, celgo.DurationType}, celgo.TimestampType,
+				celgo.BinaryBinding(timeTruncate),
+			),
+		),
+	}
+}
+
+func (kyvernoLib) ProgramOptions() []celgo.ProgramOption {
+	return nil
+}
+
+func imageNormalize(arg ref.Val) ref.Val {
+	image, ok := arg.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(arg)
+	}
+	info, err := imageutils.GetImageInfo(string(image), imageConfiguration)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return types.String(info.String())
+}
+
+func parseImage(arg ref.Val) ref.Val {
+	image, ok := arg.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(arg)
+	}
+	info, err := imageutils.GetImageInfo(string(image), imageConfiguration)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return types.NewStringStringMap(types.DefaultTypeAdapter, map[string]string{
+		"registry":         info.Registry,
+		"name":             info.Name,
+		"path":             info.Path,
+		"tag":              info.Tag,
+		"digest":           info.Digest,
+		"reference":        info.Reference,
+		"referenceWithTag": info.ReferenceWithTag,
+	})
+}
+
+func semverCompare(lhs, rhs ref.Val) ref.Val {
+	version, ok := lhs.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(lhs)
+	}
+	expected, ok := rhs.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(rhs)
+	}
+	// like semver_compare, an invalid version doesn't match any range
+	v, _ := semver.Parse(string(version))
+	expectedRange, err := semver.ParseRange(string(expected))
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return types.Bool(expectedRange(v))
+}
+
+func x509Decode(arg ref.Val) ref.Val {
+	input, ok := arg.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(arg)
+	}
+	decoded, err := jmespath.X509Decode(string(input))
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return types.DefaultTypeAdapter.NativeToValue(decoded)
+}
+
+func (l kyvernoLib) timeNow(...ref.Val) ref.Val {
+	return types.Timestamp{Time: l.clock.Now().UTC()}
+}
+
+func timeToCron(arg ref.Val) ref.Val {
+	ts, ok := arg.(types.Timestamp)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(arg)
+	}
+	t := ts.Time
+	return types.String(strconv.Itoa(t.Minute()) + " " + strconv.Itoa(t.Hour()) + " " + strconv.Itoa(t.Day()) + " " + strconv.Itoa(int(t.Month())) + " " + strconv.Itoa(int(t.Weekday())))
+}
+
+func timeTruncate(lhs, rhs ref.Val) ref.Val {
+	ts, ok := lhs.(types.Timestamp)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(lhs)
+	}
+	d, ok := rhs.(types.Duration)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(rhs)
+	}
+	return types.Timestamp{Time: ts.Time.Truncate(d.Duration)}
+}
@@ -0,0 +1,69 @@
+package cel
+
+import (
+	"testing"
+	"time"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+const certificate = "-----BEGIN CERTIFICATE-----\nMIIC7TCCAdWgAwIBAgIBADANBgkqhkiG9w0BAQsFADAYMRYwFAYDVQQDDA0qLmt5\ndmVybm8uc3ZjMB4XDTIyMDExMTEzMjY0M1oXDTIzMDExMTE0MjY0M1owGDEWMBQG\nA1UEAwwNKi5reXZlcm5vLnN2YzCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoC\nggEBAMsAz85+yino+MmdKsVtHwNi3oAVjumzXHiLfUJK7xi5KU8B7goPHF/VCe/V\n7Y2c4afyfgY2ePw4LxSDkCYNgYwqjSwGIbcsqv5ZRazBdDxR09ri6PknNyBVGLi5\nRlPXIrGQ3psNuf55qwxJxLO31qCZuvktKY5YvuIR4JPmBhuSFXOnn0ZiQw8uxMcQ\n0QA2lz+PxWCVNk9q+31H5DH1oYZDLfU3mijIOA+AJGZbBb+ZwBmpVL0+2TXLxE74\nWowdKEV+WTsKojNTd0VwcuRKRKR/6ynXAAis21y1X7Ui9FJE6mDIylUD40WXOKGJ\n1lYY41kRnYhVhvXYN9JtNYdY3HsCAwEAAaNCMEAwDgYDVR0PAQH/BAQDAgKkMA8G\nA1UdEwEB/wQFMAMBAf8wHQYDVR0OBBYEFOnlASVD9fu3TAjptlW/gAXA4ql+MA0G\nCSqGSIb3DQEBCwUAA4IBAQCIpyRiChxp97crKfQ24Jt7z8P+AGpLf3sX4eL87ESa\n7QRoVJtXLmaut1pUEoYLQruKmh/0YFtZG9WxVgY6iuKbWnu7bOeMB/Ir+V/yrX3R\n+XvZOsuXiJnEbJiBW6lJzLldoW4f/71H+j1WD4tHpqmdMxq/sLqXfPIuc0/m0yFC\nn+ADBWGGB8Nn66vxtv+cT6p+RIVotXPQWbMilWp6pd5wSuB68FqrDwtYLNJtPwFs\n9MPVkuaJdYZ0eWd/rMcKD94Hgf89gvA0+qzMVFf+3BemXskjQRYy6CKsqoyC6jX4\nnhYjumAP/7psbzITsnpHtfCEEU+2JZwgM406aiMcsgLb\n-----END CERTIFICATE-----"
+
+var now = time.Date(2024, time.June, 1, 12, 30, 0, 0, time.UTC)
+
+func evaluate(t *testing.T, expression string, vars map[string]any) (any, error) {
+	env, err := celgo.NewEnv(Kyverno(clocktesting.NewFakePassiveClock(now)), celgo.Variable("cert", celgo.StringType))
+	require.NoError(t, err)
+	ast, issues := env.Compile(expression)
+	require.NoError(t, issues.Err())
+	program, err := env.Program(ast)
+	require.NoError(t, err)
+	out, _, err := program.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
+
+func TestKyvernoLibrary(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   any
+	}{
+		{expression: `kyverno.imageNormalize("nginx")`, expected: "docker.io/nginx:latest"},
+		{expression: `kyverno.imageNormalize("ghcr.io/kyverno/kyverno:v1.12.0")`, expected: "ghcr.io/kyverno/kyverno:v1.12.0"},
+		{expression: `kyverno.parseImage("ghcr.io/kyverno/kyverno:v1.12.0").path`, expected: "kyverno/kyverno"},
+		{expression: `kyverno.parseImage("busybox").registry`, expected: "docker.io"},
+		{expression: `kyverno.semverCompare("1.12.3", ">=1.12.0")`, expected: true},
+		{expression: `kyverno.semverCompare("1.11.9", ">=1.12.0")`, expected: false},
+		{expression: `kyverno.semverCompare("invalid", ">=1.12.0")`, expected: false},
+		{expression: `kyverno.x509Decode(cert).Subject.CommonName`, expected: "*.kyverno.svc"},
+		{expression: `kyverno.timeToCron(timestamp("2023-02-02T15:04:05Z"))`, expected: "4 15 2 2 4"},
+		{expression: `kyverno.timeTruncate(timestamp("2023-02-02T15:04:05Z"), duration("1h")) == timestamp("2023-02-02T15:00:00Z")`, expected: true},
+		{expression: `kyverno.timeNow() + duration("1h") > kyverno.timeNow()`, expected: true},
+		{expression: `kyverno.timeNow() == timestamp("2024-06-01T12:30:00Z")`, expected: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			out, err := evaluate(t, tc.expression, map[string]any{"cert": certificate})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestKyvernoLibraryErrors(t *testing.T) {
+	for _, expression := range []string{
+		`kyverno.imageNormalize("Invalid:Image:Name")`,
+		`kyverno.semverCompare("1.12.0", "not a range")`,
+		`kyverno.x509Decode("not a certificate")`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := evaluate(t, expression, map[string]any{"cert": certificate})
+			assert.Error(t, err)
+		})
+	}
+}
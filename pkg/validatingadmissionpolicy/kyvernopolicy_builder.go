@@ -0,0 +1,214 @@
+package validatingadmissionpolicy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// BuildKyvernoPolicy is used to build a Kyverno policy with a single CEL rule from a Kubernetes ValidatingAdmissionPolicy
+// and an optional ValidatingAdmissionPolicyBinding. It is the reverse of BuildValidatingAdmissionPolicy.
+func BuildKyvernoPolicy(
+	discoveryClient dclient.IDiscovery,
+	vap admissionregistrationv1beta1.ValidatingAdmissionPolicy,
+	binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding,
+) (*kyvernov1.ClusterPolicy, error) {
+	if vap.Spec.MatchConstraints == nil {
+		return nil, fmt.Errorf("ValidatingAdmissionPolicy %s has no match constraints", vap.Name)
+	}
+	if binding != nil && binding.Spec.MatchResources != nil {
+		return nil, fmt.Errorf("ValidatingAdmissionPolicyBinding %s: match resources in bindings are not applicable", binding.Name)
+	}
+
+	// convert the match constraints
+	constraints := vap.Spec.MatchConstraints
+	match, err := translateResourceRules(discoveryClient, constraints.ResourceRules)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, namespaceSelector := translateNamespaceSelector(constraints.NamespaceSelector)
+	for i := range match {
+		match[i].Namespaces = namespaces
+		match[i].NamespaceSelector = namespaceSelector
+		match[i].Selector = constraints.ObjectSelector
+	}
+	exclude, err := translateResourceRules(discoveryClient, constraints.ExcludeResourceRules)
+	if err != nil {
+		return nil, err
+	}
+
+	// set validation action from the binding, policies without a binding are only audited
+	failureAction := kyvernov1.Audit
+	var paramRef *admissionregistrationv1beta1.ParamRef
+	if binding != nil {
+		if slices.Contains(binding.Spec.ValidationActions, admissionregistrationv1beta1.Deny) {
+			failureAction = kyvernov1.Enforce
+		}
+		paramRef = binding.Spec.ParamRef
+	}
+
+	rule := kyvernov1.Rule{
+		Name: vap.Name,
+		MatchResources: kyvernov1.MatchResources{
+			Any: match,
+		},
+		CELPreconditions: vap.Spec.MatchConditions,
+		Validation: &kyvernov1.Validation{
+			FailureAction: &failureAction,
+			CEL: &kyvernov1.CEL{
+				Expressions:      vap.Spec.Validations,
+				ParamKind:        vap.Spec.ParamKind,
+				ParamRef:         paramRef,
+				AuditAnnotations: vap.Spec.AuditAnnotations,
+				Variables:        vap.Spec.Variables,
+			},
+		},
+	}
+	if len(exclude) != 0 {
+		rule.ExcludeResources = &kyvernov1.MatchResources{
+			Any: exclude,
+		}
+	}
+
+	policy := &kyvernov1.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kyvernov1.SchemeGroupVersion.String(),
+			Kind:       "ClusterPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: vap.Name,
+		},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{rule},
+		},
+	}
+	if vap.Spec.FailurePolicy != nil {
+		failurePolicy := kyvernov1.FailurePolicyType(*vap.Spec.FailurePolicy)
+		policy.Spec.FailurePolicy = &failurePolicy
+	}
+	return policy, nil
+}
+
+func translateResourceRules(discoveryClient dclient.IDiscovery, rules []admissionregistrationv1beta1.NamedRuleWithOperations) (kyvernov1.ResourceFilters, error) {
+	var filters kyvernov1.ResourceFilters
+	for _, rule := range rules {
+		// the builder adds an exclude rule matching namespaces by name when a namespace is excluded,
+		// translate it back to the namespaces field
+		if len(rule.Resources) == 1 && rule.Resources[0] == "namespaces" && len(rule.ResourceNames) != 0 {
+			filters = append(filters, kyvernov1.ResourceFilter{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Namespaces: rule.ResourceNames,
+					Operations: reverseOperations(rule.Operations),
+				},
+			})
+			continue
+		}
+		var kinds []string
+		for _, group := range rule.APIGroups {
+			for _, version := range rule.APIVersions {
+				for _, resource := range rule.Resources {
+					kind, err := translateResourceToKind(discoveryClient, group, version, resource)
+					if err != nil {
+						return nil, err
+					}
+					if kind != "" && !slices.Contains(kinds, kind) {
+						kinds = append(kinds, kind)
+					}
+				}
+			}
+		}
+		filters = append(filters, kyvernov1.ResourceFilter{
+			ResourceDescription: kyvernov1.ResourceDescription{
+				Kinds:      kinds,
+				Names:      rule.ResourceNames,
+				Operations: reverseOperations(rule.Operations),
+			},
+		})
+	}
+	return filters, nil
+}
+
+// translateResourceToKind returns the kind selector of a resource, matched resources in validating admission policies
+// are written in the following format:
+// apiGroups:   ["group"]
+// apiVersions: ["version"]
+// resources:   ["resource/subresource"]
+// whereas kinds in Kyverno policies are written in the following format:
+// group/version/kind/subresource
+func translateResourceToKind(discoveryClient dclient.IDiscovery, group, version, resource string) (string, error) {
+	if resource == "*" || resource == "*/*" {
+		return "*", nil
+	}
+	resource, subresource, _ := strings.Cut(resource, "/")
+	// pods/ephemeralcontainers is added by the builder whenever pods are matched
+	if resource == "pods" && subresource == "ephemeralcontainers" {
+		return "", nil
+	}
+	if group == "*" || version == "*" || resource == "*" {
+		return "", fmt.Errorf("wildcard group, version or resource %s/%s/%s is not applicable", group, version, resource)
+	}
+	gvk, err := discoveryClient.GetGVKFromGVR(schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+	if err != nil {
+		return "", err
+	}
+	if gvk.Kind == "" {
+		return "", fmt.Errorf("no kind found for resource %s/%s/%s", group, version, resource)
+	}
+	kind := gvk.Kind
+	if group != "" || version != "v1" {
+		kind = strings.Join([]string{gvk.Group, gvk.Version, gvk.Kind}, "/")
+		kind = strings.TrimPrefix(kind, "/")
+	}
+	if subresource != "" {
+		kind = kind + "/" + subresource
+	}
+	return kind, nil
+}
+
+// translateNamespaceSelector converts back the namespace selector generated by the builder when namespaces are matched by name
+func translateNamespaceSelector(selector *metav1.LabelSelector) ([]string, *metav1.LabelSelector) {
+	if selector == nil {
+		return nil, nil
+	}
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return nil, nil
+	}
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 1 {
+		expression := selector.MatchExpressions[0]
+		if expression.Key == "kubernetes.io/metadata.name" && expression.Operator == metav1.LabelSelectorOpIn {
+			return expression.Values, nil
+		}
+	}
+	return nil, selector
+}
+
+func reverseOperations(operations []admissionregistrationv1.OperationType) []kyvernov1.AdmissionOperation {
+	var kyvernoOperations []kyvernov1.AdmissionOperation
+	for _, op := range operations {
+		switch op {
+		case admissionregistrationv1.OperationAll:
+			return nil
+		case admissionregistrationv1.Create:
+			kyvernoOperations = append(kyvernoOperations, kyvernov1.Create)
+		case admissionregistrationv1.Update:
+			kyvernoOperations = append(kyvernoOperations, kyvernov1.Update)
+		case admissionregistrationv1.Delete:
+			kyvernoOperations = append(kyvernoOperations, kyvernov1.Delete)
+		case admissionregistrationv1.Connect:
+			kyvernoOperations = append(kyvernoOperations, kyvernov1.Connect)
+		}
+	}
+
+	// all operations are matched by default in Kyverno policies
+	if len(kyvernoOperations) == 4 {
+		return nil
+	}
+	return kyvernoOperations
+}
@@ -0,0 +1,181 @@
+package validatingadmissionpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+type fakeDiscovery struct {
+	dclient.IDiscovery
+	kinds map[schema.GroupVersionResource]string
+}
+
+func (d fakeDiscovery) GetGVKFromGVR(gvr schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	if kind, ok := d.kinds[gvr]; ok {
+		return gvr.GroupVersion().WithKind(kind), nil
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("not found")
+}
+
+func Test_Build_Kyverno_Policy(t *testing.T) {
+	discovery := fakeDiscovery{
+		kinds: map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "pods"}:                       "Pod",
+			{Group: "apps", Version: "v1", Resource: "deployments"}: "Deployment",
+		},
+	}
+	testCases := []struct {
+		name     string
+		vap      []byte
+		binding  []byte
+		expected []byte
+		err      string
+	}{
+		{
+			name: "with-binding",
+			vap: []byte(`
+metadata:
+  name: check-replicas
+spec:
+  failurePolicy: Ignore
+  matchConstraints:
+    namespaceSelector:
+      matchExpressions:
+      - key: kubernetes.io/metadata.name
+        operator: In
+        values:
+        - production
+    resourceRules:
+    - apiGroups: ["apps"]
+      apiVersions: ["v1"]
+      operations: ["CREATE", "UPDATE"]
+      resources: ["deployments"]
+    - apiGroups: [""]
+      apiVersions: ["v1"]
+      operations: ["*"]
+      resources: ["pods", "pods/ephemeralcontainers", "pods/status"]
+    excludeResourceRules:
+    - apiGroups: [""]
+      apiVersions: ["v1"]
+      operations: ["CREATE", "UPDATE", "CONNECT", "DELETE"]
+      resources: ["namespaces"]
+      resourceNames: ["kube-system"]
+  validations:
+  - expression: object.spec.replicas <= 5
+`),
+			binding: []byte(`
+metadata:
+  name: check-replicas-binding
+spec:
+  policyName: check-replicas
+  validationActions: [Deny]
+`),
+			expected: []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-replicas
+spec:
+  failurePolicy: Ignore
+  rules:
+  - name: check-replicas
+    match:
+      any:
+      - resources:
+          kinds: ["apps/v1/Deployment"]
+          namespaces: ["production"]
+          operations: ["CREATE", "UPDATE"]
+      - resources:
+          kinds: ["Pod", "Pod/status"]
+          namespaces: ["production"]
+    exclude:
+      any:
+      - resources:
+          namespaces: ["kube-system"]
+    validate:
+      failureAction: Enforce
+      cel:
+        expressions:
+        - expression: object.spec.replicas <= 5
+`),
+		},
+		{
+			name: "without-binding",
+			vap: []byte(`
+metadata:
+  name: check-labels
+spec:
+  matchConstraints:
+    resourceRules:
+    - apiGroups: ["*"]
+      apiVersions: ["*"]
+      operations: ["CREATE"]
+      resources: ["*"]
+  validations:
+  - expression: "'team' in object.metadata.labels"
+`),
+			expected: []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-labels
+spec:
+  rules:
+  - name: check-labels
+    match:
+      any:
+      - resources:
+          kinds: ["*"]
+          operations: ["CREATE"]
+    validate:
+      failureAction: Audit
+      cel:
+        expressions:
+        - expression: "'team' in object.metadata.labels"
+`),
+		},
+		{
+			name: "unknown-resource",
+			vap: []byte(`
+metadata:
+  name: check-crd
+spec:
+  matchConstraints:
+    resourceRules:
+    - apiGroups: ["example.com"]
+      apiVersions: ["v1"]
+      resources: ["widgets"]
+  validations:
+  - expression: "true"
+`),
+			err: "not found",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var vap admissionregistrationv1beta1.ValidatingAdmissionPolicy
+			assert.NilError(t, yaml.Unmarshal(test.vap, &vap))
+			var binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding
+			if test.binding != nil {
+				binding = &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+				assert.NilError(t, yaml.Unmarshal(test.binding, binding))
+			}
+			policy, err := BuildKyvernoPolicy(discovery, vap, binding)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NilError(t, err)
+			var expected kyvernov1.ClusterPolicy
+			assert.NilError(t, yaml.Unmarshal(test.expected, &expected))
+			assert.DeepEqual(t, expected, *policy)
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/ext/wildcard"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
 )
 
 // CanGenerateVAP check if Kyverno policy and a PolicyException can be translated to a Kubernetes ValidatingAdmissionPolicy
@@ -127,9 +130,45 @@ func checkRuleType(rule kyvernov1.Rule) (bool, string) {
 		msg = "skip generating ValidatingAdmissionPolicy for non CEL rules."
 		return false, msg
 	}
+	if len(rule.Context) > 0 {
+		msg = "skip generating ValidatingAdmissionPolicy: context entries are not applicable."
+		return false, msg
+	}
+	if !compilesUpstream(rule) {
+		msg = "skip generating ValidatingAdmissionPolicy: Kyverno CEL functions are not applicable."
+		return false, msg
+	}
 	return true, msg
 }
 
+// compilesUpstream checks that the CEL expressions of the rule compile in the Kubernetes environment,
+// i.e. they don't use the Kyverno library or variables.
+func compilesUpstream(rule kyvernov1.Rule) bool {
+	matchConditions := make([]admissionregistrationv1.MatchCondition, 0, len(rule.CELPreconditions))
+	for _, condition := range rule.CELPreconditions {
+		matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition(condition))
+	}
+	compiler, err := celutils.NewCompiler(rule.Validation.CEL.Expressions, rule.Validation.CEL.AuditAnnotations, matchConditions, rule.Validation.CEL.Variables)
+	if err != nil {
+		return false
+	}
+	optionalVars := cel.OptionalVariableDeclarations{HasParams: rule.Validation.CEL.HasParam(), HasAuthorizer: true}
+	if errs := compiler.CompileVariables(optionalVars); len(errs) > 0 {
+		return false
+	}
+	for _, filter := range []cel.Filter{
+		compiler.CompileValidateExpressions(optionalVars),
+		compiler.CompileMessageExpressions(optionalVars),
+		compiler.CompileAuditAnnotationsExpressions(optionalVars),
+		compiler.CompileMatchExpressions(optionalVars),
+	} {
+		if len(filter.CompilationErrors()) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func checkResources(resource kyvernov1.ResourceDescription, isMatch bool) (bool, string) {
 	var msg string
 	if !isMatch {
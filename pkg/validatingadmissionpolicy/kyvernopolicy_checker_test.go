@@ -517,6 +517,98 @@ func Test_Can_Generate_ValidatingAdmissionPolicy(t *testing.T) {
     ]
   }
 }
+`),
+			expected: false,
+		},
+		{
+			name: "policy-with-context-entries",
+			policy: []byte(`
+{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "allowed-registries"
+  },
+  "spec": {
+    "validationFailureAction": "Enforce",
+    "rules": [
+      {
+        "name": "allowed-registries",
+        "match": {
+          "any": [
+            {
+              "resources": {
+                "kinds": [
+                  "Pod"
+                ]
+              }
+            }
+          ]
+        },
+        "context": [
+          {
+            "name": "allowed",
+            "variable": {
+              "value": [
+                "ghcr.io"
+              ]
+            }
+          }
+        ],
+        "validate": {
+          "cel": {
+            "expressions": [
+              {
+                "expression": "object.spec.containers.all(c, c.image.startsWith('ghcr.io/'))"
+              }
+            ]
+          }
+        }
+      }
+    ]
+  }
+}
+`),
+			expected: false,
+		},
+		{
+			name: "policy-with-kyverno-cel-functions",
+			policy: []byte(`
+{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "allowed-registries"
+  },
+  "spec": {
+    "validationFailureAction": "Enforce",
+    "rules": [
+      {
+        "name": "allowed-registries",
+        "match": {
+          "any": [
+            {
+              "resources": {
+                "kinds": [
+                  "Pod"
+                ]
+              }
+            }
+          ]
+        },
+        "validate": {
+          "cel": {
+            "expressions": [
+              {
+                "expression": "object.spec.containers.all(c, kyverno.parseImage(c.image).registry == 'ghcr.io')"
+              }
+            ]
+          }
+        }
+      }
+    ]
+  }
+}
 `),
 			expected: false,
 		},
@@ -0,0 +1,497 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+var requestVariable = regexp.MustCompile(`(^|[^.\w])request\.(\w+)`)
+
+// Checks returns all the checks supported by the linter.
+func Checks() []Check {
+	return []Check{
+		{
+			ID:          "external-context-in-foreach",
+			Description: "apiCall or imageRegistry context entries declared inside a foreach are evaluated once per element",
+			Severity:    SeverityWarning,
+			Run:         checkExternalContextInForeach,
+		},
+		{
+			ID:          "unused-context-entry",
+			Description: "context entries that are never referenced are loaded for nothing",
+			Severity:    SeverityWarning,
+			Run:         checkUnusedContextEntries,
+		},
+		{
+			ID:          "unconditional-pod-rule",
+			Description: "rules matching every Pod without any selector or precondition run on all Pod requests",
+			Severity:    SeverityInfo,
+			Run:         checkUnconditionalPodRules,
+		},
+		{
+			ID:          "background-request-variables",
+			Description: "background policies referencing request variables that are not available during background scans",
+			Severity:    SeverityWarning,
+			Run:         checkBackgroundRequestVariables,
+		},
+		{
+			ID:          "wildcard-kind",
+			Description: "rules matching wildcard kinds are invoked for every resource type",
+			Severity:    SeverityWarning,
+			Run:         checkWildcardKinds,
+		},
+		{
+			ID:          "overlapping-mutate-rules",
+			Description: "mutate rules patching the same fields of the same kinds depend on execution order",
+			Severity:    SeverityWarning,
+			Run:         checkOverlappingMutateRules,
+		},
+	}
+}
+
+func checkExternalContextInForeach(policies ...kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	for _, policy := range policies {
+		for i, rule := range policy.GetSpec().Rules {
+			for _, ctx := range foreachContexts(field.NewPath("spec").Child("rules").Index(i), rule) {
+				for j, entry := range ctx.entries {
+					var kind string
+					if entry.APICall != nil {
+						kind = "apiCall"
+					} else if entry.ImageRegistry != nil {
+						kind = "imageRegistry"
+					} else {
+						continue
+					}
+					findings = append(findings, Finding{
+						Policy:  policyName(policy),
+						Rule:    rule.Name,
+						Path:    ctx.path.Index(j).String(),
+						Message: fmt.Sprintf("%s context entry %s is evaluated for every foreach element, consider moving it to the rule context", kind, entry.Name),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkUnusedContextEntries(policies ...kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	for _, policy := range policies {
+		for i, rule := range policy.GetSpec().Rules {
+			path := field.NewPath("spec").Child("rules").Index(i)
+			expressions := ruleExpressions(rule)
+			contexts := append([]contextEntries{{path: path.Child("context"), entries: rule.Context}}, foreachContexts(path, rule)...)
+			for _, ctx := range contexts {
+				for j, entry := range ctx.entries {
+					if isReferenced(entry.Name, expressions) {
+						continue
+					}
+					findings = append(findings, Finding{
+						Policy:  policyName(policy),
+						Rule:    rule.Name,
+						Path:    ctx.path.Index(j).String(),
+						Message: fmt.Sprintf("context entry %s is never referenced", entry.Name),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkUnconditionalPodRules(policies ...kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	for _, policy := range policies {
+		for i, rule := range policy.GetSpec().Rules {
+			if rule.RawAnyAllConditions != nil || len(rule.CELPreconditions) != 0 {
+				continue
+			}
+			if rule.ExcludeResources != nil && !isEmptyMatch(*rule.ExcludeResources) {
+				continue
+			}
+			if !matchesAllPods(rule.MatchResources) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Policy:  policyName(policy),
+				Rule:    rule.Name,
+				Path:    field.NewPath("spec").Child("rules").Index(i).Child("match").String(),
+				Message: "rule matches all Pods and has no preconditions or exclusions",
+			})
+		}
+	}
+	return findings
+}
+
+func checkBackgroundRequestVariables(policies ...kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	for _, policy := range policies {
+		if !policy.GetSpec().BackgroundProcessingEnabled() {
+			continue
+		}
+		for i, rule := range policy.GetSpec().Rules {
+			// generate and mutate existing rules store the admission request for background processing
+			if !rule.HasValidate() && !rule.HasVerifyImages() {
+				continue
+			}
+			reported := map[string]bool{}
+			for _, expression := range ruleExpressions(rule) {
+				for _, match := range requestVariable.FindAllStringSubmatch(expression, -1) {
+					variable := "request." + match[2]
+					if match[2] == "object" || reported[variable] {
+						continue
+					}
+					reported[variable] = true
+					findings = append(findings, Finding{
+						Policy:  policyName(policy),
+						Rule:    rule.Name,
+						Path:    field.NewPath("spec").Child("rules").Index(i).String(),
+						Message: fmt.Sprintf("variable %s is not available in background scans, set background to false or remove the reference", variable),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkWildcardKinds(policies ...kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	for _, policy := range policies {
+		for i, rule := range policy.GetSpec().Rules {
+			for _, kind := range rule.MatchResources.GetKinds() {
+				if !strings.Contains(kind, "*") {
+					continue
+				}
+				findings = append(findings, Finding{
+					Policy:  policyName(policy),
+					Rule:    rule.Name,
+					Path:    field.NewPath("spec").Child("rules").Index(i).Child("match").String(),
+					Message: fmt.Sprintf("rule matches wildcard kind %s", kind),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+type mutateRule struct {
+	policy string
+	rule   string
+	path   *field.Path
+	kinds  []string
+	paths  []string
+}
+
+func checkOverlappingMutateRules(policies ...kyvernov1.PolicyInterface) []Finding {
+	var rules []mutateRule
+	for _, policy := range policies {
+		for i, rule := range policy.GetSpec().Rules {
+			if !rule.HasMutateStandard() {
+				continue
+			}
+			paths := mutatedPaths(rule.Mutation)
+			if len(paths) == 0 {
+				continue
+			}
+			rules = append(rules, mutateRule{
+				policy: policyName(policy),
+				rule:   rule.Name,
+				path:   field.NewPath("spec").Child("rules").Index(i).Child("mutate"),
+				kinds:  rule.MatchResources.GetKinds(),
+				paths:  paths,
+			})
+		}
+	}
+	var findings []Finding
+	for j := range rules {
+		for i := 0; i < j; i++ {
+			if !kindsOverlap(rules[i].kinds, rules[j].kinds) {
+				continue
+			}
+			if path, ok := pathsOverlap(rules[i].paths, rules[j].paths); ok {
+				findings = append(findings, Finding{
+					Policy:  rules[j].policy,
+					Rule:    rules[j].rule,
+					Path:    rules[j].path.String(),
+					Message: fmt.Sprintf("rule patches %s which is also patched by rule %s/%s", path, rules[i].policy, rules[i].rule),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+type contextEntries struct {
+	path    *field.Path
+	entries []kyvernov1.ContextEntry
+}
+
+// foreachContexts returns the context entries declared in foreach blocks of a rule, including nested ones.
+func foreachContexts(path *field.Path, rule kyvernov1.Rule) []contextEntries {
+	var out []contextEntries
+	if rule.Validation != nil {
+		out = append(out, validationForeachContexts(path.Child("validate").Child("foreach"), rule.Validation.ForEachValidation)...)
+	}
+	if rule.Mutation != nil {
+		out = append(out, mutationForeachContexts(path.Child("mutate").Child("foreach"), rule.Mutation.ForEachMutation)...)
+	}
+	if rule.Generation != nil {
+		for i, foreach := range rule.Generation.ForEachGeneration {
+			if len(foreach.Context) != 0 {
+				out = append(out, contextEntries{path: path.Child("generate").Child("foreach").Index(i).Child("context"), entries: foreach.Context})
+			}
+		}
+	}
+	return out
+}
+
+func validationForeachContexts(path *field.Path, foreaches []kyvernov1.ForEachValidation) []contextEntries {
+	var out []contextEntries
+	for i, foreach := range foreaches {
+		if len(foreach.Context) != 0 {
+			out = append(out, contextEntries{path: path.Index(i).Child("context"), entries: foreach.Context})
+		}
+		if foreach.ForEachValidation != nil {
+			out = append(out, validationForeachContexts(path.Index(i).Child("foreach"), foreach.ForEachValidation.Items)...)
+		}
+	}
+	return out
+}
+
+func mutationForeachContexts(path *field.Path, foreaches []kyvernov1.ForEachMutation) []contextEntries {
+	var out []contextEntries
+	for i, foreach := range foreaches {
+		if len(foreach.Context) != 0 {
+			out = append(out, contextEntries{path: path.Index(i).Child("context"), entries: foreach.Context})
+		}
+		if foreach.ForEachMutation != nil {
+			out = append(out, mutationForeachContexts(path.Index(i).Child("foreach"), foreach.ForEachMutation.Items)...)
+		}
+	}
+	return out
+}
+
+// ruleExpressions returns the variables ({{ ... }}) used in a rule and the raw JMESPath expressions
+// evaluated against the context (foreach lists and variable context entries).
+func ruleExpressions(rule kyvernov1.Rule) []string {
+	raw, err := json.Marshal(rule)
+	if err != nil {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil
+	}
+	var expressions []string
+	var walk func(key string, parent string, value interface{})
+	walk = func(key string, parent string, value interface{}) {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			for k, v := range typed {
+				walk(k, key, v)
+			}
+		case []interface{}:
+			for _, v := range typed {
+				walk(key, parent, v)
+			}
+		case string:
+			for _, match := range regex.RegexVariables.FindAllStringSubmatch(typed, -1) {
+				expressions = append(expressions, match[2])
+			}
+			if key == "list" || (key == "jmesPath" && parent == "variable") {
+				expressions = append(expressions, typed)
+			}
+		}
+	}
+	walk("", "", document)
+	return expressions
+}
+
+// isReferenced returns true if one of the expressions contains name as a whole word that is not a field access
+func isReferenced(name string, expressions []string) bool {
+	if name == "" {
+		return false
+	}
+	for _, expression := range expressions {
+		for offset := 0; offset < len(expression); {
+			index := strings.Index(expression[offset:], name)
+			if index < 0 {
+				break
+			}
+			start, end := offset+index, offset+index+len(name)
+			if (start == 0 || !isWordChar(expression[start-1]) && expression[start-1] != '.') && (end == len(expression) || !isWordChar(expression[end])) {
+				return true
+			}
+			offset = start + 1
+		}
+	}
+	return false
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isEmptyMatch(match kyvernov1.MatchResources) bool {
+	return len(match.Any) == 0 && len(match.All) == 0 && match.UserInfo.IsEmpty() && match.ResourceDescription.IsEmpty()
+}
+
+func matchesAllPods(match kyvernov1.MatchResources) bool {
+	if len(match.All) != 0 {
+		for _, filter := range match.All {
+			if !isPodOnlyFilter(filter) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, filter := range match.Any {
+		if isPodOnlyFilter(filter) {
+			return true
+		}
+	}
+	legacy := kyvernov1.ResourceFilter{UserInfo: match.UserInfo, ResourceDescription: match.ResourceDescription}
+	return !legacy.IsEmpty() && isPodOnlyFilter(legacy)
+}
+
+// isPodOnlyFilter returns true if the filter selects Pods by kind only.
+func isPodOnlyFilter(filter kyvernov1.ResourceFilter) bool {
+	if !filter.UserInfo.IsEmpty() {
+		return false
+	}
+	description := filter.ResourceDescription
+	if description.Name != "" || len(description.Names) != 0 || len(description.Namespaces) != 0 || len(description.Annotations) != 0 {
+		return false
+	}
+	if description.Selector != nil || description.NamespaceSelector != nil || len(description.Operations) != 0 {
+		return false
+	}
+	for _, kind := range description.Kinds {
+		if kind == "Pod" || kind == "v1/Pod" {
+			return true
+		}
+	}
+	return false
+}
+
+func kindsOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == "*" || y == "*" || kindName(x) == kindName(y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func kindName(kind string) string {
+	parts := strings.Split(kind, "/")
+	return parts[len(parts)-1]
+}
+
+// mutatedPaths returns the paths modified by a standard mutate rule, array elements are represented by *.
+func mutatedPaths(mutation *kyvernov1.Mutation) []string {
+	var paths []string
+	if patch := mutation.GetPatchStrategicMerge(); patch != nil {
+		paths = append(paths, patchPaths("", patch)...)
+	}
+	if mutation.PatchesJSON6902 != "" {
+		var operations []struct {
+			Path string `json:"path"`
+		}
+		if err := yaml.Unmarshal([]byte(mutation.PatchesJSON6902), &operations); err == nil {
+			for _, operation := range operations {
+				paths = append(paths, normalizeJSONPointer(operation.Path))
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func patchPaths(prefix string, patch interface{}) []string {
+	switch typed := patch.(type) {
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			return []string{prefix}
+		}
+		var paths []string
+		for key, value := range typed {
+			if a := anchor.Parse(key); a != nil {
+				// conditions don't modify the resource
+				if !anchor.IsAddIfNotPresent(a) {
+					continue
+				}
+				key = a.Key()
+			}
+			paths = append(paths, patchPaths(prefix+"/"+key, value)...)
+		}
+		return paths
+	case []interface{}:
+		var paths []string
+		for _, value := range typed {
+			paths = append(paths, patchPaths(prefix+"/*", value)...)
+		}
+		if len(paths) == 0 {
+			return []string{prefix}
+		}
+		return paths
+	default:
+		return []string{prefix}
+	}
+}
+
+func normalizeJSONPointer(pointer string) string {
+	parts := strings.Split(pointer, "/")
+	for i, part := range parts {
+		if part == "-" || isIndex(part) {
+			parts[i] = "*"
+		} else {
+			parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func pathsOverlap(a, b []string) (string, bool) {
+	for _, x := range a {
+		for _, y := range b {
+			if isPathPrefix(x, y) {
+				return x, true
+			}
+			if isPathPrefix(y, x) {
+				return y, true
+			}
+		}
+	}
+	return "", false
+}
+
+func isPathPrefix(prefix, path string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
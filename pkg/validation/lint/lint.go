@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+type Severity string
+
+const (
+	// SeverityInfo is used for findings that are worth knowing about but usually intentional
+	SeverityInfo Severity = "info"
+	// SeverityWarning is used for findings that are likely to hurt performance or correctness
+	SeverityWarning Severity = "warning"
+	// SeverityError is used for findings that should block a policy from being merged
+	SeverityError Severity = "error"
+)
+
+// Finding is a single issue reported by a check.
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Policy   string   `json:"policy"`
+	Rule     string   `json:"rule,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Check is a named linter check applied to a set of policies.
+type Check struct {
+	// ID uniquely identifies the check, it is used to enable/disable it and to override its severity
+	ID string
+	// Description explains what the check looks for
+	Description string
+	// Severity is the default severity of findings reported by the check
+	Severity Severity
+	// Run returns the findings for the given policies, severity is set by the linter
+	Run func(policies ...kyvernov1.PolicyInterface) []Finding
+}
+
+// Config controls which checks are run and the severity of their findings.
+type Config struct {
+	// Disable is a list of check IDs that should not run
+	Disable []string `json:"disable,omitempty"`
+	// Severity overrides the default severity of checks, keyed by check ID
+	Severity map[string]Severity `json:"severity,omitempty"`
+}
+
+// Validate returns an error if the config references an unknown check or severity.
+func (c Config) Validate() error {
+	known := map[string]bool{}
+	for _, check := range Checks() {
+		known[check.ID] = true
+	}
+	for _, id := range c.Disable {
+		if !known[id] {
+			return fmt.Errorf("unknown check %s", id)
+		}
+	}
+	for id, severity := range c.Severity {
+		if !known[id] {
+			return fmt.Errorf("unknown check %s", id)
+		}
+		switch severity {
+		case SeverityInfo, SeverityWarning, SeverityError:
+		default:
+			return fmt.Errorf("invalid severity %s for check %s (must be info, warning or error)", severity, id)
+		}
+	}
+	return nil
+}
+
+// Lint runs all enabled checks against the given policies and returns findings sorted by policy, rule, check and message.
+func Lint(config Config, policies ...kyvernov1.PolicyInterface) []Finding {
+	disabled := map[string]bool{}
+	for _, id := range config.Disable {
+		disabled[id] = true
+	}
+	var findings []Finding
+	for _, check := range Checks() {
+		if disabled[check.ID] {
+			continue
+		}
+		severity := check.Severity
+		if s, ok := config.Severity[check.ID]; ok {
+			severity = s
+		}
+		for _, finding := range check.Run(policies...) {
+			finding.Check = check.ID
+			finding.Severity = severity
+			findings = append(findings, finding)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		if a.Check != b.Check {
+			return a.Check < b.Check
+		}
+		return a.Message < b.Message
+	})
+	return findings
+}
+
+func policyName(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
@@ -0,0 +1,338 @@
+package lint
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func loadPolicy(t *testing.T, raw string) kyvernov1.PolicyInterface {
+	t.Helper()
+	var policy kyvernov1.ClusterPolicy
+	assert.NoError(t, yaml.Unmarshal([]byte(raw), &policy))
+	return &policy
+}
+
+func checkIDs(findings []Finding) []string {
+	var ids []string
+	for _, finding := range findings {
+		ids = append(ids, finding.Check)
+	}
+	return ids
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []string
+		config   Config
+		want     []string
+	}{{
+		name: "clean policy",
+		policies: []string{`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: clean
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - prod
+    context:
+    - name: team
+      variable:
+        jmesPath: request.object.metadata.labels.team
+    validate:
+      message: "team {{ team }} is not allowed"
+      deny:
+        conditions:
+          any:
+          - key: "{{ team }}"
+            operator: Equals
+            value: ""
+`},
+		want: nil,
+	}, {
+		name: "api call in foreach",
+		policies: []string{`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: foreach
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - prod
+    validate:
+      foreach:
+      - list: request.object.spec.containers
+        context:
+        - name: imageData
+          imageRegistry:
+            reference: "{{ element.image }}"
+        deny:
+          conditions:
+            any:
+            - key: "{{ imageData.configData.config.User || '' }}"
+              operator: Equals
+              value: ""
+`},
+		want: []string{"external-context-in-foreach"},
+	}, {
+		name: "unused context entry",
+		policies: []string{`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: unused
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - prod
+    context:
+    - name: cm
+      configMap:
+        name: config
+        namespace: default
+    - name: unusedvar
+      variable:
+        value: foo
+    validate:
+      message: "{{ cm.data.message }}"
+      pattern:
+        metadata:
+          name: "?*"
+`},
+		want: []string{"unused-context-entry"},
+	}, {
+		name: "unconditional pod rule, wildcard and background request variables",
+		policies: []string{`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: broad
+spec:
+  background: true
+  rules:
+  - name: pods
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "{{ request.operation }} by {{ request.userInfo.username }} is not allowed"
+      pattern:
+        metadata:
+          name: "?*"
+  - name: everything
+    match:
+      any:
+      - resources:
+          kinds:
+          - "*"
+          namespaces:
+          - prod
+    validate:
+      message: "{{ request.object.metadata.name }} is required"
+      pattern:
+        metadata:
+          name: "?*"
+`},
+		want: []string{"wildcard-kind", "background-request-variables", "background-request-variables", "unconditional-pod-rule"},
+	}, {
+		name: "overlapping mutate rules",
+		policies: []string{`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: first
+spec:
+  background: false
+  rules:
+  - name: add-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - prod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            +(team): platform
+`, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: second
+spec:
+  background: false
+  rules:
+  - name: set-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - v1/Pod
+          namespaces:
+          - prod
+    mutate:
+      patchesJson6902: |-
+        - op: add
+          path: /metadata/labels/team
+          value: core
+  - name: set-annotation
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - prod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          annotations:
+            owner: core
+`},
+		want: []string{"overlapping-mutate-rules"},
+	}, {
+		name: "disabled check",
+		policies: []string{`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: broad
+spec:
+  background: false
+  rules:
+  - name: everything
+    match:
+      any:
+      - resources:
+          kinds:
+          - "*"
+    validate:
+      message: "name is required"
+      pattern:
+        metadata:
+          name: "?*"
+`},
+		config: Config{Disable: []string{"wildcard-kind"}},
+		want:   nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policies []kyvernov1.PolicyInterface
+			for _, raw := range tt.policies {
+				policies = append(policies, loadPolicy(t, raw))
+			}
+			findings := Lint(tt.config, policies...)
+			assert.Equal(t, tt.want, checkIDs(findings))
+		})
+	}
+}
+
+func TestLintSeverity(t *testing.T) {
+	policy := loadPolicy(t, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: broad
+spec:
+  background: false
+  rules:
+  - name: everything
+    match:
+      any:
+      - resources:
+          kinds:
+          - "*"
+          namespaces:
+          - prod
+    validate:
+      message: "name is required"
+      pattern:
+        metadata:
+          name: "?*"
+`)
+	findings := Lint(Config{}, policy)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, SeverityWarning, findings[0].Severity)
+	assert.Equal(t, "spec.rules[0].match", findings[0].Path)
+	findings = Lint(Config{Severity: map[string]Severity{"wildcard-kind": SeverityError}}, policy)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{{
+		name: "empty",
+	}, {
+		name:   "valid",
+		config: Config{Disable: []string{"wildcard-kind"}, Severity: map[string]Severity{"unused-context-entry": SeverityError}},
+	}, {
+		name:    "unknown disabled check",
+		config:  Config{Disable: []string{"wildcard-kinds"}},
+		wantErr: "unknown check wildcard-kinds",
+	}, {
+		name:    "unknown severity check",
+		config:  Config{Severity: map[string]Severity{"unused-context": SeverityError}},
+		wantErr: "unknown check unused-context",
+	}, {
+		name:    "invalid severity",
+		config:  Config{Severity: map[string]Severity{"unused-context-entry": "warn"}},
+		wantErr: "invalid severity warn for check unused-context-entry (must be info, warning or error)",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsReferenced(t *testing.T) {
+	expressions := []string{"request.object.metadata.name", "config.data.foo", "length(images)"}
+	assert.True(t, isReferenced("config", expressions))
+	assert.True(t, isReferenced("images", expressions))
+	assert.True(t, isReferenced("request", expressions))
+	assert.False(t, isReferenced("name", expressions))
+	assert.False(t, isReferenced("conf", expressions))
+	assert.False(t, isReferenced("image", expressions))
+	assert.False(t, isReferenced("object", expressions))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
@@ -236,6 +237,13 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			return warnings, fmt.Errorf("disabling admission processing is only allowed with validation policies")
 		}
 	}
+	if !background {
+		for _, rule := range spec.Rules {
+			if rule.HasValidateCollection() {
+				return warnings, fmt.Errorf("collection rules are only evaluated in background scans and require background processing: rule %s", rule.Name)
+			}
+		}
+	}
 
 	if warning, err := immutableGenerateFields(policy, oldPolicy); warning != "" || err != nil {
 		warnings = append(warnings, fmt.Sprintf("no synchronization will be performed to the old target resource upon policy updates: %s", warning))
@@ -592,6 +600,7 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 			for i := range ruleCopy.Mutation.Targets {
 				withTargetOnly.Mutation.Targets[i].ResourceSpec = ruleCopy.Mutation.Targets[i].ResourceSpec
 				ctx := buildContext(withTargetOnly, background, false)
+				addParamsVariables(policy.GetSpec(), ctx)
 				if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *withTargetOnly); !variables.CheckNotFoundErr(err) {
 					return fmt.Errorf("invalid variables defined at mutate.targets[%d]: %s", i, err.Error())
 				}
@@ -599,6 +608,7 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 		}
 
 		ctx := buildContext(ruleCopy, background, mutateTarget)
+		addParamsVariables(policy.GetSpec(), ctx)
 		if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *ruleCopy); !variables.CheckNotFoundErr(err) {
 			return fmt.Errorf("variable substitution failed for rule %s: %s", ruleCopy.Name, err.Error())
 		}
@@ -760,6 +770,7 @@ func buildContext(rule *kyvernov1.Rule, background bool, target bool) *enginecon
 
 	addContextVariables(rule.Context, ctx)
 	addImageVerifyVariables(rule, ctx)
+	addCollectionVariables(rule, ctx)
 
 	if rule.Validation != nil {
 		for _, fe := range rule.Validation.ForEachValidation {
@@ -811,6 +822,18 @@ func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.Mo
 	}
 }
 
+func addParamsVariables(spec *kyvernov1.Spec, ctx *enginecontext.MockContext) {
+	if spec.Params != nil {
+		ctx.AddVariable(loaders.ParamsEntryName + "*")
+	}
+}
+
+func addCollectionVariables(rule *kyvernov1.Rule, ctx *enginecontext.MockContext) {
+	if rule.HasValidateCollection() {
+		ctx.AddVariable(loaders.CollectionEntryName + "*")
+	}
+}
+
 func addImageVerifyVariables(rule *kyvernov1.Rule, ctx *enginecontext.MockContext) {
 	if rule.HasValidateImageVerification() {
 		for _, verifyImage := range rule.VerifyImages {
@@ -55,6 +55,105 @@ func Test_PolicyValidationWithInvalidVariable(t *testing.T) {
 	assert.Contains(t, err.Error(), "variable bar must match regex")
 }
 
+func Test_PolicyValidationWithParamsVariable(t *testing.T) {
+	policy := &kyverno.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterPolicy",
+			APIVersion: "kyverno.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "policy-with-params",
+		},
+		Spec: kyverno.Spec{
+			Rules: []kyverno.Rule{
+				{
+					Name: "test-rule-params-variable",
+					MatchResources: kyverno.MatchResources{
+						Any: []kyverno.ResourceFilter{
+							{
+								ResourceDescription: kyverno.ResourceDescription{
+									Kinds: []string{"Pod"},
+								},
+							},
+						},
+					},
+					Validation: &kyverno.Validation{
+						Message: "images must come from {{ params.data.registry }}",
+						Deny:    &kyverno.Deny{},
+					},
+				},
+			},
+		},
+	}
+
+	err := ValidateVariables(policy, false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "variable params.data.registry must match regex")
+
+	policy.Spec.Params = &kyverno.PolicyParams{
+		Kind: "ConfigMap",
+		Name: "params",
+	}
+	assert.Nil(t, ValidateVariables(policy, false))
+}
+
+func Test_PolicyValidationWithCollection(t *testing.T) {
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "unique-ingress-host"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "unique-ingress-host",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": ["networking.k8s.io/v1/Ingress"]
+								}
+							}
+						]
+					},
+					"validate": {
+						"message": "ingress hosts must be unique",
+						"collection": {
+							"kinds": ["networking.k8s.io/v1/Ingress"]
+						},
+						"deny": {
+							"conditions": {
+								"any": [
+									{
+										"key": "{{ length(collection[?metadata.uid != '{{ request.object.metadata.uid }}'].spec.rules[].host) }}",
+										"operator": "GreaterThan",
+										"value": 0
+									}
+								]
+							}
+						}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.Nil(t, err)
+
+	_, err = Validate(policy, nil, nil, nil, true, "", "")
+	assert.Nil(t, err)
+
+	background := false
+	policy.Spec.Background = &background
+	_, err = Validate(policy, nil, nil, nil, true, "", "")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "collection rules are only evaluated in background scans")
+}
+
 func Test_Validate_ResourceDescription_Empty(t *testing.T) {
 	var err error
 	rawResourcedescirption := []byte(`{}`)
@@ -1201,6 +1300,78 @@ func Test_patchesJson6902_Policy(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_mutateCEL_Policy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mutate   string
+		expected string
+	}{
+		{
+			name:   "valid",
+			mutate: `{"cel": {"mutations": [{"patchType": "ApplyConfiguration", "applyConfiguration": {"expression": "{\"metadata\": {\"labels\": {\"env\": \"prod\"}}}"}}]}}`,
+		},
+		{
+			name:     "combined-with-patch",
+			mutate:   `{"patchStrategicMerge": {"metadata": {"labels": {"env": "prod"}}}, "cel": {"mutations": [{"patchType": "JSONPatch", "jsonPatch": {"expression": "[]"}}]}}`,
+			expected: "only one of `cel`, `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed",
+		},
+		{
+			name:     "no-mutations",
+			mutate:   `{"cel": {}}`,
+			expected: "at least one mutation is required",
+		},
+		{
+			name:     "missing-expression",
+			mutate:   `{"cel": {"mutations": [{"patchType": "JSONPatch"}]}}`,
+			expected: "an expression is required for patch type \"JSONPatch\"",
+		},
+		{
+			name:     "invalid-expression",
+			mutate:   `{"cel": {"mutations": [{"patchType": "JSONPatch", "jsonPatch": {"expression": "[{\"op\": "}}]}}`,
+			expected: "CEL compilation errors",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawPolicy := []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "cel-mutate"
+  },
+  "spec": {
+    "background": false,
+    "rules": [
+      {
+        "name": "mutate",
+        "match": {
+          "any": [
+            {
+              "resources": {
+                "kinds": [
+                  "Pod"
+                ]
+              }
+            }
+          ]
+        },
+        "mutate": ` + tc.mutate + `
+      }
+    ]
+  }
+}`)
+			var policy *kyverno.ClusterPolicy
+			assert.NoError(t, json.Unmarshal(rawPolicy, &policy))
+			_, err := Validate(policy, nil, nil, nil, true, "", "")
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expected)
+			}
+		})
+	}
+}
+
 func Test_deny_exec(t *testing.T) {
 	var err error
 	rawPolicy := []byte(`{
@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
@@ -61,7 +62,10 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) *resour
 			configuration,
 			config.NewDefaultMetricsConfiguration(),
 			jp,
+			enginecel.NewCache(),
+			nil,
 			adapters.Client(dclient),
+			configMapResolver,
 			factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(configMapResolver),
@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -80,6 +81,9 @@ func (h *mutationHandler) HandleMutation(
 	admissionRequestTimestamp time.Time,
 	cfg config.Configuration,
 ) ([]byte, []string, error) {
+	if toggle.FromContext(ctx).ExplainAdmission() && webhookutils.ExplainRequested(policyContext.NewResource(), policyContext.OldResource()) {
+		ctx = explain.Enable(ctx)
+	}
 	mutatePatches, mutateEngineResponses, err := h.applyMutations(ctx, request, policies, policyContext, cfg)
 	if err != nil {
 		return nil, nil, err
@@ -87,7 +91,9 @@ func (h *mutationHandler) HandleMutation(
 	if toggle.FromContext(ctx).DumpMutatePatches() {
 		h.log.V(2).Info("", "generated patches", string(mutatePatches))
 	}
-	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), nil
+	warnings := webhookutils.GetWarningMessages(mutateEngineResponses)
+	warnings = append(warnings, webhookutils.GetExplainMessages(mutateEngineResponses)...)
+	return mutatePatches, warnings, nil
 }
 
 // applyMutations handles mutating webhook admission request
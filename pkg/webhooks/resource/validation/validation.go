@@ -11,10 +11,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
@@ -100,6 +102,10 @@ func (v *validationHandler) HandleValidationEnforce(
 		return false, msg, nil, nil
 	}
 
+	if toggle.FromContext(ctx).ExplainAdmission() && webhookutils.ExplainRequested(policyContext.NewResource(), policyContext.OldResource()) {
+		ctx = explain.Enable(ctx)
+	}
+
 	var engineResponses []engineapi.EngineResponse
 	failurePolicy := kyvernov1.Ignore
 	for _, policy := range policies {
@@ -158,7 +164,7 @@ func (v *validationHandler) HandleValidationEnforce(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil, engineResponses
+		return false, webhookutils.GetBlockedMessages(engineResponses), webhookutils.GetExplainMessages(engineResponses), engineResponses
 	}
 
 	go func() {
@@ -171,6 +177,7 @@ func (v *validationHandler) HandleValidationEnforce(
 
 	engineResponses = append(engineResponses, auditWarnEngineResponses...)
 	warnings := webhookutils.GetWarningMessages(engineResponses)
+	warnings = append(warnings, webhookutils.GetExplainMessages(engineResponses)...)
 	return true, "", warnings, engineResponses
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecel "github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
@@ -2081,6 +2082,9 @@ func TestValidate_failure_action_overrides(t *testing.T) {
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jp,
+		enginecel.NewCache(),
+		nil,
+		nil,
 		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
@@ -2184,6 +2188,9 @@ func Test_RuleSelector(t *testing.T) {
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jp,
+		enginecel.NewCache(),
+		nil,
+		nil,
 		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
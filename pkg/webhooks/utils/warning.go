@@ -3,7 +3,16 @@ package utils
 import (
 	"fmt"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// maxExplainWarnings is the maximum number of trace entries returned as warnings
+	maxExplainWarnings = 20
+	// maxExplainWarningLength is the maximum length of a trace warning, the API server truncates longer warnings anyway
+	maxExplainWarningLength = 256
 )
 
 func GetWarningMessages(engineResponses []engineapi.EngineResponse) []string {
@@ -18,3 +27,39 @@ func GetWarningMessages(engineResponses []engineapi.EngineResponse) []string {
 	}
 	return warnings
 }
+
+// ExplainRequested returns true if the admitted resource opted in to evaluation traces with the explain annotation
+func ExplainRequested(newResource, oldResource unstructured.Unstructured) bool {
+	resource := newResource
+	if resource.Object == nil {
+		resource = oldResource
+	}
+	return resource.GetAnnotations()[kyverno.AnnotationExplain] == "true"
+}
+
+// GetExplainMessages returns the evaluation traces of the rules, one warning per trace entry.
+// Substituted values are redacted as they can come from external data sources, the number and length of warnings are capped.
+func GetExplainMessages(engineResponses []engineapi.EngineResponse) []string {
+	var warnings []string
+	omitted := 0
+	for _, er := range engineResponses {
+		for _, rule := range er.PolicyResponse.Rules {
+			for _, entry := range rule.Trace() {
+				if len(warnings) == maxExplainWarnings {
+					omitted++
+					continue
+				}
+				entry.Value = nil
+				msg := fmt.Sprintf("policy %s.%s explain: %s", er.Policy().GetName(), rule.Name(), entry.String())
+				if len(msg) > maxExplainWarningLength {
+					msg = msg[:maxExplainWarningLength-3] + "..."
+				}
+				warnings = append(warnings, msg)
+			}
+		}
+	}
+	if omitted != 0 {
+		warnings = append(warnings, fmt.Sprintf("explain: %d more trace entries omitted", omitted))
+	}
+	return warnings
+}
@@ -1,12 +1,15 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/explain"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestGetWarningMessages(t *testing.T) {
@@ -75,3 +78,66 @@ func TestGetWarningMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExplainMessages(t *testing.T) {
+	trace := []explain.Entry{
+		{Kind: explain.Variable, Message: "{{ request.object.metadata.name }}", Value: "nginx"},
+		{Kind: explain.Pattern, Message: "pattern failed by anchor /spec/=(hostNetwork)"},
+	}
+	engineResponses := []engineapi.EngineResponse{
+		engineapi.EngineResponse{
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{
+					*engineapi.RulePass("rule-pass", engineapi.Validation, "message pass", nil),
+					engineapi.RuleFail("rule-fail", engineapi.Validation, "message fail", nil).WithTrace(trace),
+				},
+			},
+		}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+		})),
+	}
+	assert.Nil(t, GetExplainMessages(nil))
+	assert.Equal(t, []string{
+		"policy test.rule-fail explain: variable: {{ request.object.metadata.name }}",
+		"policy test.rule-fail explain: pattern: pattern failed by anchor /spec/=(hostNetwork)",
+	}, GetExplainMessages(engineResponses))
+}
+
+func TestGetExplainMessagesLimits(t *testing.T) {
+	var trace []explain.Entry
+	for i := 0; i < maxExplainWarnings+5; i++ {
+		trace = append(trace, explain.Entry{Kind: explain.Pattern, Message: strings.Repeat("x", maxExplainWarningLength)})
+	}
+	engineResponses := []engineapi.EngineResponse{
+		engineapi.EngineResponse{
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{
+					engineapi.RuleFail("rule-fail", engineapi.Validation, "message fail", nil).WithTrace(trace),
+				},
+			},
+		}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+		})),
+	}
+	warnings := GetExplainMessages(engineResponses)
+	assert.Len(t, warnings, maxExplainWarnings+1)
+	for _, warning := range warnings[:maxExplainWarnings] {
+		assert.Len(t, warning, maxExplainWarningLength)
+	}
+	assert.Equal(t, "explain: 5 more trace entries omitted", warnings[maxExplainWarnings])
+}
+
+func TestExplainRequested(t *testing.T) {
+	annotated := unstructured.Unstructured{}
+	annotated.SetAnnotations(map[string]string{"kyverno.io/explain": "true"})
+	other := unstructured.Unstructured{}
+	other.SetName("other")
+	assert.True(t, ExplainRequested(annotated, unstructured.Unstructured{}))
+	assert.True(t, ExplainRequested(unstructured.Unstructured{}, annotated))
+	assert.False(t, ExplainRequested(other, annotated))
+	assert.False(t, ExplainRequested(unstructured.Unstructured{}, unstructured.Unstructured{}))
+}